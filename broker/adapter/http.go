@@ -13,6 +13,14 @@ func (p *HttpServer) Query(c *gin.Context) {
 	panicTodo()
 }
 
+func (p *HttpServer) ListConnections(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) CountOnline(c *gin.Context) {
+	panicTodo()
+}
+
 func (p *HttpServer) Push(c *gin.Context) {
 	panicTodo()
 }
@@ -33,6 +41,46 @@ func (p *HttpServer) SignToken(c *gin.Context) {
 	panicTodo()
 }
 
+func (p *HttpServer) RefreshToken(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) RevokeToken(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) GetBlocklist(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) UpdateBlocklist(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) GetConnectionInfo(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) CreateGroup(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) DeleteGroup(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) AddGroupMembers(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) RemoveGroupMembers(c *gin.Context) {
+	panicTodo()
+}
+
+func (p *HttpServer) ListGroupMembers(c *gin.Context) {
+	panicTodo()
+}
+
 func panicTodo() {
 	panic("TODO: implementation")
 }
@@ -22,6 +22,14 @@ func (r *RpcImpl) Query(ctx context.Context, request *brokersvc.QueryRequest) (*
 	return r.svc.Query(ctx, request)
 }
 
+func (r *RpcImpl) ListConnections(ctx context.Context, request *brokersvc.ListConnectionsRequest) (*brokersvc.ListConnectionsResponse, error) {
+	return r.svc.ListConnections(ctx, request)
+}
+
+func (r *RpcImpl) CountOnline(ctx context.Context, request *brokersvc.CountOnlineRequest) (*brokersvc.CountOnlineResponse, error) {
+	return r.svc.CountOnline(ctx, request)
+}
+
 func (r *RpcImpl) Push(ctx context.Context, request *brokersvc.PushRequest) (*brokersvc.PushResponse, error) {
 	return r.svc.Push(ctx, request)
 }
@@ -41,3 +49,43 @@ func (r *RpcImpl) StopBroadcast(ctx context.Context, request *brokersvc.StopBroa
 func (r *RpcImpl) SignToken(ctx context.Context, request *brokersvc.SignTokenRequest) (*brokersvc.SignTokenResponse, error) {
 	return r.svc.SignToken(ctx, request)
 }
+
+func (r *RpcImpl) RefreshToken(ctx context.Context, request *brokersvc.RefreshTokenRequest) (*brokersvc.RefreshTokenResponse, error) {
+	return r.svc.RefreshToken(ctx, request)
+}
+
+func (r *RpcImpl) RevokeToken(ctx context.Context, request *brokersvc.RevokeTokenRequest) (*brokersvc.RevokeTokenResponse, error) {
+	return r.svc.RevokeToken(ctx, request)
+}
+
+func (r *RpcImpl) GetBlocklist(ctx context.Context, request *brokersvc.GetBlocklistRequest) (*brokersvc.GetBlocklistResponse, error) {
+	return r.svc.GetBlocklist(ctx, request)
+}
+
+func (r *RpcImpl) GetConnectionInfo(ctx context.Context, request *brokersvc.GetConnectionInfoRequest) (*brokersvc.GetConnectionInfoResponse, error) {
+	return r.svc.GetConnectionInfo(ctx, request)
+}
+
+func (r *RpcImpl) UpdateBlocklist(ctx context.Context, request *brokersvc.UpdateBlocklistRequest) (*brokersvc.UpdateBlocklistResponse, error) {
+	return r.svc.UpdateBlocklist(ctx, request)
+}
+
+func (r *RpcImpl) CreateGroup(ctx context.Context, request *brokersvc.CreateGroupRequest) (*brokersvc.CreateGroupResponse, error) {
+	return r.svc.CreateGroup(ctx, request)
+}
+
+func (r *RpcImpl) DeleteGroup(ctx context.Context, request *brokersvc.DeleteGroupRequest) (*brokersvc.DeleteGroupResponse, error) {
+	return r.svc.DeleteGroup(ctx, request)
+}
+
+func (r *RpcImpl) AddGroupMembers(ctx context.Context, request *brokersvc.AddGroupMembersRequest) (*brokersvc.AddGroupMembersResponse, error) {
+	return r.svc.AddGroupMembers(ctx, request)
+}
+
+func (r *RpcImpl) RemoveGroupMembers(ctx context.Context, request *brokersvc.RemoveGroupMembersRequest) (*brokersvc.RemoveGroupMembersResponse, error) {
+	return r.svc.RemoveGroupMembers(ctx, request)
+}
+
+func (r *RpcImpl) ListGroupMembers(ctx context.Context, request *brokersvc.ListGroupMembersRequest) (*brokersvc.ListGroupMembersResponse, error) {
+	return r.svc.ListGroupMembers(ctx, request)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/jxskiss/nonamegw/broker/internal/config"
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+)
+
+// Providers which apply the configuration to services.
+
+func newLogConfig(cfg *config.Config) *zlog.Config {
+	return &zlog.Config{
+		Level:       cfg.Log.Level,
+		Format:      cfg.Log.Format,
+		Development: cfg.Log.Development,
+		File: zlog.FileLogConfig{
+			Filename:   cfg.Log.Filename,
+			MaxSize:    cfg.Log.MaxSize,
+			MaxDays:    cfg.Log.MaxDays,
+			MaxBackups: cfg.Log.MaxBackups,
+		},
+	}
+}
+
+func newSignerConfig(cfg *config.Config) *service.SignerConfig {
+	conf := service.NewSignerConfig()
+	conf.Version = cfg.Token.Version
+	conf.SingleUse = cfg.Token.SingleUse
+	conf.TokenTTL = time.Duration(cfg.Token.TTLSec) * time.Second
+	conf.RevocationSyncInterval = time.Duration(cfg.Token.RevocationSyncIntervalSec) * time.Second
+	for _, app := range cfg.Apps {
+		if app.TokenTTLSec > 0 {
+			if conf.AppTokenTTL == nil {
+				conf.AppTokenTTL = make(map[int64]time.Duration)
+			}
+			conf.AppTokenTTL[app.AppId] = time.Duration(app.TokenTTLSec) * time.Second
+		}
+	}
+	return conf
+}
+
+func newKeyManagerConfig(cfg *config.Config) *service.KeyManagerConfig {
+	conf := service.NewKeyManagerConfig()
+	conf.Algorithm = cfg.Token.KeyAlgorithm
+	conf.RotateInterval = time.Duration(cfg.Token.KeyRotateIntervalSec) * time.Second
+	return conf
+}
+
+func newEventHandler(cfg *config.Config, connDao service.ConnectionDao, sessionDao service.SessionDao, bizapi service.BizApi) *service.EventHandler {
+	h := service.NewEventHandler(connDao, sessionDao, bizapi)
+	h.ResumeWindow = time.Duration(cfg.Limits.ResumeWindowSec) * time.Second
+	h.MaxConnections = cfg.Limits.MaxConnections
+	for _, app := range cfg.Apps {
+		if app.MaxConnections > 0 {
+			if h.AppMaxConnections == nil {
+				h.AppMaxConnections = make(map[int64]int)
+			}
+			h.AppMaxConnections[app.AppId] = app.MaxConnections
+		}
+	}
+	return h
+}
+
+func newSweeperConfig(cfg *config.Config) *service.SweeperConfig {
+	conf := service.NewSweeperConfig()
+	conf.Interval = time.Duration(cfg.Limits.SweepIntervalSec) * time.Second
+	conf.StaleAge = time.Duration(cfg.Limits.StaleConnectionSec) * time.Second
+	return conf
+}
+
+func newPublisherConfig(cfg *config.Config) *service.PublisherConfig {
+	conf := service.NewPublisherConfig()
+	conf.Workers = cfg.Limits.PublishWorkers
+	conf.QueueSize = cfg.Limits.PublishQueueSize
+	return conf
+}
+
+func newBlocklist(cfg *config.Config, dao service.BlocklistDao) *service.Blocklist {
+	b := service.NewBlocklist(dao)
+	b.CacheTTL = time.Duration(cfg.Limits.BlocklistCacheTTLSec) * time.Second
+	return b
+}
+
+// The DAO providers below select the memory implementations when the
+// memory store is configured. They keep states in process memory, thus
+// work only for a single broker instance and lose the states on restart.
+func newConnectionDao(cfg *config.Config, redisCli redis.UniversalClient) service.ConnectionDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemoryConnectionDao()
+	}
+	return dao.NewConnectionDao(redisCli)
+}
+
+func newTokenDao(cfg *config.Config, redisCli redis.UniversalClient) service.TokenDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemoryTokenDao()
+	}
+	return dao.NewTokenDao(redisCli)
+}
+
+func newGroupDao(cfg *config.Config, redisCli redis.UniversalClient) service.GroupDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemoryGroupDao()
+	}
+	return dao.NewGroupDao(redisCli)
+}
+
+func newSessionDao(cfg *config.Config, redisCli redis.UniversalClient) service.SessionDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemorySessionDao()
+	}
+	return dao.NewSessionDao(redisCli)
+}
+
+func newCometDao(cfg *config.Config, redisCli redis.UniversalClient) service.CometDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemoryCometDao()
+	}
+	return dao.NewCometDao(redisCli)
+}
+
+func newTokenKeyDao(cfg *config.Config, redisCli redis.UniversalClient) service.TokenKeyDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemoryTokenKeyDao()
+	}
+	return dao.NewTokenKeyDao(redisCli)
+}
+
+func newBlocklistDao(cfg *config.Config, redisCli redis.UniversalClient) service.BlocklistDao {
+	if cfg.Store == config.StoreMemory {
+		return dao.NewMemoryBlocklistDao()
+	}
+	return dao.NewBlocklistDao(redisCli)
+}
@@ -1,35 +1,50 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/nats-io/nats.go"
 	"google.golang.org/grpc"
 
+	"github.com/jxskiss/nonamegw/broker/internal/config"
 	"github.com/jxskiss/nonamegw/broker/service"
 	"github.com/jxskiss/nonamegw/pkg/zlog"
 	"github.com/jxskiss/nonamegw/proto/brokersvc"
 )
 
 func main() {
-	logger, prop, _ := zlog.NewLogger(&zlog.Config{
-		Level:       "debug",
-		Format:      "console",
-		Development: true,
-	})
+	configFile := flag.String("config", "", "config file, one of TOML, YAML or JSON")
+	config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile, flag.CommandLine)
+	if err != nil {
+		log.Fatalf("failed load config, err= %v", err)
+	}
+	logger, prop, err := zlog.NewLogger(newLogConfig(cfg))
+	if err != nil {
+		log.Fatalf("failed init logger, err= %v", err)
+	}
 	zlog.ReplaceGlobals(logger, prop)
 
-	app, err := InitApp()
+	app, err := InitApp(cfg)
 	if err != nil {
 		zlog.Fatalf("failed init application, err= %v", err)
 	}
 	rpcServer := grpc.NewServer()
 	brokersvc.RegisterBrokerServer(rpcServer, app.rpcImpl)
-	zlog.Infof("starting broker/rpc server listening on %v", cfg.RpcListen)
+	zlog.Infof("starting broker/rpc server listening on %v", cfg.Listen.Rpc)
 	go func() {
-		ln, err := net.Listen("tcp", cfg.RpcListen)
+		ln, err := net.Listen("tcp", cfg.Listen.Rpc)
 		if err != nil {
 			zlog.Fatalf("failed listen broker/rpc, err= %v", err)
 		}
@@ -39,32 +54,130 @@ func main() {
 		}
 	}()
 
+	var debugServer *http.Server
+	if cfg.Listen.Debug != "" {
+		debugServer = &http.Server{Addr: cfg.Listen.Debug}
+		zlog.Infof("starting debug server listening on %v", cfg.Listen.Debug)
+		go func() {
+			err := debugServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				zlog.Errorf("failed serving debug server, err= %v", err)
+			}
+		}()
+	}
+
 	exit := make(chan os.Signal, 1)
 	signal.Notify(exit, syscall.SIGINT, syscall.SIGTERM)
-	<-exit
-	rpcServer.GracefulStop()
+	sig := <-exit
+	shutdownTimeout := time.Duration(cfg.Limits.ShutdownTimeoutSec) * time.Second
+	zlog.Infof("received signal %v, shutting down in %v", sig, shutdownTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	app.Shutdown(ctx, rpcServer, debugServer)
 }
 
-// ---- configuration ---- //
+// ---- application ---- //
 
-var cfg = &Config{
-	RpcListen: "127.0.0.1:9432",
+func NewApp(
+	nats service.NatsService,
+	rpcImpl brokersvc.BrokerServer,
+	bizapi service.BizApi,
+	events *service.EventHandler,
+	registry *service.CometRegistry,
+	sweeper *service.RouteSweeper,
+	keyMgr *service.KeyManager,
+	signer service.Signer,
+	natsConn *nats.Conn,
+	redisCli redis.UniversalClient,
+) *App {
+	return &App{
+		nats:     nats,
+		rpcImpl:  rpcImpl,
+		bizapi:   bizapi,
+		events:   events,
+		registry: registry,
+		sweeper:  sweeper,
+		keyMgr:   keyMgr,
+		signer:   signer,
+		natsConn: natsConn,
+		redisCli: redisCli,
+	}
 }
 
-type Config struct {
-	RpcListen string
+type App struct {
+	nats     service.NatsService
+	rpcImpl  brokersvc.BrokerServer
+	bizapi   service.BizApi
+	events   *service.EventHandler
+	registry *service.CometRegistry
+	sweeper  *service.RouteSweeper
+	keyMgr   *service.KeyManager
+	signer   service.Signer
+	natsConn *nats.Conn
+	redisCli redis.UniversalClient
 }
 
-// ---- application ---- //
+// Shutdown stops the application in order, so that messages and events
+// in flight are delivered before the connections are closed:
+//
+//  1. stop the debug server
+//  2. stop accepting gRPC requests and wait for the running ones
+//  3. drain NATS subscriptions, wait for received messages to be handled
+//     and queued downgoing messages to be published
+//  4. stop background work and deliver the held back events
+//  5. flush batched calls to the business service
+//  6. flush and close the NATS connection, then close Redis if used
+//
+// Background work is canceled on close, batched calls are canceled
+// when ctx is done. Waiting stops when ctx is done, the remaining steps
+// are still executed but not waited for.
+func (app *App) Shutdown(ctx context.Context, rpcServer *grpc.Server, debugServer *http.Server) {
+	if debugServer != nil {
+		waitStep(ctx, "debug server", func() error {
+			return debugServer.Shutdown(ctx)
+		})
+	}
+	waitStep(ctx, "grpc server", func() error {
+		rpcServer.GracefulStop()
+		return nil
+	})
+	if ctx.Err() != nil {
+		rpcServer.Stop()
+	}
+	waitStep(ctx, "nats subscriptions", app.nats.Close)
+	waitStep(ctx, "comet registry", app.registry.Close)
+	waitStep(ctx, "route sweeper", app.sweeper.Close)
+	waitStep(ctx, "token key manager", app.keyMgr.Close)
+	waitStep(ctx, "token signer", app.signer.Close)
+	waitStep(ctx, "event handler", app.events.Close)
+	waitStep(ctx, "bizapi", func() error {
+		return app.bizapi.Close(ctx)
+	})
 
-func NewApp(nats service.NatsService, rpcImpl brokersvc.BrokerServer) *App {
-	return &App{
-		nats:    nats,
-		rpcImpl: rpcImpl,
+	if err := app.natsConn.FlushWithContext(ctx); err != nil {
+		zlog.Errorf("failed flush nats connection, err= %v", err)
+	}
+	app.natsConn.Close()
+	if app.redisCli != nil {
+		if err := app.redisCli.Close(); err != nil {
+			zlog.Errorf("failed close redis client, err= %v", err)
+		}
 	}
+	zlog.Infof("broker shutdown finished")
 }
 
-type App struct {
-	nats    service.NatsService
-	rpcImpl brokersvc.BrokerServer
+func waitStep(ctx context.Context, name string, fn func() error) {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			zlog.Errorf("failed close %s, err= %v", name, err)
+		}
+	case <-ctx.Done():
+		zlog.Warnf("timeout waiting for %s to close", name)
+	}
 }
@@ -6,20 +6,34 @@ import (
 	"github.com/google/wire"
 	"github.com/jxskiss/nonamegw/broker/adapter"
 	"github.com/jxskiss/nonamegw/broker/internal/bizapi"
-	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/internal/config"
 	"github.com/jxskiss/nonamegw/broker/internal/infra"
 	"github.com/jxskiss/nonamegw/broker/service"
 )
 
-func InitApp() (*App, error) {
+func InitApp(cfg *config.Config) (*App, error) {
 	wire.Build(
 		NewApp,
 		adapter.NewRpcImpl,
 		service.NewNatsService,
+		newEventHandler,
+		service.NewCometRegistry,
+		service.NewRouteSweeper,
+		newSweeperConfig,
+		newPublisherConfig,
+		service.NewKeyManager,
+		newKeyManagerConfig,
 		service.NewService,
+		newSignerConfig,
 		service.NewSigner,
-		dao.NewTokenDao,
-		dao.NewConnectionDao,
+		newBlocklist,
+		newTokenDao,
+		newConnectionDao,
+		newGroupDao,
+		newSessionDao,
+		newCometDao,
+		newTokenKeyDao,
+		newBlocklistDao,
 		bizapi.NewBizApiImpl,
 		infra.InitNatsClient,
 		infra.InitRedis,
@@ -8,32 +8,49 @@ package main
 import (
 	"github.com/jxskiss/nonamegw/broker/adapter"
 	"github.com/jxskiss/nonamegw/broker/internal/bizapi"
-	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/internal/config"
 	"github.com/jxskiss/nonamegw/broker/internal/infra"
 	"github.com/jxskiss/nonamegw/broker/service"
 )
 
 // Injectors from wire.go:
 
-func InitApp() (*App, error) {
-	conn, err := infra.InitNatsClient()
+func InitApp(cfg *config.Config) (*App, error) {
+	conn, err := infra.InitNatsClient(cfg)
 	if err != nil {
 		return nil, err
 	}
-	bizApi := bizapi.NewBizApiImpl()
-	client, err := infra.InitRedis()
+	publisherConfig := newPublisherConfig(cfg)
+	bizApi := bizapi.NewBizApiImpl(cfg, conn)
+	universalClient, err := infra.InitRedis(cfg)
 	if err != nil {
 		return nil, err
 	}
-	connectionDao := dao.NewConnectionDao(client)
-	tokenDao := dao.NewTokenDao(client)
-	signer := service.NewSigner(tokenDao)
-	natsService, err := service.NewNatsService(conn, bizApi, connectionDao, signer)
+	connectionDao := newConnectionDao(cfg, universalClient)
+	sessionDao := newSessionDao(cfg, universalClient)
+	eventHandler := newEventHandler(cfg, connectionDao, sessionDao, bizApi)
+	cometDao := newCometDao(cfg, universalClient)
+	cometRegistry := service.NewCometRegistry(connectionDao, cometDao, eventHandler)
+	keyManagerConfig := newKeyManagerConfig(cfg)
+	tokenKeyDao := newTokenKeyDao(cfg, universalClient)
+	keyManager, err := service.NewKeyManager(keyManagerConfig, tokenKeyDao)
 	if err != nil {
 		return nil, err
 	}
-	serviceService := service.NewService(signer, connectionDao, natsService)
+	signerConfig := newSignerConfig(cfg)
+	tokenDao := newTokenDao(cfg, universalClient)
+	signer := service.NewSigner(signerConfig, tokenDao, keyManager)
+	blocklistDao := newBlocklistDao(cfg, universalClient)
+	blocklist := newBlocklist(cfg, blocklistDao)
+	natsService, err := service.NewNatsService(conn, publisherConfig, bizApi, eventHandler, cometRegistry, keyManager, connectionDao, signer, blocklist)
+	if err != nil {
+		return nil, err
+	}
+	groupDao := newGroupDao(cfg, universalClient)
+	serviceService := service.NewService(signer, connectionDao, groupDao, natsService, cometRegistry, blocklist)
 	brokerServer := adapter.NewRpcImpl(serviceService)
-	app := NewApp(natsService, brokerServer)
+	sweeperConfig := newSweeperConfig(cfg)
+	routeSweeper := service.NewRouteSweeper(sweeperConfig, connectionDao, eventHandler)
+	app := NewApp(natsService, brokerServer, bizApi, eventHandler, cometRegistry, routeSweeper, keyManager, signer, conn, universalClient)
 	return app, nil
 }
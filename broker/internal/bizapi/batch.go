@@ -0,0 +1,204 @@
+package bizapi
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+)
+
+const (
+	// batchQueueSize is the number of full batches waiting to be delivered,
+	// Add blocks when the queue is full.
+	batchQueueSize = 16
+
+	// A failed batch is retried batchMaxAttempts times at most,
+	// waiting batchRetryInterval times the attempts between two tries.
+	batchMaxAttempts   = 3
+	batchRetryInterval = 100 * time.Millisecond
+)
+
+var metricDroppedBatchItems = new(expvar.Int)
+
+func init() {
+	service.RegisterMetric("bizapi_dropped_batch_items", metricDroppedBatchItems)
+}
+
+// BatchConfig configures batching of upgoing messages or events for an app.
+type BatchConfig struct {
+	// Window is the max duration an item waits in the batch before
+	// it is delivered.
+	Window time.Duration
+	// MaxSize is the max number of items delivered in one call.
+	MaxSize int
+}
+
+// batcher accumulates items and flushes them in one call when either
+// the batch window elapses or the size limit is reached.
+//
+// Batches are delivered in order by a single worker goroutine, a failed
+// batch is retried a few times, then it is dropped and counted.
+type batcher struct {
+	window  time.Duration
+	maxSize int
+	flush   func(ctx context.Context, items []interface{}) error
+
+	// ctx is passed to flush, it is canceled when Close gives up
+	// waiting for the queued batches.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	pending []interface{}
+	timer   *time.Timer
+	closed  bool
+	nextSeq uint64
+
+	// Batches are taken with b.mu held and queued after releasing it,
+	// sendMu and sentSeq keep them queued in the order they are taken.
+	sendMu   sync.Mutex
+	sendCond *sync.Cond
+	sentSeq  uint64
+
+	batches chan []interface{}
+	done    chan struct{}
+}
+
+func newBatcher(config *BatchConfig, flush func(ctx context.Context, items []interface{}) error) *batcher {
+	maxSize := config.MaxSize
+	if maxSize <= 0 {
+		maxSize = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &batcher{
+		window:  config.Window,
+		maxSize: maxSize,
+		flush:   flush,
+		ctx:     ctx,
+		cancel:  cancel,
+		batches: make(chan []interface{}, batchQueueSize),
+		done:    make(chan struct{}),
+	}
+	b.sendCond = sync.NewCond(&b.sendMu)
+	go b.work()
+	return b
+}
+
+// Add adds an item to the pending batch, it returns false if the batcher
+// has been closed.
+func (b *batcher) Add(item interface{}) bool {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return false
+	}
+	b.pending = append(b.pending, item)
+	if len(b.pending) >= b.maxSize {
+		items, seq := b.takeBatch()
+		b.mu.Unlock()
+		b.send(seq, items, false)
+		return true
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.onTimer)
+	}
+	b.mu.Unlock()
+	return true
+}
+
+func (b *batcher) onTimer() {
+	b.mu.Lock()
+	if b.closed || len(b.pending) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	items, seq := b.takeBatch()
+	b.mu.Unlock()
+	b.send(seq, items, false)
+}
+
+// takeBatch takes the pending items and the sequence number to queue
+// them, it must be called with b.mu held.
+func (b *batcher) takeBatch() ([]interface{}, uint64) {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	items := b.pending
+	b.pending = nil
+	seq := b.nextSeq
+	b.nextSeq++
+	return items, seq
+}
+
+// send queues items after the batches taken before it, the queue is
+// closed after the last batch.
+func (b *batcher) send(seq uint64, items []interface{}, last bool) {
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+	for b.sentSeq != seq {
+		b.sendCond.Wait()
+	}
+	if len(items) > 0 {
+		b.batches <- items
+	}
+	if last {
+		close(b.batches)
+	}
+	b.sentSeq++
+	b.sendCond.Broadcast()
+}
+
+func (b *batcher) work() {
+	defer close(b.done)
+	for items := range b.batches {
+		b.deliver(items)
+	}
+}
+
+func (b *batcher) deliver(items []interface{}) {
+	var err error
+	attempt := 0
+	for attempt < batchMaxAttempts && b.ctx.Err() == nil {
+		attempt++
+		if err = b.flush(b.ctx, items); err == nil {
+			return
+		}
+		if attempt < batchMaxAttempts {
+			select {
+			case <-b.ctx.Done():
+			case <-time.After(time.Duration(attempt) * batchRetryInterval):
+			}
+		}
+	}
+	if err == nil {
+		err = b.ctx.Err()
+	}
+	metricDroppedBatchItems.Add(int64(len(items)))
+	zlog.Errorf("dropped batch after %d attempts, count= %d, err= %v", attempt, len(items), err)
+}
+
+// Close flushes the pending items and waits for the queued batches
+// to be delivered. When ctx is done, the batches being delivered are
+// canceled and the remaining ones are dropped.
+func (b *batcher) Close(ctx context.Context) {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		items, seq := b.takeBatch()
+		// Queuing may wait for the batches being queued by Add,
+		// Close waits for it together with ctx below.
+		go b.send(seq, items, true)
+	}
+	b.mu.Unlock()
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		b.cancel()
+		<-b.done
+	}
+	b.cancel()
+}
@@ -0,0 +1,156 @@
+package bizapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+}
+
+func (r *batchRecorder) flush(ctx context.Context, items []interface{}) error {
+	r.mu.Lock()
+	r.batches = append(r.batches, items)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *batchRecorder) get() [][]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]interface{}(nil), r.batches...)
+}
+
+func TestBatcherMaxSize(t *testing.T) {
+	rec := &batchRecorder{}
+	b := newBatcher(&BatchConfig{Window: time.Hour, MaxSize: 3}, rec.flush)
+	for i := 0; i < 7; i++ {
+		assert.True(t, b.Add(i))
+	}
+	b.Close(context.Background())
+
+	batches := rec.get()
+	assert.Len(t, batches, 3)
+	total := 0
+	for _, x := range batches {
+		assert.LessOrEqual(t, len(x), 3)
+		total += len(x)
+	}
+	assert.Equal(t, 7, total)
+	assert.False(t, b.Add(8))
+}
+
+func TestBatcherWindow(t *testing.T) {
+	rec := &batchRecorder{}
+	b := newBatcher(&BatchConfig{Window: 20 * time.Millisecond, MaxSize: 100}, rec.flush)
+	b.Add(1)
+	b.Add(2)
+	assert.Len(t, rec.get(), 0)
+
+	time.Sleep(100 * time.Millisecond)
+	batches := rec.get()
+	assert.Len(t, batches, 1)
+	assert.Equal(t, []interface{}{1, 2}, batches[0])
+	b.Close(context.Background())
+}
+
+func TestBatcherOrder(t *testing.T) {
+	rec := &batchRecorder{}
+	b := newBatcher(&BatchConfig{Window: time.Millisecond, MaxSize: 2}, rec.flush)
+	for i := 0; i < 101; i++ {
+		assert.True(t, b.Add(i))
+		if i%10 == 0 {
+			time.Sleep(2 * time.Millisecond)
+		}
+	}
+	b.Close(context.Background())
+
+	var got []interface{}
+	for _, x := range rec.get() {
+		got = append(got, x...)
+	}
+	assert.Len(t, got, 101)
+	for i, x := range got {
+		assert.Equal(t, i, x)
+	}
+}
+
+func TestBatcherRetry(t *testing.T) {
+	var calls int
+	b := newBatcher(&BatchConfig{Window: time.Hour, MaxSize: 2}, func(ctx context.Context, items []interface{}) error {
+		calls++
+		if calls < batchMaxAttempts {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	b.Add(1)
+	b.Add(2)
+	b.Close(context.Background())
+	assert.Equal(t, batchMaxAttempts, calls)
+
+	dropped := metricDroppedBatchItems.Value()
+	b = newBatcher(&BatchConfig{Window: time.Hour, MaxSize: 2}, func(ctx context.Context, items []interface{}) error {
+		return errors.New("unavailable")
+	})
+	b.Add(1)
+	b.Add(2)
+	b.Close(context.Background())
+	assert.Equal(t, dropped+2, metricDroppedBatchItems.Value())
+}
+
+func TestBatcherCloseTimeout(t *testing.T) {
+	dropped := metricDroppedBatchItems.Value()
+	b := newBatcher(&BatchConfig{Window: time.Hour, MaxSize: 2}, func(ctx context.Context, items []interface{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	for i := 0; i < 6; i++ {
+		b.Add(i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	b.Close(ctx)
+	assert.Equal(t, dropped+6, metricDroppedBatchItems.Value())
+}
+
+func TestBatcherCloseWithBlockedAdd(t *testing.T) {
+	b := newBatcher(&BatchConfig{Window: time.Hour, MaxSize: 1}, func(ctx context.Context, items []interface{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	// One batch is being delivered, the queue is full, the last Add
+	// blocks until Close cancels the delivery.
+	added := make(chan struct{})
+	go func() {
+		for i := 0; i < batchQueueSize+2; i++ {
+			b.Add(i)
+		}
+		close(added)
+	}()
+	assert.Eventually(t, func() bool { return len(b.batches) == batchQueueSize }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	closed := make(chan struct{})
+	go func() {
+		b.Close(ctx)
+		close(closed)
+	}()
+	for _, ch := range []chan struct{}{added, closed} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("batcher blocked")
+		}
+	}
+	assert.False(t, b.Add(0))
+}
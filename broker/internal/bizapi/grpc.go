@@ -2,48 +2,144 @@ package bizapi
 
 import (
 	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/jxskiss/errors"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc"
+
+	"github.com/jxskiss/nonamegw/broker/internal/config"
 	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
 	"github.com/jxskiss/nonamegw/proto/bizapi"
 	"github.com/jxskiss/nonamegw/proto/protocol"
-	"google.golang.org/grpc"
-	"sync/atomic"
 )
 
-func NewBizApiImpl() service.BizApi {
-	return &bizApiImpl{}
+const batchCallTimeout = 3 * time.Second
+
+func NewBizApiImpl(cfg *config.Config, natsClient *nats.Conn) service.BizApi {
+	impl := &bizApiImpl{
+		apps:        make(map[int64]*appConfig),
+		eventRoutes: make(map[int64]map[protocol.Event_Type]*EventRoute),
+		msgBatchers: make(map[int64]*batcher),
+		evtBatchers: make(map[int64]*batcher),
+		webhook:     newWebhookClient(),
+		natsCli:     natsClient,
+	}
+	for _, app := range cfg.Apps {
+		impl.addApp(newAppConfig(app))
+	}
+	return impl
 }
 
-// FIXME
-type bizApiImpl struct {
+// appConfig tells how to deliver upgoing messages and events of an app.
+type appConfig struct {
+	AppId int64
+	Addr  string
+
+	// MessageBatch enables batching of upgoing messages if not nil.
+	MessageBatch *BatchConfig
+	// EventBatch enables batching of events if not nil.
+	EventBatch *BatchConfig
+
+	// EventRoutes specifies which events the app subscribes and where
+	// the events go, events not matching any route are dropped.
+	// If it is empty, all events are delivered through gRPC.
+	EventRoutes []*EventRoute
 }
 
-const (
-	exampleChatAppId = 1001
-	exampleChatAddr  = "127.0.0.1:9433"
-)
+func newAppConfig(conf *config.AppConfig) *appConfig {
+	app := &appConfig{
+		AppId:        conf.AppId,
+		Addr:         conf.Addr,
+		MessageBatch: newBatchConfig(conf.MessageBatch),
+		EventBatch:   newBatchConfig(conf.EventBatch),
+	}
+	for _, r := range conf.EventRoutes {
+		route := &EventRoute{
+			Sink:   EventSink(r.Sink),
+			Target: r.Target,
+		}
+		for _, typ := range r.Types {
+			route.Types = append(route.Types, protocol.Event_Type(protocol.Event_Type_value[typ]))
+		}
+		app.EventRoutes = append(app.EventRoutes, route)
+	}
+	return app
+}
+
+func newBatchConfig(conf *config.BatchConfig) *BatchConfig {
+	if conf == nil {
+		return nil
+	}
+	return &BatchConfig{
+		Window:  time.Duration(conf.WindowMsec) * time.Millisecond,
+		MaxSize: conf.MaxSize,
+	}
+}
+
+type bizApiImpl struct {
+	apps        map[int64]*appConfig
+	eventRoutes map[int64]map[protocol.Event_Type]*EventRoute
+	msgBatchers map[int64]*batcher
+	evtBatchers map[int64]*batcher
+
+	clients sync.Map // addr -> bizapi.BizApiClient
+	webhook *webhookClient
+	natsCli *nats.Conn
+}
 
-var chatClient atomic.Value
+func (p *bizApiImpl) addApp(app *appConfig) {
+	appId := app.AppId
+	p.apps[appId] = app
+	if len(app.EventRoutes) > 0 {
+		routes := make(map[protocol.Event_Type]*EventRoute)
+		for _, r := range app.EventRoutes {
+			for _, typ := range r.Types {
+				routes[typ] = r
+			}
+		}
+		p.eventRoutes[appId] = routes
+	}
+	if app.MessageBatch != nil {
+		p.msgBatchers[appId] = newBatcher(app.MessageBatch, func(ctx context.Context, items []interface{}) error {
+			return p.flushMessages(ctx, appId, items)
+		})
+	}
+	if app.EventBatch != nil {
+		p.evtBatchers[appId] = newBatcher(app.EventBatch, func(ctx context.Context, items []interface{}) error {
+			return p.flushEvents(ctx, appId, items)
+		})
+	}
+}
 
-func getChatClient() (bizapi.BizApiClient, error) {
-	if client := chatClient.Load(); client != nil {
+func (p *bizApiImpl) getClient(appId int64) (bizapi.BizApiClient, error) {
+	app := p.apps[appId]
+	if app == nil {
+		return nil, errors.Errorf("unknown app_id %v", appId)
+	}
+	if client, ok := p.clients.Load(app.Addr); ok {
 		return client.(bizapi.BizApiClient), nil
 	}
-	cc, err := grpc.Dial(exampleChatAddr, grpc.WithInsecure())
+	cc, err := grpc.Dial(app.Addr, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
-	client := bizapi.NewBizApiClient(cc)
-	chatClient.Store(client)
-	return client, nil
+	client, loaded := p.clients.LoadOrStore(app.Addr, bizapi.NewBizApiClient(cc))
+	if loaded {
+		cc.Close()
+	}
+	return client.(bizapi.BizApiClient), nil
 }
 
 func (p *bizApiImpl) OnMessage(ctx context.Context, message *protocol.Message) error {
-	conn := message.GetConn()
-	if conn.GetAppId() != exampleChatAppId {
-		return errors.Errorf("unknown app_id %v", conn.GetAppId())
+	appId := message.GetConn().GetAppId()
+	if b := p.msgBatchers[appId]; b != nil && b.Add(message) {
+		return nil
 	}
-	bizCli, err := getChatClient()
+	bizCli, err := p.getClient(appId)
 	if err != nil {
 		return errors.AddStack(err)
 	}
@@ -58,11 +154,30 @@ func (p *bizApiImpl) OnMessage(ctx context.Context, message *protocol.Message) e
 }
 
 func (p *bizApiImpl) OnEvent(ctx context.Context, event *protocol.Event) error {
-	conn := event.GetConn()
-	if conn.GetAppId() != exampleChatAppId {
-		return errors.Errorf("unknown app_id %v", conn.GetAppId())
+	appId := event.GetConn().GetAppId()
+	if p.apps[appId] == nil {
+		return errors.Errorf("unknown app_id %v", appId)
+	}
+	sink, target := SinkGrpc, ""
+	if routes := p.eventRoutes[appId]; routes != nil {
+		route := routes[event.GetType()]
+		if route == nil {
+			// The app does not subscribe this event.
+			return nil
+		}
+		sink, target = route.Sink, route.Target
+	}
+	switch sink {
+	case SinkWebhook:
+		return p.webhook.PostEvent(ctx, target, event)
+	case SinkNats:
+		return p.publishEvent(target, event)
 	}
-	bizCli, err := getChatClient()
+
+	if b := p.evtBatchers[appId]; b != nil && b.Add(event) {
+		return nil
+	}
+	bizCli, err := p.getClient(appId)
 	if err != nil {
 		return errors.AddStack(err)
 	}
@@ -75,3 +190,69 @@ func (p *bizApiImpl) OnEvent(ctx context.Context, event *protocol.Event) error {
 	}
 	return nil
 }
+
+func (p *bizApiImpl) publishEvent(subject string, event *protocol.Event) error {
+	buf, err := proto.Marshal(&bizapi.OnEventRequest{Event: event})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	err = p.natsCli.Publish(subject, buf)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *bizApiImpl) flushMessages(ctx context.Context, appId int64, items []interface{}) error {
+	bizReq := &bizapi.OnMessageBatchRequest{
+		Messages: make([]*protocol.Message, 0, len(items)),
+	}
+	for _, x := range items {
+		bizReq.Messages = append(bizReq.Messages, x.(*protocol.Message))
+	}
+	bizCli, err := p.getClient(appId)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, batchCallTimeout)
+	defer cancel()
+	_, err = bizCli.OnMessageBatch(ctx, bizReq)
+	if err != nil {
+		zlog.Warnf("failed call bizapi.OnMessageBatch, app_id= %v, count= %d, err= %v", appId, len(items), err)
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *bizApiImpl) flushEvents(ctx context.Context, appId int64, items []interface{}) error {
+	bizReq := &bizapi.OnEventBatchRequest{
+		Events: make([]*protocol.Event, 0, len(items)),
+	}
+	for _, x := range items {
+		bizReq.Events = append(bizReq.Events, x.(*protocol.Event))
+	}
+	bizCli, err := p.getClient(appId)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, batchCallTimeout)
+	defer cancel()
+	_, err = bizCli.OnEventBatch(ctx, bizReq)
+	if err != nil {
+		zlog.Warnf("failed call bizapi.OnEventBatch, app_id= %v, count= %d, err= %v", appId, len(items), err)
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// Close flushes pending batches and waits for in-flight batch calls,
+// the calls are canceled when ctx is done.
+func (p *bizApiImpl) Close(ctx context.Context) error {
+	for _, b := range p.msgBatchers {
+		b.Close(ctx)
+	}
+	for _, b := range p.evtBatchers {
+		b.Close(ctx)
+	}
+	return nil
+}
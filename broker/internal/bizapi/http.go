@@ -1 +1,47 @@
 package bizapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jxskiss/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/jxskiss/nonamegw/proto/bizapi"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+const webhookTimeout = 3 * time.Second
+
+func newWebhookClient() *webhookClient {
+	return &webhookClient{
+		httpCli: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+type webhookClient struct {
+	httpCli *http.Client
+}
+
+func (p *webhookClient) PostEvent(ctx context.Context, url string, event *protocol.Event) error {
+	body, err := protojson.Marshal(&bizapi.OnEventRequest{Event: event})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.httpCli.Do(req)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook %s responds status %d", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package bizapi
+
+import (
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+// EventSink tells where events are delivered to.
+type EventSink string
+
+const (
+	// SinkGrpc delivers events by calling BizApi.OnEvent or BizApi.OnEventBatch.
+	SinkGrpc EventSink = "grpc"
+	// SinkWebhook posts events as JSON encoded bizapi.OnEventRequest to an URL.
+	SinkWebhook EventSink = "webhook"
+	// SinkNats publishes events as protobuf encoded bizapi.OnEventRequest
+	// to a NATS subject.
+	SinkNats EventSink = "nats"
+)
+
+// EventRoute routes events of the given types to a sink.
+type EventRoute struct {
+	Types []protocol.Event_Type
+	Sink  EventSink
+
+	// Target is the URL for SinkWebhook, or the subject for SinkNats.
+	Target string
+}
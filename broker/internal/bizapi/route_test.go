@@ -0,0 +1,64 @@
+package bizapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/jxskiss/nonamegw/broker/internal/config"
+	"github.com/jxskiss/nonamegw/proto/bizapi"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+func TestEventRoutes(t *testing.T) {
+	var received []*protocol.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		req := &bizapi.OnEventRequest{}
+		assert.Nil(t, protojson.Unmarshal(body, req))
+		received = append(received, req.GetEvent())
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Apps: []*config.AppConfig{
+			{
+				AppId: 2001,
+				Addr:  "127.0.0.1:9433",
+				EventRoutes: []*config.EventRoute{
+					{
+						Types:  []string{"CONNECT", "DISCONNECT"},
+						Sink:   "webhook",
+						Target: server.URL,
+					},
+				},
+			},
+		},
+	}
+	impl := NewBizApiImpl(cfg, nil).(*bizApiImpl)
+
+	ctx := context.Background()
+	for _, typ := range []protocol.Event_Type{
+		protocol.Event_CONNECT,
+		protocol.Event_TOUCH,
+		protocol.Event_TOUCH,
+		protocol.Event_DISCONNECT,
+	} {
+		event := &protocol.Event{
+			Conn: &protocol.Connection{Id: "abc", AppId: 2001},
+			Type: typ,
+		}
+		assert.Nil(t, impl.OnEvent(ctx, event))
+	}
+	assert.Len(t, received, 2)
+	assert.Equal(t, protocol.Event_CONNECT, received[0].GetType())
+	assert.Equal(t, protocol.Event_DISCONNECT, received[1].GetType())
+
+	err := impl.OnEvent(ctx, &protocol.Event{Conn: &protocol.Connection{AppId: 3001}})
+	assert.NotNil(t, err)
+}
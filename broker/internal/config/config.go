@@ -0,0 +1,320 @@
+package config
+
+import (
+	"flag"
+	"net"
+
+	"github.com/jxskiss/errors"
+	"github.com/jxskiss/gopkg/exp/confr"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+// EnvPrefix prefixes the environment variables which override the
+// configuration, eg. BROKER_NATS_URL overrides Config.Nats.Url.
+const EnvPrefix = "BROKER"
+
+// Config is the configuration of broker.
+//
+// It is loaded from a TOML, YAML or JSON file, the file type is told
+// by the extension. Environment variables override values in the file,
+// and command line flags override environment variables.
+// Fields not given by any source take the values of their default tags.
+type Config struct {
+	// Store is where the routing table, tokens, sessions and other
+	// states are stored, one of "redis" or "memory". The memory store
+	// does not use Redis, it works only for a single broker instance,
+	// and the states are lost when the broker restarts.
+	Store string `toml:"store" yaml:"store" json:"store" default:"redis"`
+
+	Listen ListenConfig `toml:"listen" yaml:"listen" json:"listen"`
+	Nats   NatsConfig   `toml:"nats" yaml:"nats" json:"nats"`
+	Redis  RedisConfig  `toml:"redis" yaml:"redis" json:"redis"`
+	Log    LogConfig    `toml:"log" yaml:"log" json:"log"`
+	Token  TokenConfig  `toml:"token" yaml:"token" json:"token"`
+	Limits LimitsConfig `toml:"limits" yaml:"limits" json:"limits"`
+
+	Apps []*AppConfig `toml:"apps" yaml:"apps" json:"apps"`
+}
+
+type ListenConfig struct {
+	Rpc string `toml:"rpc" yaml:"rpc" json:"rpc" flag:"rpc-listen" default:"127.0.0.1:9432"`
+
+	// Debug is the address of the HTTP server serving metrics at
+	// /debug/vars, empty disables it.
+	Debug string `toml:"debug" yaml:"debug" json:"debug" flag:"debug-listen"`
+}
+
+type NatsConfig struct {
+	Url  string `toml:"url" yaml:"url" json:"url" flag:"nats-url" default:"nats://127.0.0.1:4222"`
+	Name string `toml:"name" yaml:"name" json:"name" default:"broker"`
+}
+
+const (
+	StoreRedis  = "redis"
+	StoreMemory = "memory"
+)
+
+// Redis modes.
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+
+	// RedisModeDev starts an embedded miniredis server listening on
+	// the first address, it is for development only.
+	RedisModeDev = "dev"
+)
+
+type RedisConfig struct {
+	Mode string `toml:"mode" yaml:"mode" json:"mode" flag:"redis-mode" default:"standalone"`
+
+	// Addrs are addresses of the server in standalone or dev mode,
+	// the sentinels in sentinel mode, or the seed nodes in cluster mode.
+	Addrs []string `toml:"addrs" yaml:"addrs" json:"addrs" flag:"redis-addrs" default:"127.0.0.1:6379"`
+
+	// MasterName is required in sentinel mode.
+	MasterName       string `toml:"master-name" yaml:"master-name" json:"master-name"`
+	SentinelPassword string `toml:"sentinel-password" yaml:"sentinel-password" json:"sentinel-password"`
+
+	Password string `toml:"password" yaml:"password" json:"password"`
+	DB       int    `toml:"db" yaml:"db" json:"db"`
+
+	DialTimeoutMsec  int `toml:"dial-timeout-msec" yaml:"dial-timeout-msec" json:"dial-timeout-msec" default:"50"`
+	ReadTimeoutMsec  int `toml:"read-timeout-msec" yaml:"read-timeout-msec" json:"read-timeout-msec" default:"100"`
+	WriteTimeoutMsec int `toml:"write-timeout-msec" yaml:"write-timeout-msec" json:"write-timeout-msec" default:"100"`
+	IdleTimeoutMsec  int `toml:"idle-timeout-msec" yaml:"idle-timeout-msec" json:"idle-timeout-msec" default:"1000"`
+}
+
+// LogConfig is the subset of zlog.Config which can be configured for broker.
+type LogConfig struct {
+	Level       string `toml:"level" yaml:"level" json:"level" flag:"log-level" default:"info"`
+	Format      string `toml:"format" yaml:"format" json:"format" default:"console"`
+	Development bool   `toml:"development" yaml:"development" json:"development"`
+
+	// Filename of the log file, leave empty to log to stderr.
+	Filename   string `toml:"filename" yaml:"filename" json:"filename"`
+	MaxSize    int    `toml:"max-size" yaml:"max-size" json:"max-size"`
+	MaxDays    int    `toml:"max-days" yaml:"max-days" json:"max-days"`
+	MaxBackups int    `toml:"max-backups" yaml:"max-backups" json:"max-backups"`
+}
+
+type TokenConfig struct {
+	Version   string `toml:"version" yaml:"version" json:"version" default:"1"`
+	SingleUse bool   `toml:"single-use" yaml:"single-use" json:"single-use"`
+	TTLSec    int    `toml:"ttl-sec" yaml:"ttl-sec" json:"ttl-sec" default:"600"`
+
+	KeyAlgorithm         string `toml:"key-algorithm" yaml:"key-algorithm" json:"key-algorithm" default:"hmac-sha256"`
+	KeyRotateIntervalSec int    `toml:"key-rotate-interval-sec" yaml:"key-rotate-interval-sec" json:"key-rotate-interval-sec" default:"86400"`
+
+	// RevocationSyncIntervalSec is the interval to sync token revocations
+	// into memory, revocations made by other broker instances take effect
+	// after the next sync, zero disables syncing and the store is queried
+	// to verify every token.
+	RevocationSyncIntervalSec int `toml:"revocation-sync-interval-sec" yaml:"revocation-sync-interval-sec" json:"revocation-sync-interval-sec" default:"3"`
+}
+
+type LimitsConfig struct {
+	ShutdownTimeoutSec   int `toml:"shutdown-timeout-sec" yaml:"shutdown-timeout-sec" json:"shutdown-timeout-sec" default:"30"`
+	ResumeWindowSec      int `toml:"resume-window-sec" yaml:"resume-window-sec" json:"resume-window-sec" default:"30"`
+	BlocklistCacheTTLSec int `toml:"blocklist-cache-ttl-sec" yaml:"blocklist-cache-ttl-sec" json:"blocklist-cache-ttl-sec" default:"10"`
+
+	// MaxConnections is the max number of connections of a user or device,
+	// the oldest connections are kicked when it is exceeded.
+	MaxConnections int `toml:"max-connections" yaml:"max-connections" json:"max-connections" default:"5"`
+
+	// Connections not touched in StaleConnectionSec are removed from
+	// the routing table every SweepIntervalSec, zero disables sweeping.
+	SweepIntervalSec   int `toml:"sweep-interval-sec" yaml:"sweep-interval-sec" json:"sweep-interval-sec" default:"60"`
+	StaleConnectionSec int `toml:"stale-connection-sec" yaml:"stale-connection-sec" json:"stale-connection-sec" default:"300"`
+
+	// Downgoing messages are published to comet servers by PublishWorkers
+	// goroutines, each has a queue of PublishQueueSize messages.
+	PublishWorkers   int `toml:"publish-workers" yaml:"publish-workers" json:"publish-workers" default:"8"`
+	PublishQueueSize int `toml:"publish-queue-size" yaml:"publish-queue-size" json:"publish-queue-size" default:"1024"`
+}
+
+// AppConfig tells how to deliver upgoing messages and events of an app.
+type AppConfig struct {
+	AppId int64  `toml:"app-id" yaml:"app-id" json:"app-id"`
+	Addr  string `toml:"addr" yaml:"addr" json:"addr"`
+
+	// TokenTTLSec overrides Token.TTLSec for the app if it is positive.
+	TokenTTLSec int `toml:"token-ttl-sec" yaml:"token-ttl-sec" json:"token-ttl-sec"`
+	// MaxConnections overrides Limits.MaxConnections for the app if it is positive.
+	MaxConnections int `toml:"max-connections" yaml:"max-connections" json:"max-connections"`
+
+	MessageBatch *BatchConfig `toml:"message-batch" yaml:"message-batch" json:"message-batch"`
+	EventBatch   *BatchConfig `toml:"event-batch" yaml:"event-batch" json:"event-batch"`
+
+	EventRoutes []*EventRoute `toml:"event-routes" yaml:"event-routes" json:"event-routes"`
+}
+
+type BatchConfig struct {
+	WindowMsec int `toml:"window-msec" yaml:"window-msec" json:"window-msec"`
+	MaxSize    int `toml:"max-size" yaml:"max-size" json:"max-size"`
+}
+
+type EventRoute struct {
+	// Types are names of protocol.Event_Type, eg. "CONNECT".
+	Types []string `toml:"types" yaml:"types" json:"types"`
+	// Sink is one of "grpc", "webhook" or "nats".
+	Sink   string `toml:"sink" yaml:"sink" json:"sink"`
+	Target string `toml:"target" yaml:"target" json:"target"`
+}
+
+// Load loads configuration from file, environment variables and the
+// flags in fs which must have been parsed, file may be empty.
+// The loaded configuration is validated.
+func Load(file string, fs *flag.FlagSet) (*Config, error) {
+	var files []string
+	if file != "" {
+		files = append(files, file)
+	}
+	loader := confr.New(&confr.Config{
+		DisallowUnknownFields: true,
+		EnableImplicitEnv:     true,
+		EnvPrefix:             EnvPrefix,
+		FlagSet:               fs,
+	})
+	cfg := &Config{}
+	if err := loader.Load(cfg, files...); err != nil {
+		return nil, errors.AddStack(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// RegisterFlags defines the flags which override the configuration.
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.String("rpc-listen", "", "address the broker gRPC server listens on")
+	fs.String("debug-listen", "", "address the debug HTTP server listens on")
+	fs.String("nats-url", "", "NATS server url")
+	fs.String("redis-mode", "", "Redis mode, one of standalone, sentinel, cluster or dev")
+	fs.String("redis-addrs", "", "space separated Redis server addresses")
+	fs.String("log-level", "", "log level")
+}
+
+// Validate checks the configuration is usable.
+func (c *Config) Validate() error {
+	if c.Store != StoreRedis && c.Store != StoreMemory {
+		return errors.Errorf("config: invalid store %q", c.Store)
+	}
+	if _, _, err := net.SplitHostPort(c.Listen.Rpc); err != nil {
+		return errors.Errorf("config: invalid listen.rpc %q: %v", c.Listen.Rpc, err)
+	}
+	if c.Listen.Debug != "" {
+		if _, _, err := net.SplitHostPort(c.Listen.Debug); err != nil {
+			return errors.Errorf("config: invalid listen.debug %q: %v", c.Listen.Debug, err)
+		}
+	}
+	if c.Nats.Url == "" {
+		return errors.New("config: nats.url is required")
+	}
+	if c.Store == StoreRedis {
+		if err := c.Redis.validate(); err != nil {
+			return err
+		}
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		return errors.Errorf("config: invalid log.level %q", c.Log.Level)
+	}
+	switch c.Log.Format {
+	case "json", "text", "console":
+	default:
+		return errors.Errorf("config: invalid log.format %q", c.Log.Format)
+	}
+	switch c.Token.Version {
+	case service.TokenVersion0, service.TokenVersion1:
+	default:
+		return errors.Errorf("config: invalid token.version %q", c.Token.Version)
+	}
+	switch c.Token.KeyAlgorithm {
+	case service.TokenAlgHmacSha256, service.TokenAlgEd25519:
+	default:
+		return errors.Errorf("config: invalid token.key-algorithm %q", c.Token.KeyAlgorithm)
+	}
+	if c.Token.TTLSec <= 0 || c.Token.KeyRotateIntervalSec <= 0 {
+		return errors.New("config: token.ttl-sec and token.key-rotate-interval-sec must be positive")
+	}
+	if c.Token.RevocationSyncIntervalSec < 0 {
+		return errors.New("config: token.revocation-sync-interval-sec must not be negative")
+	}
+	if c.Limits.ShutdownTimeoutSec <= 0 {
+		return errors.New("config: limits.shutdown-timeout-sec must be positive")
+	}
+	if c.Limits.ResumeWindowSec < 0 || c.Limits.BlocklistCacheTTLSec < 0 || c.Limits.MaxConnections < 0 {
+		return errors.New("config: limits must not be negative")
+	}
+	if c.Limits.PublishWorkers <= 0 || c.Limits.PublishQueueSize <= 0 {
+		return errors.New("config: limits.publish-workers and limits.publish-queue-size must be positive")
+	}
+
+	appIds := make(map[int64]bool, len(c.Apps))
+	for _, app := range c.Apps {
+		if err := app.validate(); err != nil {
+			return err
+		}
+		if appIds[app.AppId] {
+			return errors.Errorf("config: duplicate app %d", app.AppId)
+		}
+		appIds[app.AppId] = true
+	}
+	return nil
+}
+
+func (c *RedisConfig) validate() error {
+	if len(c.Addrs) == 0 {
+		return errors.New("config: redis.addrs is required")
+	}
+	switch c.Mode {
+	case RedisModeStandalone, RedisModeDev:
+	case RedisModeSentinel:
+		if c.MasterName == "" {
+			return errors.New("config: redis.master-name is required in sentinel mode")
+		}
+	case RedisModeCluster:
+		if c.DB != 0 {
+			return errors.New("config: redis.db must be 0 in cluster mode")
+		}
+	default:
+		return errors.Errorf("config: invalid redis.mode %q", c.Mode)
+	}
+	return nil
+}
+
+func (app *AppConfig) validate() error {
+	if app.AppId <= 0 {
+		return errors.Errorf("config: invalid app-id %d", app.AppId)
+	}
+	if app.Addr == "" {
+		return errors.Errorf("config: app %d: addr is required", app.AppId)
+	}
+	for _, b := range []*BatchConfig{app.MessageBatch, app.EventBatch} {
+		if b != nil && (b.WindowMsec <= 0 || b.MaxSize <= 0) {
+			return errors.Errorf("config: app %d: batch window-msec and max-size must be positive", app.AppId)
+		}
+	}
+	for _, r := range app.EventRoutes {
+		for _, typ := range r.Types {
+			if _, ok := protocol.Event_Type_value[typ]; !ok {
+				return errors.Errorf("config: app %d: unknown event type %q", app.AppId, typ)
+			}
+		}
+		switch r.Sink {
+		case "grpc":
+		case "webhook", "nats":
+			if r.Target == "" {
+				return errors.Errorf("config: app %d: target is required for %s sink", app.AppId, r.Sink)
+			}
+		default:
+			return errors.Errorf("config: app %d: unknown event sink %q", app.AppId, r.Sink)
+		}
+	}
+	return nil
+}
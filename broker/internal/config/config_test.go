@@ -0,0 +1,73 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load("../../conf/broker.toml", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "127.0.0.1:9432", cfg.Listen.Rpc)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, RedisModeStandalone, cfg.Redis.Mode)
+	require.Len(t, cfg.Apps, 1)
+	assert.Equal(t, int64(1001), cfg.Apps[0].AppId)
+	assert.Equal(t, []string{"CONNECT", "RECONNECT", "DISCONNECT", "KICKOFF"}, cfg.Apps[0].EventRoutes[0].Types)
+
+	cfg, err = Load("../../conf/broker.dev.toml", nil)
+	require.Nil(t, err)
+	assert.Equal(t, RedisModeDev, cfg.Redis.Mode)
+	assert.Equal(t, "127.0.0.1:9432", cfg.Listen.Rpc)
+	require.Len(t, cfg.Apps, 1)
+}
+
+func TestLoadOverrides(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "broker.yaml")
+	err := os.WriteFile(file, []byte("nats:\n  url: nats://10.0.0.1:4222\nredis:\n  addrs: [10.0.0.2:6379]\n"), 0644)
+	require.Nil(t, err)
+
+	os.Setenv("BROKER_REDIS_ADDRS", "10.0.0.3:6379 10.0.0.4:6379")
+	defer os.Unsetenv("BROKER_REDIS_ADDRS")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	require.Nil(t, fs.Parse([]string{"-rpc-listen", ":9000"}))
+
+	cfg, err := Load(file, fs)
+	require.Nil(t, err)
+	assert.Equal(t, ":9000", cfg.Listen.Rpc)
+	assert.Equal(t, "nats://10.0.0.1:4222", cfg.Nats.Url)
+	assert.Equal(t, []string{"10.0.0.3:6379", "10.0.0.4:6379"}, cfg.Redis.Addrs)
+	assert.Equal(t, RedisModeStandalone, cfg.Redis.Mode)
+	assert.Equal(t, 600, cfg.Token.TTLSec)
+	assert.Equal(t, 30, cfg.Limits.ShutdownTimeoutSec)
+}
+
+func TestValidate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "broker.json")
+	for _, content := range []string{
+		`{"token": {"key-algorithm": "rsa"}}`,
+		`{"log": {"level": "verbose"}}`,
+		`{"apps": [{"app-id": 1001, "addr": ":9433"}, {"app-id": 1001, "addr": ":9434"}]}`,
+		`{"apps": [{"app-id": 1001, "addr": ":9433", "event-routes": [{"types": ["FOO"], "sink": "grpc"}]}]}`,
+		`{"apps": [{"app-id": 1001, "addr": ":9433", "event-routes": [{"types": ["CONNECT"], "sink": "webhook"}]}]}`,
+		`{"redis": {"mode": "sentinel"}}`,
+		`{"redis": {"mode": "cluster", "db": 1}}`,
+		`{"store": "mysql"}`,
+		`{"unknown": 1}`,
+	} {
+		require.Nil(t, os.WriteFile(file, []byte(content), 0644))
+		_, err := Load(file, nil)
+		assert.NotNil(t, err, content)
+	}
+
+	// Redis is not used by the memory store.
+	require.Nil(t, os.WriteFile(file, []byte(`{"store": "memory", "redis": {"mode": "sentinel"}}`), 0644))
+	_, err := Load(file, nil)
+	assert.Nil(t, err)
+}
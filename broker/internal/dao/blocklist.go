@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+)
+
+func NewBlocklistDao(redisClient redis.UniversalClient) service.BlocklistDao {
+	return &blocklistDaoImpl{
+		redisCli: redisClient,
+	}
+}
+
+type blocklistDaoImpl struct {
+	redisCli redis.UniversalClient
+}
+
+func (p *blocklistDaoImpl) GetBlocklist(ctx context.Context, appId int64) (*brokersvc.Blocklist, error) {
+	var cidrsCmd, usersCmd, devicesCmd *redis.StringSliceCmd
+	_, err := p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		cidrsCmd = pipe.SMembers(ctx, blockedCidrsKey(appId))
+		usersCmd = pipe.SMembers(ctx, blockedUsersKey(appId))
+		devicesCmd = pipe.SMembers(ctx, blockedDevicesKey(appId))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	list := &brokersvc.Blocklist{
+		Cidrs:     cidrsCmd.Val(),
+		UserIds:   parseInt64s(usersCmd.Val()),
+		DeviceIds: parseInt64s(devicesCmd.Val()),
+	}
+	return list, nil
+}
+
+func (p *blocklistDaoImpl) AddBlocklist(ctx context.Context, appId int64, list *brokersvc.Blocklist) error {
+	_, err := p.redisCli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(list.Cidrs) > 0 {
+			pipe.SAdd(ctx, blockedCidrsKey(appId), stringsToInterfaces(list.Cidrs)...)
+		}
+		if len(list.UserIds) > 0 {
+			pipe.SAdd(ctx, blockedUsersKey(appId), int64sToInterfaces(list.UserIds)...)
+		}
+		if len(list.DeviceIds) > 0 {
+			pipe.SAdd(ctx, blockedDevicesKey(appId), int64sToInterfaces(list.DeviceIds)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *blocklistDaoImpl) RemoveBlocklist(ctx context.Context, appId int64, list *brokersvc.Blocklist) error {
+	_, err := p.redisCli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(list.Cidrs) > 0 {
+			pipe.SRem(ctx, blockedCidrsKey(appId), stringsToInterfaces(list.Cidrs)...)
+		}
+		if len(list.UserIds) > 0 {
+			pipe.SRem(ctx, blockedUsersKey(appId), int64sToInterfaces(list.UserIds)...)
+		}
+		if len(list.DeviceIds) > 0 {
+			pipe.SRem(ctx, blockedDevicesKey(appId), int64sToInterfaces(list.DeviceIds)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func parseInt64s(vals []string) []int64 {
+	out := make([]int64, 0, len(vals))
+	for _, x := range vals {
+		id, err := strconv.ParseInt(x, 10, 64)
+		if err != nil {
+			continue
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+func stringsToInterfaces(vals []string) []interface{} {
+	out := make([]interface{}, 0, len(vals))
+	for _, x := range vals {
+		out = append(out, x)
+	}
+	return out
+}
+
+func int64sToInterfaces(vals []int64) []interface{} {
+	out := make([]interface{}, 0, len(vals))
+	for _, x := range vals {
+		out = append(out, x)
+	}
+	return out
+}
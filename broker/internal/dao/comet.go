@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+)
+
+func NewCometDao(redisClient redis.UniversalClient) service.CometDao {
+	return &cometDaoImpl{
+		redisCli: redisClient,
+	}
+}
+
+type cometDaoImpl struct {
+	redisCli redis.UniversalClient
+}
+
+func (p *cometDaoImpl) AcquireCleanup(ctx context.Context, machineId string, ttl time.Duration) (bool, error) {
+	key := cometCleanupKey(machineId)
+	ok, err := p.redisCli.SetNX(ctx, key, time.Now().Unix(), ttl).Result()
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	return ok, nil
+}
+
+func (p *cometDaoImpl) ReleaseCleanup(ctx context.Context, machineId string) error {
+	key := cometCleanupKey(machineId)
+	err := p.redisCli.Del(ctx, key).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
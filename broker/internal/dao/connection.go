@@ -4,6 +4,7 @@ import (
 	"context"
 	stderr "errors"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -11,82 +12,125 @@ import (
 	"github.com/jxskiss/errors"
 
 	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/connid"
 	"github.com/jxskiss/nonamegw/proto/data"
 )
 
 const (
 	purgeExpiration = 10 * time.Minute
+
+	// maxPipelineKeys limits the number of keys read by one pipeline,
+	// a large request is split into several pipelines.
+	maxPipelineKeys = 500
 )
 
 var (
 	ErrInvalidUserIdDeviceId = stderr.New("invalid user_id/device_id")
 )
 
-func NewConnectionDao(redisClient *redis.Client) service.ConnectionDao {
+func NewConnectionDao(redisClient redis.UniversalClient) service.ConnectionDao {
 	return &connectionDaoImpl{
 		redisCli: redisClient,
+		now:      time.Now,
 	}
 }
 
 type connectionDaoImpl struct {
-	redisCli *redis.Client
+	redisCli redis.UniversalClient
+	now      func() time.Time
 }
 
-func (p *connectionDaoImpl) SaveConnection(ctx context.Context, connection *data.ConnectionInfo) error {
+// saveConnectionScript saves a connection and evicts the outdated ones
+// atomically, it returns the evicted IDs and values in pairs.
+//
+// KEYS: hash key, zset key
+// ARGV: connection id, connection value, score, ttl seconds, limit
+var saveConnectionScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+local limit = tonumber(ARGV[5])
+if limit <= 0 then
+	return {}
+end
+local ids = redis.call('ZREVRANGE', KEYS[2], limit, -1)
+if #ids == 0 then
+	return {}
+end
+local result = {}
+for _, id in ipairs(ids) do
+	table.insert(result, id)
+	table.insert(result, redis.call('HGET', KEYS[1], id) or '')
+end
+redis.call('ZREM', KEYS[2], unpack(ids))
+redis.call('HDEL', KEYS[1], unpack(ids))
+return result
+`)
+
+func (p *connectionDaoImpl) SaveConnection(ctx context.Context, connection *data.ConnectionInfo, limit int) ([]*data.ConnectionInfo, error) {
 	if connection.UserId <= 0 && connection.DeviceId <= 0 {
-		return errors.AddStack(ErrInvalidUserIdDeviceId)
+		return nil, errors.AddStack(ErrInvalidUserIdDeviceId)
 	}
 	buf, err := proto.Marshal(connection)
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
 
-	pipe := p.redisCli.Pipeline()
-	defer pipe.Close()
-
-	hkey, zkey := p.getConnectionKeys(connection.AppId, connection.UserId, connection.DeviceId)
-	pipe.HSet(ctx, hkey, connection.Id, buf)
-	pipe.ZAdd(ctx, zkey, &redis.Z{
-		Score:  getTimeNowScore(),
-		Member: connection.Id,
-	})
-	pipe.Expire(ctx, hkey, purgeExpiration)
-	pipe.Expire(ctx, zkey, purgeExpiration)
-	_, err = pipe.Exec(ctx)
+	appId, userId, deviceId := connection.AppId, connection.UserId, connection.DeviceId
+	hkey, zkey := p.getConnectionKeys(appId, userId, deviceId)
+	ttl := int64(purgeExpiration / time.Second)
+	score := p.getTimeNowScore()
+	result, err := saveConnectionScript.Run(ctx, p.redisCli, []string{hkey, zkey},
+		connection.Id, buf, score, ttl, limit).Result()
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
+	values, _ := result.([]interface{})
+	evicted := decodeRemovedConnections(values, appId, userId, deviceId)
 
-	go func() {
-		p.purgeOutdatedConnections(ctx, hkey, zkey)
-	}()
-
-	return nil
-}
-
-func (p *connectionDaoImpl) purgeOutdatedConnections(ctx context.Context, hkey, zkey string) {
-	invalidMembers, err := p.redisCli.ZRevRangeWithScores(ctx, zkey, 5, -1).Result()
-	if err != nil {
-		// TODO: logging
-		return
-	}
-	if len(invalidMembers) == 0 {
-		return
-	}
-	purgeScore := invalidMembers[0].Score
-	invalidConnIds := make([]string, 0, len(invalidMembers))
-	for _, m := range invalidMembers {
-		invalidConnIds = append(invalidConnIds, m.Member.(string))
-	}
+	// The machine and app indexes may be in other cluster slots,
+	// they are not updated in the script.
 	_, err = p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
-		maxScore := strconv.FormatFloat(purgeScore, 'f', 6, 64)
-		pipe.HDel(ctx, hkey, invalidConnIds...)
-		pipe.ZRemRangeByScore(ctx, zkey, "0", maxScore)
+		if mkey := getMachineKey(connection.Id); mkey != "" {
+			pipe.HSet(ctx, mkey, connection.Id, buf)
+		}
+		pipe.ZAdd(ctx, appConnectionsKey(appId), &redis.Z{Score: score, Member: connection.Id})
+		akey, member := getAppMember(appId, userId, deviceId)
+		pipe.ZAdd(ctx, akey, &redis.Z{Score: score, Member: member})
+		for _, c := range evicted {
+			if mkey := getMachineKey(c.Id); mkey != "" {
+				pipe.HDel(ctx, mkey, c.Id)
+			}
+			pipe.ZRem(ctx, appConnectionsKey(appId), c.Id)
+		}
 		return nil
 	})
 	if err != nil {
-		// TODO: logging
+		return evicted, errors.AddStack(err)
+	}
+	return evicted, nil
+}
+
+// decodeRemovedConnections decodes the id/value pairs returned by
+// saveConnectionScript and sweepConnectionsScript.
+func decodeRemovedConnections(values []interface{}, appId, userId, deviceId int64) []*data.ConnectionInfo {
+	removed := make([]*data.ConnectionInfo, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		id, _ := values[i].(string)
+		val, _ := values[i+1].(string)
+		connInfo := &data.ConnectionInfo{}
+		if val == "" || proto.Unmarshal([]byte(val), connInfo) != nil {
+			connInfo = &data.ConnectionInfo{
+				AppId:    appId,
+				UserId:   userId,
+				DeviceId: deviceId,
+			}
+		}
+		connInfo.Id = id
+		removed = append(removed, connInfo)
 	}
+	return removed
 }
 
 func (p *connectionDaoImpl) getConnectionKeys(appId, userId, deviceId int64) (hkey, zkey string) {
@@ -100,19 +144,48 @@ func (p *connectionDaoImpl) getConnectionKeys(appId, userId, deviceId int64) (hk
 	return
 }
 
+// getAppMember returns the app index key and member of a user or device.
+func getAppMember(appId, userId, deviceId int64) (key string, member int64) {
+	if userId > 0 {
+		return appUsersKey(appId), userId
+	}
+	return appDevicesKey(appId), deviceId
+}
+
+func getMachineKey(connectionId string) string {
+	connId, err := connid.ParseConnectionId(connectionId)
+	if err != nil {
+		return ""
+	}
+	return machineConnectionsKey(connId.MachineId)
+}
+
 func (p *connectionDaoImpl) DeleteConnection(ctx context.Context, appId, userId, deviceId int64, connectionId string) error {
 	if userId <= 0 && deviceId <= 0 {
 		return errors.AddStack(ErrInvalidUserIdDeviceId)
 	}
 	hkey, zkey := p.getConnectionKeys(appId, userId, deviceId)
+	var remaining *redis.IntCmd
 	_, err := p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
 		pipe.HDel(ctx, hkey, connectionId)
 		pipe.ZRem(ctx, zkey, connectionId)
+		remaining = pipe.HLen(ctx, hkey)
+		if mkey := getMachineKey(connectionId); mkey != "" {
+			pipe.HDel(ctx, mkey, connectionId)
+		}
+		pipe.ZRem(ctx, appConnectionsKey(appId), connectionId)
 		return nil
 	})
 	if err != nil {
 		return errors.AddStack(err)
 	}
+	if remaining.Val() == 0 {
+		akey, member := getAppMember(appId, userId, deviceId)
+		err = p.redisCli.ZRem(ctx, akey, member).Err()
+		if err != nil {
+			return errors.AddStack(err)
+		}
+	}
 	return nil
 }
 
@@ -121,13 +194,17 @@ func (p *connectionDaoImpl) TouchConnection(ctx context.Context, appId, userId,
 		return errors.AddStack(ErrInvalidUserIdDeviceId)
 	}
 	hkey, zkey := p.getConnectionKeys(appId, userId, deviceId)
+	score := p.getTimeNowScore()
 	_, err := p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
 		pipe.ZAdd(ctx, zkey, &redis.Z{
-			Score:  getTimeNowScore(),
+			Score:  score,
 			Member: connectionId,
 		})
 		pipe.Expire(ctx, hkey, purgeExpiration)
 		pipe.Expire(ctx, zkey, purgeExpiration)
+		pipe.ZAddXX(ctx, appConnectionsKey(appId), &redis.Z{Score: score, Member: connectionId})
+		akey, member := getAppMember(appId, userId, deviceId)
+		pipe.ZAdd(ctx, akey, &redis.Z{Score: score, Member: member})
 		return nil
 	})
 	if err != nil {
@@ -140,78 +217,346 @@ func (p *connectionDaoImpl) ListUserConnections(ctx context.Context, appId int64
 	if len(userIds) == 0 {
 		return nil, nil
 	}
-
-	hkeys := make([]string, 0, len(userIds))
+	keys := make([][2]string, 0, len(userIds))
 	for _, userId := range userIds {
-		hkeys = append(hkeys, userConnectionsHashKey(appId, userId))
+		hkey, zkey := p.getConnectionKeys(appId, userId, 0)
+		keys = append(keys, [2]string{hkey, zkey})
+	}
+	conns, err := p.listConnections(ctx, keys)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	var resultConnections = make(map[int64][]*data.ConnectionInfo)
+	for _, connInfo := range conns {
+		userId := connInfo.UserId
+		resultConnections[userId] = append(resultConnections[userId], connInfo)
 	}
+	return resultConnections, nil
+}
 
+func (p *connectionDaoImpl) ListDeviceConnections(ctx context.Context, appId int64, deviceIds []int64) (map[int64][]*data.ConnectionInfo, error) {
+	if len(deviceIds) == 0 {
+		return nil, nil
+	}
+	keys := make([][2]string, 0, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		hkey, zkey := p.getConnectionKeys(appId, 0, deviceId)
+		keys = append(keys, [2]string{hkey, zkey})
+	}
+	conns, err := p.listConnections(ctx, keys)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	var resultConnections = make(map[int64][]*data.ConnectionInfo)
+	for _, connInfo := range conns {
+		deviceId := connInfo.DeviceId
+		resultConnections[deviceId] = append(resultConnections[deviceId], connInfo)
+	}
+	return resultConnections, nil
+}
+
+// listConnections reads connections from pairs of hash key and zset key,
+// the last active time is filled by the zset scores. The keys are read
+// by pipelines of at most maxPipelineKeys keys.
+func (p *connectionDaoImpl) listConnections(ctx context.Context, keys [][2]string) ([]*data.ConnectionInfo, error) {
+	var result []*data.ConnectionInfo
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > maxPipelineKeys {
+			n = maxPipelineKeys
+		}
+		conns, err := p.pipelineListConnections(ctx, keys[:n])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, conns...)
+		keys = keys[n:]
+	}
+	return result, nil
+}
+
+func (p *connectionDaoImpl) pipelineListConnections(ctx context.Context, keys [][2]string) ([]*data.ConnectionInfo, error) {
 	pipe := p.redisCli.Pipeline()
 	defer pipe.Close()
 
-	for _, hk := range hkeys {
-		pipe.HGetAll(ctx, hk)
+	hcmds := make([]*redis.StringStringMapCmd, 0, len(keys))
+	zcmds := make([]*redis.ZSliceCmd, 0, len(keys))
+	for _, k := range keys {
+		hcmds = append(hcmds, pipe.HGetAll(ctx, k[0]))
+		zcmds = append(zcmds, pipe.ZRangeWithScores(ctx, k[1], 0, -1))
 	}
-	pipeResult, err := pipe.Exec(ctx)
+	_, err := pipe.Exec(ctx)
 	if err != nil {
 		return nil, errors.AddStack(err)
 	}
 
-	var resultConnections = make(map[int64][]*data.ConnectionInfo)
-	for _, cmd := range pipeResult {
-		val := cmd.(*redis.StringStringMapCmd).Val()
-		for _, buf := range val {
+	var result []*data.ConnectionInfo
+	for i, hcmd := range hcmds {
+		scores := make(map[string]float64)
+		for _, z := range zcmds[i].Val() {
+			member, _ := z.Member.(string)
+			scores[member] = z.Score
+		}
+		for _, buf := range hcmd.Val() {
 			connInfo := &data.ConnectionInfo{}
 			err := proto.Unmarshal([]byte(buf), connInfo)
 			if err != nil {
 				// TODO: logging
 				continue
 			}
-			userId := connInfo.UserId
-			resultConnections[userId] = append(resultConnections[userId], connInfo)
+			if score, ok := scores[connInfo.Id]; ok {
+				connInfo.LastActiveTimeMsec = int64(score)
+			}
+			result = append(result, connInfo)
 		}
 	}
-	return resultConnections, nil
+	return result, nil
 }
 
-func (p *connectionDaoImpl) ListDeviceConnections(ctx context.Context, appId int64, deviceIds []int64) (map[int64][]*data.ConnectionInfo, error) {
-	if len(deviceIds) == 0 {
-		return nil, nil
+func (p *connectionDaoImpl) ScanMachineConnections(ctx context.Context, machineId string, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error) {
+	mkey := machineConnectionsKey(machineId)
+	kvs, next, err := p.redisCli.HScan(ctx, mkey, cursor, "", count).Result()
+	if err != nil {
+		return nil, 0, errors.AddStack(err)
 	}
-
-	hkeys := make([]string, 0, len(deviceIds))
-	for _, deviceId := range deviceIds {
-		hkeys = append(hkeys, deviceConnectionsHashKey(appId, deviceId))
+	result := make([]*data.ConnectionInfo, 0, len(kvs)/2)
+	for i := 1; i < len(kvs); i += 2 {
+		connInfo := &data.ConnectionInfo{}
+		err := proto.Unmarshal([]byte(kvs[i]), connInfo)
+		if err != nil {
+			// TODO: logging
+			continue
+		}
+		result = append(result, connInfo)
 	}
+	return result, next, nil
+}
 
-	pipe := p.redisCli.Pipeline()
-	defer pipe.Close()
+func (p *connectionDaoImpl) DeleteMachineConnections(ctx context.Context, machineId string) error {
+	mkey := machineConnectionsKey(machineId)
+	err := p.redisCli.Del(ctx, mkey).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
 
-	for _, hk := range hkeys {
-		pipe.HGetAll(ctx, hk)
+func (p *connectionDaoImpl) GetConnections(ctx context.Context, appId int64, connectionIds []string) ([]*data.ConnectionInfo, error) {
+	if len(connectionIds) == 0 {
+		return nil, nil
 	}
-	pipeResult, err := pipe.Exec(ctx)
-	if err != nil {
-		return nil, errors.AddStack(err)
+	machineConnIds := make(map[string][]string)
+	for _, id := range connectionIds {
+		if mkey := getMachineKey(id); mkey != "" {
+			machineConnIds[mkey] = append(machineConnIds[mkey], id)
+		}
+	}
+	if len(machineConnIds) == 0 {
+		return nil, nil
 	}
 
-	var resultConnections = make(map[int64][]*data.ConnectionInfo)
-	for _, cmd := range pipeResult {
-		val := cmd.(*redis.StringStringMapCmd).Val()
-		for _, buf := range val {
+	var hcmds []*redis.SliceCmd
+	scores := make(map[string]*redis.FloatCmd, len(connectionIds))
+	_, err := p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for mkey, ids := range machineConnIds {
+			hcmds = append(hcmds, pipe.HMGet(ctx, mkey, ids...))
+			for _, id := range ids {
+				scores[id] = pipe.ZScore(ctx, appConnectionsKey(appId), id)
+			}
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, errors.AddStack(err)
+	}
+	var result []*data.ConnectionInfo
+	for _, cmd := range hcmds {
+		for _, val := range cmd.Val() {
+			buf, ok := val.(string)
+			if !ok {
+				continue
+			}
 			connInfo := &data.ConnectionInfo{}
 			err := proto.Unmarshal([]byte(buf), connInfo)
 			if err != nil {
 				// TODO: logging
 				continue
 			}
-			deviceId := connInfo.DeviceId
-			resultConnections[deviceId] = append(resultConnections[deviceId], connInfo)
+			if connInfo.AppId != appId {
+				continue
+			}
+			if cmd := scores[connInfo.Id]; cmd != nil && cmd.Err() == nil {
+				connInfo.LastActiveTimeMsec = int64(cmd.Val())
+			}
+			result = append(result, connInfo)
 		}
 	}
-	return resultConnections, nil
+	return result, nil
+}
+
+func (p *connectionDaoImpl) ScanAppConnections(ctx context.Context, appId int64, activeSince time.Time, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error) {
+	members, next, err := p.redisCli.ZScan(ctx, appConnectionsKey(appId), cursor, "", count).Result()
+	if err != nil {
+		return nil, 0, errors.AddStack(err)
+	}
+	minScore := getActiveSinceScore(activeSince)
+	ids := make([]string, 0, len(members)/2)
+	for i := 0; i+1 < len(members); i += 2 {
+		score, _ := strconv.ParseFloat(members[i+1], 64)
+		if score >= minScore {
+			ids = append(ids, members[i])
+		}
+	}
+	result, err := p.GetConnections(ctx, appId, ids)
+	if err != nil {
+		return nil, 0, errors.AddStack(err)
+	}
+	return result, next, nil
+}
+
+func (p *connectionDaoImpl) CountOnline(ctx context.Context, appId int64, activeSince time.Time) (*service.OnlineCount, error) {
+	min := "-inf"
+	if !activeSince.IsZero() {
+		min = strconv.FormatFloat(getActiveSinceScore(activeSince), 'f', 0, 64)
+	}
+	var users, devices, conns *redis.IntCmd
+	_, err := p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		users = pipe.ZCount(ctx, appUsersKey(appId), min, "+inf")
+		devices = pipe.ZCount(ctx, appDevicesKey(appId), min, "+inf")
+		conns = pipe.ZCount(ctx, appConnectionsKey(appId), min, "+inf")
+		return nil
+	})
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &service.OnlineCount{
+		Users:       users.Val(),
+		Devices:     devices.Val(),
+		Connections: conns.Val(),
+	}, nil
+}
+
+// getActiveSinceScore returns the min score of connections active since t.
+func getActiveSinceScore(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano() / 1e6)
+}
+
+// sweepConnectionsScript removes the connections whose score is less
+// than the given one, it returns the number of remaining connections,
+// followed by the removed IDs and values in pairs.
+//
+// KEYS: hash key, zset key
+// ARGV: max score (exclusive)
+var sweepConnectionsScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', '(' .. ARGV[1])
+if #ids == 0 then
+	return {}
+end
+local result = {0}
+for _, id in ipairs(ids) do
+	table.insert(result, id)
+	table.insert(result, redis.call('HGET', KEYS[1], id) or '')
+end
+redis.call('ZREM', KEYS[2], unpack(ids))
+redis.call('HDEL', KEYS[1], unpack(ids))
+result[1] = redis.call('HLEN', KEYS[1])
+return result
+`)
+
+func (p *connectionDaoImpl) SweepConnections(ctx context.Context, before time.Time, count int64, fn func(removed []*data.ConnectionInfo)) error {
+	maxScore := before.UnixNano() / 1e6
+	var mu sync.Mutex
+	sweepRoute := func(ctx context.Context, key string) error {
+		removed, err := p.sweepZset(ctx, key, maxScore)
+		if err != nil {
+			return errors.AddStack(err)
+		}
+		if len(removed) > 0 {
+			mu.Lock()
+			fn(removed)
+			mu.Unlock()
+		}
+		return nil
+	}
+	// The app indexes are swept after the routes, members left behind
+	// by lost events are removed without notifying.
+	sweepAppIndex := func(ctx context.Context, key string) error {
+		err := p.redisCli.ZRemRangeByScore(ctx, key, "-inf", "("+strconv.FormatInt(maxScore, 10)).Err()
+		if err != nil {
+			return errors.AddStack(err)
+		}
+		return nil
+	}
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		patterns := []string{userConnectionsZsetPattern, deviceConnectionsZsetPattern}
+		patterns = append(patterns, appIndexPatterns...)
+		for i, pattern := range patterns {
+			sweepKey := sweepRoute
+			if i >= 2 {
+				sweepKey = sweepAppIndex
+			}
+			iter := client.Scan(ctx, 0, pattern, count).Iterator()
+			for iter.Next(ctx) {
+				if err := sweepKey(ctx, iter.Val()); err != nil {
+					return err
+				}
+			}
+			if err := iter.Err(); err != nil {
+				return errors.AddStack(err)
+			}
+		}
+		return nil
+	}
+
+	// Keys are scanned on each master node of a Redis cluster.
+	if cluster, ok := p.redisCli.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scan(ctx, client)
+		})
+	}
+	return scan(ctx, p.redisCli)
+}
+
+func (p *connectionDaoImpl) sweepZset(ctx context.Context, zkey string, maxScore int64) ([]*data.ConnectionInfo, error) {
+	appId, userId, deviceId, ok := parseConnectionsZsetKey(zkey)
+	if !ok {
+		return nil, nil
+	}
+	hkey, zkey := p.getConnectionKeys(appId, userId, deviceId)
+	result, err := sweepConnectionsScript.Run(ctx, p.redisCli, []string{hkey, zkey}, maxScore).Result()
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	values, _ := result.([]interface{})
+	if len(values) == 0 {
+		return nil, nil
+	}
+	remaining, _ := values[0].(int64)
+	removed := decodeRemovedConnections(values[1:], appId, userId, deviceId)
+	_, err = p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, c := range removed {
+			if mkey := getMachineKey(c.Id); mkey != "" {
+				pipe.HDel(ctx, mkey, c.Id)
+			}
+			pipe.ZRem(ctx, appConnectionsKey(appId), c.Id)
+		}
+		if remaining == 0 {
+			akey, member := getAppMember(appId, userId, deviceId)
+			pipe.ZRem(ctx, akey, member)
+		}
+		return nil
+	})
+	if err != nil {
+		return removed, errors.AddStack(err)
+	}
+	return removed, nil
 }
 
-func getTimeNowScore() float64 {
-	return float64(time.Now().Unix())
+// getTimeNowScore returns the current time in milliseconds, so that
+// connections made in the same second are ordered.
+func (p *connectionDaoImpl) getTimeNowScore() float64 {
+	return float64(p.now().UnixNano() / 1e6)
 }
@@ -0,0 +1,191 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao/daotest"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+func newTestRedis(t *testing.T) (redis.UniversalClient, daotest.FastForward) {
+	s, err := miniredis.Run()
+	require.Nil(t, err)
+	t.Cleanup(s.Close)
+	return redis.NewClient(&redis.Options{Addr: s.Addr()}), s.FastForward
+}
+
+// testClock is the real time fast forwarded by offset.
+type testClock struct {
+	offset time.Duration
+}
+
+func (c *testClock) Now() time.Time { return time.Now().Add(c.offset) }
+
+func (c *testClock) FastForward(d time.Duration) { c.offset += d }
+
+func TestRedisConnectionDao(t *testing.T) {
+	daotest.RunConnectionDaoTests(t, purgeExpiration, func(t *testing.T) (service.ConnectionDao, daotest.FastForward) {
+		redisCli, fastForward := newTestRedis(t)
+		clock := &testClock{}
+		connDao := NewConnectionDao(redisCli)
+		connDao.(*connectionDaoImpl).now = clock.Now
+		return connDao, func(d time.Duration) {
+			fastForward(d)
+			clock.FastForward(d)
+		}
+	})
+}
+
+func TestMemoryConnectionDao(t *testing.T) {
+	daotest.RunConnectionDaoTests(t, purgeExpiration, func(t *testing.T) (service.ConnectionDao, daotest.FastForward) {
+		clock := &testClock{}
+		connDao := NewMemoryConnectionDao()
+		connDao.(*memoryConnectionDao).now = clock.Now
+		return connDao, clock.FastForward
+	})
+}
+
+func TestRedisTokenDao(t *testing.T) {
+	daotest.RunTokenDaoTests(t, func(t *testing.T) (service.TokenDao, daotest.FastForward) {
+		redisCli, fastForward := newTestRedis(t)
+		return NewTokenDao(redisCli), fastForward
+	})
+}
+
+func TestMemoryTokenDao(t *testing.T) {
+	daotest.RunTokenDaoTests(t, func(t *testing.T) (service.TokenDao, daotest.FastForward) {
+		clock := &testClock{}
+		tokenDao := NewMemoryTokenDao()
+		tokenDao.(*memoryTokenDao).now = clock.Now
+		return tokenDao, clock.FastForward
+	})
+}
+
+func TestRedisGroupDao(t *testing.T) {
+	daotest.RunGroupDaoTests(t, func(t *testing.T) service.GroupDao {
+		redisCli, _ := newTestRedis(t)
+		return NewGroupDao(redisCli)
+	})
+}
+
+func TestMemoryGroupDao(t *testing.T) {
+	daotest.RunGroupDaoTests(t, func(t *testing.T) service.GroupDao {
+		return NewMemoryGroupDao()
+	})
+}
+
+func TestRedisSessionDao(t *testing.T) {
+	daotest.RunSessionDaoTests(t, sessionExpiration, resumedExpiration, func(t *testing.T) (service.SessionDao, daotest.FastForward) {
+		redisCli, fastForward := newTestRedis(t)
+		return NewSessionDao(redisCli), fastForward
+	})
+}
+
+func TestTransferSessionScriptConflict(t *testing.T) {
+	ctx := context.Background()
+	redisCli, _ := newTestRedis(t)
+	oldKey, newKey := sessionKey(1001, 123, "c1"), sessionKey(1001, 123, "c2")
+	keys := []string{oldKey, newKey, resumedKey(1001, 123, "c1")}
+	require.Nil(t, redisCli.Set(ctx, oldKey, "old", 0).Err())
+
+	// The old session is changed after being read.
+	ok, err := transferSessionScript.Run(ctx, redisCli, keys, "stale", "", "merged", 1, 1000, "c2", 1000).Int()
+	require.Nil(t, err)
+	assert.Equal(t, 0, ok)
+	assert.Equal(t, int64(0), redisCli.Exists(ctx, newKey, keys[2]).Val())
+
+	ok, err = transferSessionScript.Run(ctx, redisCli, keys, "old", "", "merged", 1, 1000, "c2", 1000).Int()
+	require.Nil(t, err)
+	assert.Equal(t, 1, ok)
+	assert.Equal(t, "merged", redisCli.Get(ctx, newKey).Val())
+	assert.Equal(t, int64(0), redisCli.Exists(ctx, oldKey).Val())
+}
+
+func TestMemorySessionDao(t *testing.T) {
+	daotest.RunSessionDaoTests(t, sessionExpiration, resumedExpiration, func(t *testing.T) (service.SessionDao, daotest.FastForward) {
+		clock := &testClock{}
+		sessionDao := NewMemorySessionDao()
+		sessionDao.(*memorySessionDao).now = clock.Now
+		return sessionDao, clock.FastForward
+	})
+}
+
+func TestRedisCometDao(t *testing.T) {
+	daotest.RunCometDaoTests(t, func(t *testing.T) (service.CometDao, daotest.FastForward) {
+		redisCli, fastForward := newTestRedis(t)
+		return NewCometDao(redisCli), fastForward
+	})
+}
+
+func TestMemoryCometDao(t *testing.T) {
+	daotest.RunCometDaoTests(t, func(t *testing.T) (service.CometDao, daotest.FastForward) {
+		clock := &testClock{}
+		cometDao := NewMemoryCometDao()
+		cometDao.(*memoryCometDao).now = clock.Now
+		return cometDao, clock.FastForward
+	})
+}
+
+func TestRedisTokenKeyDao(t *testing.T) {
+	daotest.RunTokenKeyDaoTests(t, func(t *testing.T) (service.TokenKeyDao, daotest.FastForward) {
+		redisCli, fastForward := newTestRedis(t)
+		return NewTokenKeyDao(redisCli), fastForward
+	})
+}
+
+func TestMemoryTokenKeyDao(t *testing.T) {
+	daotest.RunTokenKeyDaoTests(t, func(t *testing.T) (service.TokenKeyDao, daotest.FastForward) {
+		clock := &testClock{}
+		tokenKeyDao := NewMemoryTokenKeyDao()
+		tokenKeyDao.(*memoryTokenKeyDao).now = clock.Now
+		return tokenKeyDao, clock.FastForward
+	})
+}
+
+func TestRedisBlocklistDao(t *testing.T) {
+	daotest.RunBlocklistDaoTests(t, func(t *testing.T) service.BlocklistDao {
+		redisCli, _ := newTestRedis(t)
+		return NewBlocklistDao(redisCli)
+	})
+}
+
+func TestMemoryBlocklistDao(t *testing.T) {
+	daotest.RunBlocklistDaoTests(t, func(t *testing.T) service.BlocklistDao {
+		return NewMemoryBlocklistDao()
+	})
+}
+
+func TestMergeSessionState(t *testing.T) {
+	old := &data.SessionState{
+		Tags:            []string{"a", "b"},
+		Attributes:      map[string]string{"x": "1", "y": "1"},
+		PendingMessages: make([][]byte, 1, 4),
+		SyncSeq:         100,
+	}
+	old.PendingMessages[0] = []byte("m1")
+	new := &data.SessionState{
+		Tags:            []string{"b", "c"},
+		Attributes:      map[string]string{"y": "2"},
+		PendingMessages: [][]byte{[]byte("m2")},
+		SyncSeq:         50,
+	}
+	merged := mergeSessionState(old, new)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, merged.Tags)
+	assert.Equal(t, map[string]string{"x": "1", "y": "2"}, merged.Attributes)
+	assert.Equal(t, [][]byte{[]byte("m1"), []byte("m2")}, merged.PendingMessages)
+	assert.Equal(t, int64(100), merged.SyncSeq)
+
+	// The merged state does not share memory with the old one.
+	merged.PendingMessages[0] = []byte("changed")
+	assert.Equal(t, []byte("m1"), old.PendingMessages[0])
+	old.PendingMessages = append(old.PendingMessages, []byte("m3"))
+	assert.Equal(t, []byte("m2"), merged.PendingMessages[1])
+}
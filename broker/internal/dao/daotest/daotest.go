@@ -0,0 +1,886 @@
+// Package daotest provides conformance tests which every implementation
+// of the service DAO interfaces must pass.
+package daotest
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/binary"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/connid"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+// FastForward advances the clock of the storage used by a DAO,
+// so that values expire.
+type FastForward func(d time.Duration)
+
+type ConnectionDaoFactory func(t *testing.T) (service.ConnectionDao, FastForward)
+
+type TokenDaoFactory func(t *testing.T) (service.TokenDao, FastForward)
+
+type GroupDaoFactory func(t *testing.T) service.GroupDao
+
+type SessionDaoFactory func(t *testing.T) (service.SessionDao, FastForward)
+
+type CometDaoFactory func(t *testing.T) (service.CometDao, FastForward)
+
+type TokenKeyDaoFactory func(t *testing.T) (service.TokenKeyDao, FastForward)
+
+type BlocklistDaoFactory func(t *testing.T) service.BlocklistDao
+
+const (
+	testAppId  = 1001
+	testUserId = 123
+)
+
+// Connections 1 and 2 are on machine 1, connection 3 is on machine 2.
+var (
+	testConnId1 = newConnId(1, 1)
+	testConnId2 = newConnId(1, 2)
+	testConnId3 = newConnId(2, 3)
+
+	testMachineId1 = getMachineId(testConnId1)
+	testMachineId2 = getMachineId(testConnId3)
+)
+
+var b32Enc = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+func newConnId(machine byte, incr uint16) string {
+	buf := make([]byte, 28)
+	buf[23] = machine
+	binary.BigEndian.PutUint16(buf[24:26], incr)
+	return b32Enc.EncodeToString(buf)
+}
+
+func getMachineId(connId string) string {
+	id, err := connid.ParseConnectionId(connId)
+	if err != nil {
+		panic(err)
+	}
+	return id.MachineId
+}
+
+// RunConnectionDaoTests runs the conformance tests of ConnectionDao,
+// routeTTL is the duration a route expires after it was last saved
+// or touched.
+func RunConnectionDaoTests(t *testing.T, routeTTL time.Duration, factory ConnectionDaoFactory) {
+	t.Run("SaveAndList", func(t *testing.T) {
+		testSaveAndList(t, factory)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		testConnectionLimit(t, factory)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		testDeleteConnection(t, factory)
+	})
+	t.Run("Expiration", func(t *testing.T) {
+		testRouteExpiration(t, routeTTL, factory)
+	})
+	t.Run("Machine", func(t *testing.T) {
+		testMachineConnections(t, factory)
+	})
+	t.Run("Sweep", func(t *testing.T) {
+		testSweepConnections(t, factory)
+	})
+	t.Run("AppQuery", func(t *testing.T) {
+		testAppQuery(t, factory)
+	})
+	t.Run("ScanWhileDeleting", func(t *testing.T) {
+		testScanWhileDeleting(t, factory)
+	})
+	t.Run("InvalidArguments", func(t *testing.T) {
+		connDao, _ := factory(t)
+		ctx := context.Background()
+		_, err := connDao.SaveConnection(ctx, &data.ConnectionInfo{Id: testConnId1, AppId: testAppId}, 0)
+		assert.NotNil(t, err)
+		assert.NotNil(t, connDao.DeleteConnection(ctx, testAppId, 0, 0, testConnId1))
+		assert.NotNil(t, connDao.TouchConnection(ctx, testAppId, 0, 0, testConnId1))
+	})
+}
+
+func newUserConn(connId string, userId int64) *data.ConnectionInfo {
+	return &data.ConnectionInfo{
+		Id:            connId,
+		AppId:         testAppId,
+		UserId:        userId,
+		DeviceId:      456,
+		ClientIp:      "127.0.0.1",
+		ClientVersion: "1.0.0",
+
+		Platform:        "ios",
+		Os:              "iOS 15.0",
+		Locale:          "en",
+		NetworkType:     "wifi",
+		Attributes:      map[string]string{"region": "eu"},
+		MachineId:       getMachineId(connId),
+		ConnectTimeMsec: 1629000000000,
+	}
+}
+
+func userConnIds(t *testing.T, connDao service.ConnectionDao, userId int64) []string {
+	conns, err := connDao.ListUserConnections(context.Background(), testAppId, []int64{userId})
+	require.Nil(t, err)
+	var ids []string
+	for _, c := range conns[userId] {
+		ids = append(ids, c.Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func machineConnIds(t *testing.T, connDao service.ConnectionDao, machineId string) []string {
+	var ids []string
+	var cursor uint64
+	for {
+		conns, next, err := connDao.ScanMachineConnections(context.Background(), machineId, cursor, 1)
+		require.Nil(t, err)
+		for _, c := range conns {
+			ids = append(ids, c.Id)
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func testSaveAndList(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, _ := factory(t)
+
+	conn := newUserConn(testConnId1, 123)
+	savedAt := time.Now()
+	evicted, err := connDao.SaveConnection(ctx, conn, 5)
+	require.Nil(t, err)
+	assert.Len(t, evicted, 0)
+	_, err = connDao.SaveConnection(ctx, newUserConn(testConnId2, 124), 5)
+	require.Nil(t, err)
+
+	deviceConn := newUserConn(testConnId3, 0)
+	deviceConn.DeviceId = 789
+	_, err = connDao.SaveConnection(ctx, deviceConn, 5)
+	require.Nil(t, err)
+
+	users, err := connDao.ListUserConnections(ctx, testAppId, []int64{123, 124, 125})
+	require.Nil(t, err)
+	require.Len(t, users[123], 1)
+	got := users[123][0]
+	assert.True(t, got.LastActiveTimeMsec >= savedAt.UnixNano()/1e6)
+	got.LastActiveTimeMsec = 0
+	assert.Equal(t, conn.String(), got.String())
+	require.Len(t, users[124], 1)
+	assert.Len(t, users[125], 0)
+
+	devices, err := connDao.ListDeviceConnections(ctx, testAppId, []int64{789, 456})
+	require.Nil(t, err)
+	require.Len(t, devices[789], 1)
+	assert.Equal(t, testConnId3, devices[789][0].Id)
+	// Connections of logged in users are not indexed by device.
+	assert.Len(t, devices[456], 0)
+
+	users, err = connDao.ListUserConnections(ctx, testAppId+1, []int64{123})
+	require.Nil(t, err)
+	assert.Len(t, users[123], 0)
+
+	// Saving again updates the connection.
+	conn.ClientVersion = "2.0.0"
+	_, err = connDao.SaveConnection(ctx, conn, 5)
+	require.Nil(t, err)
+	users, err = connDao.ListUserConnections(ctx, testAppId, []int64{123})
+	require.Nil(t, err)
+	require.Len(t, users[123], 1)
+	assert.Equal(t, "2.0.0", users[123][0].ClientVersion)
+
+	// Touching updates the last active time.
+	lastActive := users[123][0].LastActiveTimeMsec
+	time.Sleep(5 * time.Millisecond)
+	require.Nil(t, connDao.TouchConnection(ctx, testAppId, 123, 456, testConnId1))
+	users, err = connDao.ListUserConnections(ctx, testAppId, []int64{123})
+	require.Nil(t, err)
+	require.Len(t, users[123], 1)
+	assert.True(t, users[123][0].LastActiveTimeMsec > lastActive)
+	conns, err := connDao.GetConnections(ctx, testAppId, []string{testConnId1})
+	require.Nil(t, err)
+	require.Len(t, conns, 1)
+	assert.Equal(t, users[123][0].LastActiveTimeMsec, conns[0].LastActiveTimeMsec)
+}
+
+func testConnectionLimit(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, fastForward := factory(t)
+
+	for _, id := range []string{testConnId1, testConnId2} {
+		evicted, err := connDao.SaveConnection(ctx, newUserConn(id, 123), 2)
+		require.Nil(t, err)
+		assert.Len(t, evicted, 0)
+		fastForward(time.Millisecond)
+	}
+	evicted, err := connDao.SaveConnection(ctx, newUserConn(testConnId3, 123), 2)
+	require.Nil(t, err)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, newUserConn(testConnId1, 123).String(), evicted[0].String())
+
+	assert.Equal(t, []string{testConnId2, testConnId3}, userConnIds(t, connDao, 123))
+	assert.Equal(t, []string{testConnId2}, machineConnIds(t, connDao, testMachineId1))
+	assert.Equal(t, []string{testConnId3}, machineConnIds(t, connDao, testMachineId2))
+
+	// Touching makes a connection the latest.
+	fastForward(time.Millisecond)
+	require.Nil(t, connDao.TouchConnection(ctx, testAppId, 123, 456, testConnId2))
+	fastForward(time.Millisecond)
+	evicted, err = connDao.SaveConnection(ctx, newUserConn(testConnId1, 123), 2)
+	require.Nil(t, err)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, testConnId3, evicted[0].Id)
+
+	// Non-positive limit means no limit.
+	evicted, err = connDao.SaveConnection(ctx, newUserConn(testConnId3, 123), 0)
+	require.Nil(t, err)
+	assert.Len(t, evicted, 0)
+	assert.Len(t, userConnIds(t, connDao, 123), 3)
+}
+
+func testDeleteConnection(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, _ := factory(t)
+
+	for _, id := range []string{testConnId1, testConnId2} {
+		_, err := connDao.SaveConnection(ctx, newUserConn(id, 123), 5)
+		require.Nil(t, err)
+	}
+	require.Nil(t, connDao.DeleteConnection(ctx, testAppId, 123, 456, testConnId1))
+	assert.Equal(t, []string{testConnId2}, userConnIds(t, connDao, 123))
+	assert.Equal(t, []string{testConnId2}, machineConnIds(t, connDao, testMachineId1))
+
+	// Deleting a missing connection is not an error.
+	require.Nil(t, connDao.DeleteConnection(ctx, testAppId, 123, 456, testConnId1))
+	require.Nil(t, connDao.DeleteConnection(ctx, testAppId, 999, 456, testConnId3))
+}
+
+func testRouteExpiration(t *testing.T, routeTTL time.Duration, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, fastForward := factory(t)
+
+	_, err := connDao.SaveConnection(ctx, newUserConn(testConnId1, 123), 5)
+	require.Nil(t, err)
+	_, err = connDao.SaveConnection(ctx, newUserConn(testConnId2, 124), 5)
+	require.Nil(t, err)
+
+	fastForward(routeTTL - time.Minute)
+	require.Nil(t, connDao.TouchConnection(ctx, testAppId, 123, 456, testConnId1))
+	fastForward(2 * time.Minute)
+	assert.Equal(t, []string{testConnId1}, userConnIds(t, connDao, 123))
+	assert.Len(t, userConnIds(t, connDao, 124), 0)
+
+	fastForward(routeTTL)
+	assert.Len(t, userConnIds(t, connDao, 123), 0)
+}
+
+func testMachineConnections(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, _ := factory(t)
+
+	for i, id := range []string{testConnId1, testConnId2, testConnId3} {
+		_, err := connDao.SaveConnection(ctx, newUserConn(id, int64(123+i)), 5)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, []string{testConnId1, testConnId2}, machineConnIds(t, connDao, testMachineId1))
+	assert.Equal(t, []string{testConnId3}, machineConnIds(t, connDao, testMachineId2))
+
+	require.Nil(t, connDao.DeleteMachineConnections(ctx, testMachineId1))
+	assert.Len(t, machineConnIds(t, connDao, testMachineId1), 0)
+	assert.Equal(t, []string{testConnId3}, machineConnIds(t, connDao, testMachineId2))
+}
+
+// testScanWhileDeleting checks that connections deleted during a scan,
+// as the comet cleanup does, do not make the others skipped.
+func testScanWhileDeleting(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	const total = 250
+
+	saveAll := func(connDao service.ConnectionDao) {
+		for i := 0; i < total; i++ {
+			conn := newUserConn(newConnId(1, uint16(i)), int64(1000+i))
+			_, err := connDao.SaveConnection(ctx, conn, 5)
+			require.Nil(t, err)
+		}
+	}
+	deleteAll := func(connDao service.ConnectionDao, conns []*data.ConnectionInfo) {
+		for _, c := range conns {
+			require.Nil(t, connDao.DeleteConnection(ctx, c.AppId, c.UserId, c.DeviceId, c.Id))
+		}
+	}
+
+	connDao, _ := factory(t)
+	saveAll(connDao)
+	seen := make(map[string]bool)
+	var cursor uint64
+	for {
+		conns, next, err := connDao.ScanMachineConnections(ctx, testMachineId1, cursor, 100)
+		require.Nil(t, err)
+		for _, c := range conns {
+			seen[c.Id] = true
+		}
+		deleteAll(connDao, conns)
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	assert.Equal(t, total, len(seen))
+
+	connDao, _ = factory(t)
+	saveAll(connDao)
+	seen = make(map[string]bool)
+	cursor = 0
+	for {
+		conns, next, err := connDao.ScanAppConnections(ctx, testAppId, time.Time{}, cursor, 100)
+		require.Nil(t, err)
+		for _, c := range conns {
+			seen[c.Id] = true
+		}
+		deleteAll(connDao, conns)
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	assert.Equal(t, total, len(seen))
+}
+
+func testSweepConnections(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, _ := factory(t)
+
+	deviceConn := newUserConn(testConnId3, 0)
+	deviceConn.DeviceId = 789
+	_, err := connDao.SaveConnection(ctx, newUserConn(testConnId1, 123), 5)
+	require.Nil(t, err)
+	_, err = connDao.SaveConnection(ctx, deviceConn, 5)
+	require.Nil(t, err)
+	time.Sleep(5 * time.Millisecond)
+	before := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	_, err = connDao.SaveConnection(ctx, newUserConn(testConnId2, 123), 5)
+	require.Nil(t, err)
+
+	sweep := func() []*data.ConnectionInfo {
+		var removed []*data.ConnectionInfo
+		err := connDao.SweepConnections(ctx, before, 10, func(conns []*data.ConnectionInfo) {
+			removed = append(removed, conns...)
+		})
+		require.Nil(t, err)
+		sort.Slice(removed, func(i, j int) bool { return removed[i].Id < removed[j].Id })
+		return removed
+	}
+	removed := sweep()
+	require.Len(t, removed, 2)
+	assert.Equal(t, testConnId1, removed[0].Id)
+	assert.Equal(t, int64(123), removed[0].UserId)
+	assert.Equal(t, testConnId3, removed[1].Id)
+	assert.Equal(t, int64(789), removed[1].DeviceId)
+
+	assert.Equal(t, []string{testConnId2}, userConnIds(t, connDao, 123))
+	devices, err := connDao.ListDeviceConnections(ctx, testAppId, []int64{789})
+	require.Nil(t, err)
+	assert.Len(t, devices[789], 0)
+	assert.Equal(t, []string{testConnId2}, machineConnIds(t, connDao, testMachineId1))
+	assert.Len(t, machineConnIds(t, connDao, testMachineId2), 0)
+	count, err := connDao.CountOnline(ctx, testAppId, time.Time{})
+	require.Nil(t, err)
+	assert.Equal(t, &service.OnlineCount{Users: 1, Connections: 1}, count)
+
+	assert.Len(t, sweep(), 0)
+}
+
+func testAppQuery(t *testing.T, factory ConnectionDaoFactory) {
+	ctx := context.Background()
+	connDao, _ := factory(t)
+
+	deviceConn := newUserConn(testConnId3, 0)
+	deviceConn.DeviceId = 789
+	otherApp := newUserConn(newConnId(2, 4), 125)
+	otherApp.AppId = testAppId + 1
+	_, err := connDao.SaveConnection(ctx, newUserConn(testConnId1, 123), 5)
+	require.Nil(t, err)
+	_, err = connDao.SaveConnection(ctx, otherApp, 5)
+	require.Nil(t, err)
+	time.Sleep(5 * time.Millisecond)
+	activeSince := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	_, err = connDao.SaveConnection(ctx, newUserConn(testConnId2, 123), 5)
+	require.Nil(t, err)
+	_, err = connDao.SaveConnection(ctx, deviceConn, 5)
+	require.Nil(t, err)
+
+	conns, err := connDao.GetConnections(ctx, testAppId, []string{testConnId1, testConnId3, otherApp.Id, "invalid"})
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []string{testConnId1, testConnId3}, connIds(conns))
+
+	scanAll := func(activeSince time.Time) []string {
+		var ids []string
+		var cursor uint64
+		for {
+			conns, next, err := connDao.ScanAppConnections(ctx, testAppId, activeSince, cursor, 1)
+			require.Nil(t, err)
+			ids = append(ids, connIds(conns)...)
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	assert.Equal(t, []string{testConnId1, testConnId2, testConnId3}, scanAll(time.Time{}))
+	assert.Equal(t, []string{testConnId2, testConnId3}, scanAll(activeSince))
+
+	count, err := connDao.CountOnline(ctx, testAppId, time.Time{})
+	require.Nil(t, err)
+	assert.Equal(t, &service.OnlineCount{Users: 1, Devices: 1, Connections: 3}, count)
+	count, err = connDao.CountOnline(ctx, testAppId, activeSince)
+	require.Nil(t, err)
+	assert.Equal(t, &service.OnlineCount{Users: 1, Devices: 1, Connections: 2}, count)
+
+	require.Nil(t, connDao.DeleteConnection(ctx, testAppId, 123, 456, testConnId1))
+	require.Nil(t, connDao.DeleteConnection(ctx, testAppId, 123, 456, testConnId2))
+	require.Nil(t, connDao.DeleteConnection(ctx, testAppId, 0, 789, testConnId3))
+	count, err = connDao.CountOnline(ctx, testAppId, time.Time{})
+	require.Nil(t, err)
+	assert.Equal(t, &service.OnlineCount{}, count)
+	assert.Len(t, scanAll(time.Time{}), 0)
+}
+
+func connIds(conns []*data.ConnectionInfo) []string {
+	ids := make([]string, 0, len(conns))
+	for _, c := range conns {
+		ids = append(ids, c.Id)
+	}
+	return ids
+}
+
+func RunTokenDaoTests(t *testing.T, factory TokenDaoFactory) {
+	t.Run("SaveAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		tokenDao, fastForward := factory(t)
+
+		got, err := tokenDao.GetToken(ctx, "missing")
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		info := &data.TokenInfo{Id: "t1", AppId: testAppId, UserId: 123, DeviceId: 456, SignTimeMsec: 1000}
+		require.Nil(t, tokenDao.SaveToken(ctx, info, time.Minute))
+		got, err = tokenDao.GetToken(ctx, "t1")
+		require.Nil(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, info.String(), got.String())
+
+		fastForward(2 * time.Minute)
+		got, err = tokenDao.GetToken(ctx, "t1")
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("RevokeToken", func(t *testing.T) {
+		ctx := context.Background()
+		tokenDao, fastForward := factory(t)
+
+		require.Nil(t, tokenDao.RevokeToken(ctx, "t1", time.Minute))
+		revoked, err := tokenDao.IsTokenRevoked(ctx, testAppId, 123, "t1", 1000)
+		require.Nil(t, err)
+		assert.True(t, revoked)
+		revoked, err = tokenDao.IsTokenRevoked(ctx, testAppId, 0, "t1", 1000)
+		require.Nil(t, err)
+		assert.True(t, revoked)
+		revoked, err = tokenDao.IsTokenRevoked(ctx, testAppId, 123, "t2", 1000)
+		require.Nil(t, err)
+		assert.False(t, revoked)
+
+		fastForward(2 * time.Minute)
+		revoked, err = tokenDao.IsTokenRevoked(ctx, testAppId, 123, "t1", 1000)
+		require.Nil(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("RevokeUserTokens", func(t *testing.T) {
+		ctx := context.Background()
+		tokenDao, fastForward := factory(t)
+
+		require.Nil(t, tokenDao.RevokeUserTokens(ctx, testAppId, 123, 2000, time.Minute))
+		for _, tc := range []struct {
+			appId, userId int64
+			signTime      int64
+			revoked       bool
+		}{
+			{testAppId, 123, 1000, true},
+			{testAppId, 123, 2000, true},
+			{testAppId, 123, 3000, false},
+			{testAppId, 124, 1000, false},
+			{testAppId + 1, 123, 1000, false},
+		} {
+			revoked, err := tokenDao.IsTokenRevoked(ctx, tc.appId, tc.userId, "t1", tc.signTime)
+			require.Nil(t, err)
+			assert.Equal(t, tc.revoked, revoked, "%+v", tc)
+		}
+
+		fastForward(2 * time.Minute)
+		revoked, err := tokenDao.IsTokenRevoked(ctx, testAppId, 123, "t1", 1000)
+		require.Nil(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("ListRevocations", func(t *testing.T) {
+		ctx := context.Background()
+		tokenDao, _ := factory(t)
+
+		revs, cursor, err := tokenDao.ListRevocations(ctx, 0)
+		require.Nil(t, err)
+		assert.Len(t, revs, 0)
+		assert.Equal(t, uint64(0), cursor)
+
+		require.Nil(t, tokenDao.RevokeToken(ctx, "t1", time.Minute))
+		require.Nil(t, tokenDao.RevokeUserTokens(ctx, testAppId, 123, 2000, time.Minute))
+		revs, cursor, err = tokenDao.ListRevocations(ctx, 0)
+		require.Nil(t, err)
+		assert.Equal(t, []*service.TokenRevocation{
+			{Token: "t1"},
+			{AppId: testAppId, UserId: 123, BeforeMsec: 2000},
+		}, revs)
+
+		require.Nil(t, tokenDao.RevokeToken(ctx, "t2", time.Minute))
+		revs, next, err := tokenDao.ListRevocations(ctx, cursor)
+		require.Nil(t, err)
+		assert.Equal(t, []*service.TokenRevocation{{Token: "t2"}}, revs)
+		assert.True(t, next > cursor)
+
+		revs, cursor, err = tokenDao.ListRevocations(ctx, next)
+		require.Nil(t, err)
+		assert.Len(t, revs, 0)
+		assert.Equal(t, next, cursor)
+	})
+
+	t.Run("MarkTokenUsed", func(t *testing.T) {
+		ctx := context.Background()
+		tokenDao, fastForward := factory(t)
+
+		ok, err := tokenDao.MarkTokenUsed(ctx, "t1", time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+		ok, err = tokenDao.MarkTokenUsed(ctx, "t1", time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+		ok, err = tokenDao.MarkTokenUsed(ctx, "t2", time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		fastForward(2 * time.Minute)
+		ok, err = tokenDao.MarkTokenUsed(ctx, "t1", time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func RunGroupDaoTests(t *testing.T, factory GroupDaoFactory) {
+	t.Run("CreateAndDelete", func(t *testing.T) {
+		ctx := context.Background()
+		groupDao := factory(t)
+
+		got, err := groupDao.GetGroup(ctx, testAppId, "g1")
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		group := &data.GroupInfo{AppId: testAppId, GroupId: "g1", Name: "group 1", CreateTimeMsec: 1000}
+		created, err := groupDao.CreateGroup(ctx, group)
+		require.Nil(t, err)
+		assert.True(t, created)
+		created, err = groupDao.CreateGroup(ctx, &data.GroupInfo{AppId: testAppId, GroupId: "g1"})
+		require.Nil(t, err)
+		assert.False(t, created)
+
+		got, err = groupDao.GetGroup(ctx, testAppId, "g1")
+		require.Nil(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, group.String(), got.String())
+		got, err = groupDao.GetGroup(ctx, testAppId+1, "g1")
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		ok, err := groupDao.AddGroupMembers(ctx, testAppId, "g1", []int64{123, 124})
+		require.Nil(t, err)
+		assert.True(t, ok)
+		require.Nil(t, groupDao.DeleteGroup(ctx, testAppId, "g1"))
+		got, err = groupDao.GetGroup(ctx, testAppId, "g1")
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+		count, err := groupDao.CountGroupMembers(ctx, testAppId, "g1")
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), count)
+
+		ok, err = groupDao.AddGroupMembers(ctx, testAppId, "g1", []int64{123})
+		require.Nil(t, err)
+		assert.False(t, ok)
+		count, err = groupDao.CountGroupMembers(ctx, testAppId, "g1")
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		ctx := context.Background()
+		groupDao := factory(t)
+
+		_, err := groupDao.CreateGroup(ctx, &data.GroupInfo{AppId: testAppId, GroupId: "g1"})
+		require.Nil(t, err)
+		var userIds []int64
+		for i := int64(1); i <= 300; i++ {
+			userIds = append(userIds, i)
+		}
+		ok, err := groupDao.AddGroupMembers(ctx, testAppId, "g1", userIds)
+		require.Nil(t, err)
+		assert.True(t, ok)
+		require.Nil(t, groupDao.RemoveGroupMembers(ctx, testAppId, "g1", []int64{1, 2, 3, 999}))
+
+		count, err := groupDao.CountGroupMembers(ctx, testAppId, "g1")
+		require.Nil(t, err)
+		assert.Equal(t, int64(297), count)
+
+		members := make(map[int64]bool)
+		var cursor uint64
+		for {
+			ids, next, err := groupDao.ScanGroupMembers(ctx, testAppId, "g1", cursor, 50)
+			require.Nil(t, err)
+			for _, id := range ids {
+				members[id] = true
+			}
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+		assert.Len(t, members, 297)
+		assert.False(t, members[1])
+		assert.True(t, members[300])
+
+		ids, next, err := groupDao.ScanGroupMembers(ctx, testAppId, "missing", 0, 50)
+		require.Nil(t, err)
+		assert.Len(t, ids, 0)
+		assert.Equal(t, uint64(0), next)
+	})
+}
+
+func RunSessionDaoTests(t *testing.T, sessionTTL, resumedTTL time.Duration, factory SessionDaoFactory) {
+	t.Run("SaveAndDelete", func(t *testing.T) {
+		ctx := context.Background()
+		sessionDao, fastForward := factory(t)
+
+		got, err := sessionDao.GetSession(ctx, testAppId, testUserId, testConnId1)
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		state := &data.SessionState{Tags: []string{"a"}, SyncSeq: 100}
+		require.Nil(t, sessionDao.SaveSession(ctx, testAppId, testUserId, testConnId1, state))
+		got, err = sessionDao.GetSession(ctx, testAppId, testUserId, testConnId1)
+		require.Nil(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, state.String(), got.String())
+		got, err = sessionDao.GetSession(ctx, testAppId+1, testUserId, testConnId1)
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+		got, err = sessionDao.GetSession(ctx, testAppId, testUserId+1, testConnId1)
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		require.Nil(t, sessionDao.DeleteSession(ctx, testAppId, testUserId, testConnId1))
+		got, err = sessionDao.GetSession(ctx, testAppId, testUserId, testConnId1)
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		require.Nil(t, sessionDao.SaveSession(ctx, testAppId, testUserId, testConnId1, state))
+		fastForward(sessionTTL + time.Second)
+		got, err = sessionDao.GetSession(ctx, testAppId, testUserId, testConnId1)
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Transfer", func(t *testing.T) {
+		ctx := context.Background()
+		sessionDao, fastForward := factory(t)
+
+		merged, err := sessionDao.TransferSession(ctx, testAppId, testUserId, testConnId1, testConnId2)
+		require.Nil(t, err)
+		assert.Nil(t, merged)
+		resumed, err := sessionDao.IsResumed(ctx, testAppId, testUserId, testConnId1)
+		require.Nil(t, err)
+		assert.True(t, resumed)
+
+		old := &data.SessionState{Tags: []string{"a"}, SyncSeq: 100}
+		new := &data.SessionState{Tags: []string{"b"}, SyncSeq: 50}
+		require.Nil(t, sessionDao.SaveSession(ctx, testAppId, testUserId, testConnId2, old))
+		require.Nil(t, sessionDao.SaveSession(ctx, testAppId, testUserId, testConnId3, new))
+		merged, err = sessionDao.TransferSession(ctx, testAppId, testUserId, testConnId2, testConnId3)
+		require.Nil(t, err)
+		require.NotNil(t, merged)
+		assert.ElementsMatch(t, []string{"a", "b"}, merged.Tags)
+		assert.Equal(t, int64(100), merged.SyncSeq)
+
+		got, err := sessionDao.GetSession(ctx, testAppId, testUserId, testConnId3)
+		require.Nil(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, merged.String(), got.String())
+		got, err = sessionDao.GetSession(ctx, testAppId, testUserId, testConnId2)
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+		resumed, err = sessionDao.IsResumed(ctx, testAppId, testUserId, testConnId3)
+		require.Nil(t, err)
+		assert.False(t, resumed)
+
+		fastForward(resumedTTL + time.Second)
+		resumed, err = sessionDao.IsResumed(ctx, testAppId, testUserId, testConnId2)
+		require.Nil(t, err)
+		assert.False(t, resumed)
+	})
+
+	t.Run("TransferResumed", func(t *testing.T) {
+		ctx := context.Background()
+		sessionDao, _ := factory(t)
+
+		old := &data.SessionState{Tags: []string{"a"}, SyncSeq: 100}
+		require.Nil(t, sessionDao.SaveSession(ctx, testAppId, testUserId, testConnId1, old))
+		merged, err := sessionDao.TransferSession(ctx, testAppId, testUserId, testConnId1, testConnId2)
+		require.Nil(t, err)
+		require.NotNil(t, merged)
+
+		// A session is resumed only once.
+		merged, err = sessionDao.TransferSession(ctx, testAppId, testUserId, testConnId1, testConnId3)
+		require.Nil(t, err)
+		assert.Nil(t, merged)
+		got, err := sessionDao.GetSession(ctx, testAppId, testUserId, testConnId2)
+		require.Nil(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, []string{"a"}, got.Tags)
+	})
+}
+
+func RunCometDaoTests(t *testing.T, factory CometDaoFactory) {
+	t.Run("Cleanup", func(t *testing.T) {
+		ctx := context.Background()
+		cometDao, fastForward := factory(t)
+
+		ok, err := cometDao.AcquireCleanup(ctx, testMachineId1, time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+		ok, err = cometDao.AcquireCleanup(ctx, testMachineId1, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+		ok, err = cometDao.AcquireCleanup(ctx, testMachineId2, time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		require.Nil(t, cometDao.ReleaseCleanup(ctx, testMachineId1))
+		ok, err = cometDao.AcquireCleanup(ctx, testMachineId1, time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		fastForward(2 * time.Minute)
+		ok, err = cometDao.AcquireCleanup(ctx, testMachineId2, time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func RunTokenKeyDaoTests(t *testing.T, factory TokenKeyDaoFactory) {
+	t.Run("SaveAndDelete", func(t *testing.T) {
+		ctx := context.Background()
+		tokenKeyDao, _ := factory(t)
+
+		keys, err := tokenKeyDao.ListTokenKeys(ctx)
+		require.Nil(t, err)
+		assert.Len(t, keys, 0)
+
+		key1 := &data.TokenKeyInfo{KeyId: "k1", Secret: []byte("s1"), EnableTimeSec: 1000}
+		key2 := &data.TokenKeyInfo{KeyId: "k2", Secret: []byte("s2"), EnableTimeSec: 2000}
+		require.Nil(t, tokenKeyDao.SaveTokenKey(ctx, key1))
+		require.Nil(t, tokenKeyDao.SaveTokenKey(ctx, key2))
+		keys, err = tokenKeyDao.ListTokenKeys(ctx)
+		require.Nil(t, err)
+		sort.Slice(keys, func(i, j int) bool { return keys[i].KeyId < keys[j].KeyId })
+		require.Len(t, keys, 2)
+		assert.Equal(t, key1.String(), keys[0].String())
+		assert.Equal(t, key2.String(), keys[1].String())
+
+		require.Nil(t, tokenKeyDao.DeleteTokenKeys(ctx))
+		require.Nil(t, tokenKeyDao.DeleteTokenKeys(ctx, "k1", "missing"))
+		keys, err = tokenKeyDao.ListTokenKeys(ctx)
+		require.Nil(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "k2", keys[0].KeyId)
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		ctx := context.Background()
+		tokenKeyDao, fastForward := factory(t)
+
+		ok, err := tokenKeyDao.AcquireRotation(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+		ok, err = tokenKeyDao.AcquireRotation(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		fastForward(2 * time.Minute)
+		ok, err = tokenKeyDao.AcquireRotation(ctx, time.Minute)
+		require.Nil(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func RunBlocklistDaoTests(t *testing.T, factory BlocklistDaoFactory) {
+	t.Run("AddAndRemove", func(t *testing.T) {
+		ctx := context.Background()
+		blocklistDao := factory(t)
+
+		list, err := blocklistDao.GetBlocklist(ctx, testAppId)
+		require.Nil(t, err)
+		assert.Len(t, list.Cidrs, 0)
+		assert.Len(t, list.UserIds, 0)
+		assert.Len(t, list.DeviceIds, 0)
+
+		require.Nil(t, blocklistDao.AddBlocklist(ctx, testAppId, &brokersvc.Blocklist{
+			Cidrs:     []string{"10.0.0.0/8", "192.168.1.1/32"},
+			UserIds:   []int64{123, 124},
+			DeviceIds: []int64{456},
+		}))
+		require.Nil(t, blocklistDao.AddBlocklist(ctx, testAppId, &brokersvc.Blocklist{UserIds: []int64{124, 125}}))
+		list, err = blocklistDao.GetBlocklist(ctx, testAppId)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{"10.0.0.0/8", "192.168.1.1/32"}, list.Cidrs)
+		assert.ElementsMatch(t, []int64{123, 124, 125}, list.UserIds)
+		assert.ElementsMatch(t, []int64{456}, list.DeviceIds)
+
+		list, err = blocklistDao.GetBlocklist(ctx, testAppId+1)
+		require.Nil(t, err)
+		assert.Len(t, list.UserIds, 0)
+
+		require.Nil(t, blocklistDao.RemoveBlocklist(ctx, testAppId, &brokersvc.Blocklist{
+			Cidrs:     []string{"10.0.0.0/8"},
+			UserIds:   []int64{123, 999},
+			DeviceIds: []int64{456},
+		}))
+		require.Nil(t, blocklistDao.RemoveBlocklist(ctx, testAppId+1, &brokersvc.Blocklist{UserIds: []int64{123}}))
+		list, err = blocklistDao.GetBlocklist(ctx, testAppId)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{"192.168.1.1/32"}, list.Cidrs)
+		assert.ElementsMatch(t, []int64{124, 125}, list.UserIds)
+		assert.Len(t, list.DeviceIds, 0)
+	})
+}
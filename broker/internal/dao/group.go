@@ -0,0 +1,127 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+func NewGroupDao(redisClient redis.UniversalClient) service.GroupDao {
+	return &groupDaoImpl{
+		redisCli: redisClient,
+	}
+}
+
+type groupDaoImpl struct {
+	redisCli redis.UniversalClient
+}
+
+func (p *groupDaoImpl) CreateGroup(ctx context.Context, group *data.GroupInfo) (bool, error) {
+	key := groupInfoKey(group.AppId, group.GroupId)
+	buf, err := proto.Marshal(group)
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	created, err := p.redisCli.SetNX(ctx, key, buf, 0).Result()
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	return created, nil
+}
+
+// GetGroup returns nil if the group does not exist.
+func (p *groupDaoImpl) GetGroup(ctx context.Context, appId int64, groupId string) (*data.GroupInfo, error) {
+	key := groupInfoKey(appId, groupId)
+	val, err := p.redisCli.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, errors.AddStack(err)
+	}
+	group := &data.GroupInfo{}
+	err = proto.Unmarshal(val, group)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return group, nil
+}
+
+func (p *groupDaoImpl) DeleteGroup(ctx context.Context, appId int64, groupId string) error {
+	ikey := groupInfoKey(appId, groupId)
+	mkey := groupMembersKey(appId, groupId)
+	err := p.redisCli.Del(ctx, ikey, mkey).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// addGroupMembersScript adds members only if the group exists,
+// so that members of a deleted group are not left behind.
+var addGroupMembersScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('SADD', KEYS[2], unpack(ARGV))
+return 1
+`)
+
+func (p *groupDaoImpl) AddGroupMembers(ctx context.Context, appId int64, groupId string, userIds []int64) (bool, error) {
+	ikey := groupInfoKey(appId, groupId)
+	mkey := groupMembersKey(appId, groupId)
+	args := make([]interface{}, 0, len(userIds))
+	for _, userId := range userIds {
+		args = append(args, userId)
+	}
+	result, err := addGroupMembersScript.Run(ctx, p.redisCli, []string{ikey, mkey}, args...).Int64()
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	return result == 1, nil
+}
+
+func (p *groupDaoImpl) RemoveGroupMembers(ctx context.Context, appId int64, groupId string, userIds []int64) error {
+	mkey := groupMembersKey(appId, groupId)
+	members := make([]interface{}, 0, len(userIds))
+	for _, userId := range userIds {
+		members = append(members, userId)
+	}
+	err := p.redisCli.SRem(ctx, mkey, members...).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *groupDaoImpl) ScanGroupMembers(ctx context.Context, appId int64, groupId string, cursor uint64, count int64) ([]int64, uint64, error) {
+	mkey := groupMembersKey(appId, groupId)
+	members, next, err := p.redisCli.SScan(ctx, mkey, cursor, "", count).Result()
+	if err != nil {
+		return nil, 0, errors.AddStack(err)
+	}
+	userIds := make([]int64, 0, len(members))
+	for _, member := range members {
+		userId, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, next, nil
+}
+
+func (p *groupDaoImpl) CountGroupMembers(ctx context.Context, appId int64, groupId string) (int64, error) {
+	mkey := groupMembersKey(appId, groupId)
+	count, err := p.redisCli.SCard(ctx, mkey).Result()
+	if err != nil {
+		return 0, errors.AddStack(err)
+	}
+	return count, nil
+}
@@ -1,17 +1,88 @@
 package dao
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/jxskiss/gopkg/exp/kvutil"
 )
 
 var km = kvutil.KeyManager{}
 
+// Keys which are accessed together are hash-tagged by the parts in
+// double braces, eg. "u:h:{{app_id}:{user_id}}" formats to "u:h:{1001:123}",
+// so that they are in the same slot of a Redis cluster.
+
 var (
-	tokenKey = km.NewKey("token:{token}")
+	tokenKey             = km.NewKey("token:{token}")
+	revokedTokenKey      = km.NewKey("token:r:{token}")
+	usedTokenKey         = km.NewKey("token:u:{token}")
+	revokedUserTokensKey = km.NewKey("token:ru:{app_id}:{user_id}")
+
+	// The revocation log keys are hash-tagged to be updated by one script.
+	// The log is scored by sequence numbers, the expire set is scored by
+	// expire times to trim the log.
+	revocationSeqKey    = "{token:rl}:seq"
+	revocationLogKey    = "{token:rl}:log"
+	revocationExpireKey = "{token:rl}:exp"
+
+	tokenKeysKey    = km.NewKey("tk:keys")
+	tokenKeyLockKey = km.NewKey("tk:lock")
+
+	userConnectionsHashKey = km.NewKey("u:h:{{app_id}:{user_id}}", "app_id", "user_id")
+	userConnectionsZsetKey = km.NewKey("u:s:{{app_id}:{user_id}}", "app_id", "user_id")
+
+	deviceConnectionsHashKey = km.NewKey("d:h:{{app_id}:{device_id}}", "app_id", "device_id")
+	deviceConnectionsZsetKey = km.NewKey("d:s:{{app_id}:{device_id}}", "app_id", "device_id")
 
-	userConnectionsHashKey = km.NewKey("u:h:{app_id}:{user_id}")
-	userConnectionsZsetKey = km.NewKey("u:s:{app_id}:{user_id}")
+	machineConnectionsKey = km.NewKey("m:c:{machine_id}")
 
-	deviceConnectionsHashKey = km.NewKey("d:h:{app_id}:{device_id}")
-	deviceConnectionsZsetKey = km.NewKey("d:s:{app_id}:{device_id}")
+	appConnectionsKey = km.NewKey("a:c:{{app_id}}", "app_id")
+	appUsersKey       = km.NewKey("a:u:{{app_id}}", "app_id")
+	appDevicesKey     = km.NewKey("a:d:{{app_id}}", "app_id")
+
+	userConnectionsZsetPattern   = "u:s:{*}"
+	deviceConnectionsZsetPattern = "d:s:{*}"
+	appIndexPatterns             = []string{"a:c:{*}", "a:u:{*}", "a:d:{*}"}
+
+	groupInfoKey    = km.NewKey("g:i:{{app_id}:{group_id}}", "app_id", "group_id")
+	groupMembersKey = km.NewKey("g:m:{{app_id}:{group_id}}", "app_id", "group_id")
+
+	cometCleanupKey = km.NewKey("comet:cleanup:{machine_id}")
+
+	blockedCidrsKey   = km.NewKey("bl:n:{{app_id}}", "app_id")
+	blockedUsersKey   = km.NewKey("bl:u:{{app_id}}", "app_id")
+	blockedDevicesKey = km.NewKey("bl:d:{{app_id}}", "app_id")
+
+	sessionKey = km.NewKey("sess:{{app_id}:{user_id}}:{conn_id}", "app_id", "user_id", "conn_id")
+	resumedKey = km.NewKey("sess:r:{{app_id}:{user_id}}:{conn_id}", "app_id", "user_id", "conn_id")
 )
+
+// parseConnectionsZsetKey parses the app ID and user ID or device ID
+// from a key formatted by userConnectionsZsetKey or deviceConnectionsZsetKey.
+func parseConnectionsZsetKey(key string) (appId, userId, deviceId int64, ok bool) {
+	var isUser bool
+	switch {
+	case strings.HasPrefix(key, "u:s:{"):
+		isUser = true
+	case strings.HasPrefix(key, "d:s:{"):
+	default:
+		return 0, 0, 0, false
+	}
+	if !strings.HasSuffix(key, "}") {
+		return 0, 0, 0, false
+	}
+	parts := strings.Split(key[len("u:s:{"):len(key)-1], ":")
+	if len(parts) != 2 {
+		return 0, 0, 0, false
+	}
+	appId, err1 := strconv.ParseInt(parts[0], 10, 64)
+	id, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil || id <= 0 {
+		return 0, 0, 0, false
+	}
+	if isUser {
+		return appId, id, 0, true
+	}
+	return appId, 0, id, true
+}
@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jxskiss/gopkg/set"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+)
+
+// NewMemoryBlocklistDao returns the memory implementation of BlocklistDao.
+func NewMemoryBlocklistDao() service.BlocklistDao {
+	return &memoryBlocklistDao{
+		lists: make(map[int64]*memoryBlocklist),
+	}
+}
+
+type memoryBlocklist struct {
+	cidrs   set.String
+	users   set.Int64
+	devices set.Int64
+}
+
+type memoryBlocklistDao struct {
+	mu    sync.Mutex
+	lists map[int64]*memoryBlocklist
+}
+
+func (p *memoryBlocklistDao) GetBlocklist(ctx context.Context, appId int64) (*brokersvc.Blocklist, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	list := p.lists[appId]
+	if list == nil {
+		return &brokersvc.Blocklist{}, nil
+	}
+	return &brokersvc.Blocklist{
+		Cidrs:     list.cidrs.Slice(),
+		UserIds:   list.users.Slice(),
+		DeviceIds: list.devices.Slice(),
+	}, nil
+}
+
+func (p *memoryBlocklistDao) AddBlocklist(ctx context.Context, appId int64, list *brokersvc.Blocklist) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	blocked := p.lists[appId]
+	if blocked == nil {
+		blocked = &memoryBlocklist{
+			cidrs:   set.NewString(),
+			users:   set.NewInt64(),
+			devices: set.NewInt64(),
+		}
+		p.lists[appId] = blocked
+	}
+	blocked.cidrs.Add(list.Cidrs...)
+	blocked.users.Add(list.UserIds...)
+	blocked.devices.Add(list.DeviceIds...)
+	return nil
+}
+
+func (p *memoryBlocklistDao) RemoveBlocklist(ctx context.Context, appId int64, list *brokersvc.Blocklist) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	blocked := p.lists[appId]
+	if blocked == nil {
+		return nil
+	}
+	blocked.cidrs.Del(list.Cidrs...)
+	blocked.users.Del(list.UserIds...)
+	blocked.devices.Del(list.DeviceIds...)
+	if blocked.cidrs.Size() == 0 && blocked.users.Size() == 0 && blocked.devices.Size() == 0 {
+		delete(p.lists, appId)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+)
+
+// NewMemoryCometDao returns the memory implementation of CometDao.
+func NewMemoryCometDao() service.CometDao {
+	return &memoryCometDao{
+		now:      time.Now,
+		memoryKV: newMemoryKV(),
+	}
+}
+
+// memoryCometDao stores values by the same keys as cometDaoImpl.
+type memoryCometDao struct {
+	now func() time.Time
+
+	mu sync.Mutex
+	memoryKV
+}
+
+func (p *memoryCometDao) AcquireCleanup(ctx context.Context, machineId string, ttl time.Duration) (bool, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.setNX(cometCleanupKey(machineId), now.Unix(), ttl, now), nil
+}
+
+func (p *memoryCometDao) ReleaseCleanup(ctx context.Context, machineId string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.del(cometCleanupKey(machineId))
+	return nil
+}
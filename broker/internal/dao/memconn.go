@@ -0,0 +1,488 @@
+package dao
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+const memoryPurgeInterval = time.Minute
+
+// NewMemoryConnectionDao returns the memory implementation of ConnectionDao.
+func NewMemoryConnectionDao() service.ConnectionDao {
+	return &memoryConnectionDao{
+		now:      time.Now,
+		routes:   make(map[routeKey]*memoryRoute),
+		machines: make(map[string]*memoryIndex),
+		apps:     make(map[int64]*memoryIndex),
+	}
+}
+
+type routeKey struct {
+	appId    int64
+	userId   int64
+	deviceId int64
+}
+
+// memoryRoute is the connections of a user or device, it expires
+// purgeExpiration after it was last saved or touched.
+type memoryRoute struct {
+	conns    map[string]*memoryConn
+	expireAt time.Time
+}
+
+func (r *memoryRoute) getConn(id string) *memoryConn {
+	if r == nil {
+		return nil
+	}
+	return r.conns[id]
+}
+
+type memoryConn struct {
+	info  *data.ConnectionInfo
+	score int64 // last update time in milliseconds
+}
+
+// clone returns a copy of the connection with the last active time.
+func (c *memoryConn) clone() *data.ConnectionInfo {
+	info := proto.Clone(c.info).(*data.ConnectionInfo)
+	info.LastActiveTimeMsec = c.score
+	return info
+}
+
+// memoryIndex keeps connections in the order they are added, it is
+// scanned by the sequence number of the last returned connection,
+// so that connections deleted during a scan do not make the others
+// skipped, which is what HSCAN and ZSCAN guarantee.
+type memoryIndex struct {
+	entries map[string]*memoryIndexEntry
+	order   []*memoryIndexEntry // sorted by seq, including deleted ones
+	deleted int
+}
+
+type memoryIndexEntry struct {
+	seq     uint64
+	info    *data.ConnectionInfo
+	deleted bool
+}
+
+func (x *memoryIndex) get(id string) *data.ConnectionInfo {
+	if x == nil || x.entries[id] == nil {
+		return nil
+	}
+	return x.entries[id].info
+}
+
+// set adds a connection with seq, a connection already in the index
+// keeps its place.
+func (x *memoryIndex) set(info *data.ConnectionInfo, seq uint64) {
+	if e := x.entries[info.Id]; e != nil {
+		e.info = info
+		return
+	}
+	e := &memoryIndexEntry{seq: seq, info: info}
+	x.entries[info.Id] = e
+	x.order = append(x.order, e)
+}
+
+func (x *memoryIndex) del(id string) {
+	e := x.entries[id]
+	if e == nil {
+		return
+	}
+	e.deleted = true
+	delete(x.entries, id)
+	x.deleted++
+	if x.deleted > len(x.order)/2 {
+		order := make([]*memoryIndexEntry, 0, len(x.entries))
+		for _, e := range x.order {
+			if !e.deleted {
+				order = append(order, e)
+			}
+		}
+		x.order = order
+		x.deleted = 0
+	}
+}
+
+// scan calls fn with at most count connections added after cursor,
+// it returns the next cursor, a zero cursor means the scan is done.
+func (x *memoryIndex) scan(cursor uint64, count int64, fn func(info *data.ConnectionInfo)) uint64 {
+	if x == nil {
+		return 0
+	}
+	if count <= 0 {
+		count = 10
+	}
+	i := sort.Search(len(x.order), func(i int) bool { return x.order[i].seq > cursor })
+	for ; i < len(x.order) && count > 0; i++ {
+		if e := x.order[i]; !e.deleted {
+			fn(e.info)
+			cursor = e.seq
+			count--
+		}
+	}
+	if i >= len(x.order) {
+		return 0
+	}
+	return cursor
+}
+
+func (x *memoryIndex) size() int {
+	if x == nil {
+		return 0
+	}
+	return len(x.entries)
+}
+
+type memoryConnectionDao struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	routes    map[routeKey]*memoryRoute
+	machines  map[string]*memoryIndex
+	apps      map[int64]*memoryIndex
+	seq       uint64
+	lastPurge time.Time
+}
+
+func getRouteKey(appId, userId, deviceId int64) routeKey {
+	if userId > 0 {
+		return routeKey{appId: appId, userId: userId}
+	}
+	return routeKey{appId: appId, deviceId: deviceId}
+}
+
+// getRoute must be called with p.mu held, it returns nil if the route
+// does not exist or has expired.
+func (p *memoryConnectionDao) getRoute(key routeKey, now time.Time) *memoryRoute {
+	route := p.routes[key]
+	if route != nil && !now.Before(route.expireAt) {
+		p.deleteRoute(key, route)
+		return nil
+	}
+	return route
+}
+
+// deleteRoute must be called with p.mu held.
+func (p *memoryConnectionDao) deleteRoute(key routeKey, route *memoryRoute) {
+	delete(p.routes, key)
+	for id := range route.conns {
+		p.deleteAppConn(key.appId, id)
+	}
+}
+
+// purgeExpired must be called with p.mu held.
+func (p *memoryConnectionDao) purgeExpired(now time.Time) {
+	if now.Sub(p.lastPurge) < memoryPurgeInterval {
+		return
+	}
+	p.lastPurge = now
+	for key, route := range p.routes {
+		if !now.Before(route.expireAt) {
+			p.deleteRoute(key, route)
+		}
+	}
+}
+
+func (p *memoryConnectionDao) SaveConnection(ctx context.Context, connection *data.ConnectionInfo, limit int) ([]*data.ConnectionInfo, error) {
+	if connection.UserId <= 0 && connection.DeviceId <= 0 {
+		return nil, errors.AddStack(ErrInvalidUserIdDeviceId)
+	}
+	info := proto.Clone(connection).(*data.ConnectionInfo)
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.purgeExpired(now)
+
+	key := getRouteKey(info.AppId, info.UserId, info.DeviceId)
+	route := p.getRoute(key, now)
+	if route == nil {
+		route = &memoryRoute{conns: make(map[string]*memoryConn)}
+		p.routes[key] = route
+	}
+	route.conns[info.Id] = &memoryConn{info: info, score: now.UnixNano() / 1e6}
+	route.expireAt = now.Add(purgeExpiration)
+	p.setIndexConn(info)
+
+	if limit <= 0 || len(route.conns) <= limit {
+		return nil, nil
+	}
+	conns := make([]*memoryConn, 0, len(route.conns))
+	for _, c := range route.conns {
+		conns = append(conns, c)
+	}
+	// Same order as ZREVRANGE: by score then by member descending.
+	sort.Slice(conns, func(i, j int) bool {
+		if conns[i].score != conns[j].score {
+			return conns[i].score > conns[j].score
+		}
+		return conns[i].info.Id > conns[j].info.Id
+	})
+	evicted := make([]*data.ConnectionInfo, 0, len(conns)-limit)
+	for _, c := range conns[limit:] {
+		delete(route.conns, c.info.Id)
+		p.deleteMachineConn(c.info.Id)
+		p.deleteAppConn(c.info.AppId, c.info.Id)
+		evicted = append(evicted, proto.Clone(c.info).(*data.ConnectionInfo))
+	}
+	return evicted, nil
+}
+
+// setIndexConn adds a connection to the machine and app indexes,
+// it must be called with p.mu held.
+func (p *memoryConnectionDao) setIndexConn(info *data.ConnectionInfo) {
+	p.seq++
+	if mkey := getMachineKey(info.Id); mkey != "" {
+		index := p.machines[mkey]
+		if index == nil {
+			index = &memoryIndex{entries: make(map[string]*memoryIndexEntry)}
+			p.machines[mkey] = index
+		}
+		index.set(info, p.seq)
+	}
+	index := p.apps[info.AppId]
+	if index == nil {
+		index = &memoryIndex{entries: make(map[string]*memoryIndexEntry)}
+		p.apps[info.AppId] = index
+	}
+	index.set(info, p.seq)
+}
+
+// deleteMachineConn must be called with p.mu held.
+func (p *memoryConnectionDao) deleteMachineConn(connectionId string) {
+	mkey := getMachineKey(connectionId)
+	if index := p.machines[mkey]; index != nil {
+		index.del(connectionId)
+		if index.size() == 0 {
+			delete(p.machines, mkey)
+		}
+	}
+}
+
+// deleteAppConn must be called with p.mu held.
+func (p *memoryConnectionDao) deleteAppConn(appId int64, connectionId string) {
+	if index := p.apps[appId]; index != nil {
+		index.del(connectionId)
+		if index.size() == 0 {
+			delete(p.apps, appId)
+		}
+	}
+}
+
+func (p *memoryConnectionDao) DeleteConnection(ctx context.Context, appId, userId, deviceId int64, connectionId string) error {
+	if userId <= 0 && deviceId <= 0 {
+		return errors.AddStack(ErrInvalidUserIdDeviceId)
+	}
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if route := p.getRoute(getRouteKey(appId, userId, deviceId), now); route != nil {
+		delete(route.conns, connectionId)
+	}
+	p.deleteMachineConn(connectionId)
+	p.deleteAppConn(appId, connectionId)
+	return nil
+}
+
+func (p *memoryConnectionDao) TouchConnection(ctx context.Context, appId, userId, deviceId int64, connectionId string) error {
+	if userId <= 0 && deviceId <= 0 {
+		return errors.AddStack(ErrInvalidUserIdDeviceId)
+	}
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	route := p.getRoute(getRouteKey(appId, userId, deviceId), now)
+	if route == nil {
+		return nil
+	}
+	if c := route.conns[connectionId]; c != nil {
+		c.score = now.UnixNano() / 1e6
+	}
+	route.expireAt = now.Add(purgeExpiration)
+	return nil
+}
+
+func (p *memoryConnectionDao) ListUserConnections(ctx context.Context, appId int64, userIds []int64) (map[int64][]*data.ConnectionInfo, error) {
+	if len(userIds) == 0 {
+		return nil, nil
+	}
+	keys := make([]routeKey, 0, len(userIds))
+	for _, userId := range userIds {
+		keys = append(keys, routeKey{appId: appId, userId: userId})
+	}
+	result := make(map[int64][]*data.ConnectionInfo)
+	p.listConnections(keys, func(info *data.ConnectionInfo) {
+		result[info.UserId] = append(result[info.UserId], info)
+	})
+	return result, nil
+}
+
+func (p *memoryConnectionDao) ListDeviceConnections(ctx context.Context, appId int64, deviceIds []int64) (map[int64][]*data.ConnectionInfo, error) {
+	if len(deviceIds) == 0 {
+		return nil, nil
+	}
+	keys := make([]routeKey, 0, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		keys = append(keys, routeKey{appId: appId, deviceId: deviceId})
+	}
+	result := make(map[int64][]*data.ConnectionInfo)
+	p.listConnections(keys, func(info *data.ConnectionInfo) {
+		result[info.DeviceId] = append(result[info.DeviceId], info)
+	})
+	return result, nil
+}
+
+func (p *memoryConnectionDao) listConnections(keys []routeKey, fn func(info *data.ConnectionInfo)) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, key := range keys {
+		route := p.getRoute(key, now)
+		if route == nil {
+			continue
+		}
+		for _, c := range route.conns {
+			fn(c.clone())
+		}
+	}
+}
+
+// ScanMachineConnections iterates connections in the order they are
+// saved, the cursor is the sequence number of the last returned one.
+func (p *memoryConnectionDao) ScanMachineConnections(ctx context.Context, machineId string, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var result []*data.ConnectionInfo
+	next := p.machines[machineConnectionsKey(machineId)].scan(cursor, count, func(info *data.ConnectionInfo) {
+		result = append(result, proto.Clone(info).(*data.ConnectionInfo))
+	})
+	return result, next, nil
+}
+
+func (p *memoryConnectionDao) DeleteMachineConnections(ctx context.Context, machineId string) error {
+	p.mu.Lock()
+	delete(p.machines, machineConnectionsKey(machineId))
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *memoryConnectionDao) SweepConnections(ctx context.Context, before time.Time, count int64, fn func(removed []*data.ConnectionInfo)) error {
+	maxScore := before.UnixNano() / 1e6
+	now := p.now()
+
+	var removed []*data.ConnectionInfo
+	p.mu.Lock()
+	for key, route := range p.routes {
+		if !now.Before(route.expireAt) {
+			p.deleteRoute(key, route)
+			continue
+		}
+		for id, c := range route.conns {
+			if c.score < maxScore {
+				delete(route.conns, id)
+				p.deleteMachineConn(id)
+				p.deleteAppConn(key.appId, id)
+				removed = append(removed, c.info)
+			}
+		}
+		if len(route.conns) == 0 {
+			p.deleteRoute(key, route)
+		}
+	}
+	p.mu.Unlock()
+
+	if count <= 0 {
+		count = 10
+	}
+	for len(removed) > 0 {
+		n := len(removed)
+		if int64(n) > count {
+			n = int(count)
+		}
+		fn(removed[:n])
+		removed = removed[n:]
+	}
+	return nil
+}
+
+func (p *memoryConnectionDao) GetConnections(ctx context.Context, appId int64, connectionIds []string) ([]*data.ConnectionInfo, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var result []*data.ConnectionInfo
+	for _, id := range connectionIds {
+		info := p.machines[getMachineKey(id)].get(id)
+		if info == nil || info.AppId != appId {
+			continue
+		}
+		route := p.getRoute(getRouteKey(info.AppId, info.UserId, info.DeviceId), now)
+		if c := route.getConn(id); c != nil {
+			result = append(result, c.clone())
+		} else {
+			result = append(result, proto.Clone(info).(*data.ConnectionInfo))
+		}
+	}
+	return result, nil
+}
+
+// ScanAppConnections iterates connections in the order they are
+// saved, the cursor is the sequence number of the last returned one.
+func (p *memoryConnectionDao) ScanAppConnections(ctx context.Context, appId int64, activeSince time.Time, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error) {
+	minScore := int64(getActiveSinceScore(activeSince))
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var result []*data.ConnectionInfo
+	next := p.apps[appId].scan(cursor, count, func(info *data.ConnectionInfo) {
+		// The index must not be changed during scanning, expired routes
+		// are skipped but not deleted.
+		route := p.routes[getRouteKey(info.AppId, info.UserId, info.DeviceId)]
+		if route == nil || !now.Before(route.expireAt) {
+			return
+		}
+		if c := route.getConn(info.Id); c != nil && c.score >= minScore {
+			result = append(result, c.clone())
+		}
+	})
+	return result, next, nil
+}
+
+func (p *memoryConnectionDao) CountOnline(ctx context.Context, appId int64, activeSince time.Time) (*service.OnlineCount, error) {
+	minScore := int64(getActiveSinceScore(activeSince))
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	count := &service.OnlineCount{}
+	for key, route := range p.routes {
+		if key.appId != appId || !now.Before(route.expireAt) {
+			continue
+		}
+		var active int64
+		for _, c := range route.conns {
+			if c.score >= minScore {
+				active++
+			}
+		}
+		if active == 0 {
+			continue
+		}
+		count.Connections += active
+		if key.userId > 0 {
+			count.Users++
+		} else {
+			count.Devices++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,127 @@
+package dao
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+// NewMemoryGroupDao returns the memory implementation of GroupDao.
+func NewMemoryGroupDao() service.GroupDao {
+	return &memoryGroupDao{
+		groups: make(map[string]*memoryGroup),
+	}
+}
+
+type memoryGroup struct {
+	info    *data.GroupInfo
+	members map[int64]struct{}
+}
+
+// memoryGroupDao stores groups by the same keys as groupDaoImpl.
+type memoryGroupDao struct {
+	mu     sync.Mutex
+	groups map[string]*memoryGroup
+}
+
+func (p *memoryGroupDao) CreateGroup(ctx context.Context, group *data.GroupInfo) (bool, error) {
+	key := groupInfoKey(group.AppId, group.GroupId)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.groups[key] != nil {
+		return false, nil
+	}
+	p.groups[key] = &memoryGroup{
+		info:    proto.Clone(group).(*data.GroupInfo),
+		members: make(map[int64]struct{}),
+	}
+	return true, nil
+}
+
+// GetGroup returns nil if the group does not exist.
+func (p *memoryGroupDao) GetGroup(ctx context.Context, appId int64, groupId string) (*data.GroupInfo, error) {
+	key := groupInfoKey(appId, groupId)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	group := p.groups[key]
+	if group == nil {
+		return nil, nil
+	}
+	return proto.Clone(group.info).(*data.GroupInfo), nil
+}
+
+func (p *memoryGroupDao) DeleteGroup(ctx context.Context, appId int64, groupId string) error {
+	key := groupInfoKey(appId, groupId)
+	p.mu.Lock()
+	delete(p.groups, key)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *memoryGroupDao) AddGroupMembers(ctx context.Context, appId int64, groupId string, userIds []int64) (bool, error) {
+	key := groupInfoKey(appId, groupId)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	group := p.groups[key]
+	if group == nil {
+		return false, nil
+	}
+	for _, userId := range userIds {
+		group.members[userId] = struct{}{}
+	}
+	return true, nil
+}
+
+func (p *memoryGroupDao) RemoveGroupMembers(ctx context.Context, appId int64, groupId string, userIds []int64) error {
+	key := groupInfoKey(appId, groupId)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if group := p.groups[key]; group != nil {
+		for _, userId := range userIds {
+			delete(group.members, userId)
+		}
+	}
+	return nil
+}
+
+// ScanGroupMembers iterates members in order of user ID, the cursor is
+// the offset of the next member. Like SSCAN, members changed during
+// the iteration may be missed or returned more than once.
+func (p *memoryGroupDao) ScanGroupMembers(ctx context.Context, appId int64, groupId string, cursor uint64, count int64) ([]int64, uint64, error) {
+	key := groupInfoKey(appId, groupId)
+	p.mu.Lock()
+	group := p.groups[key]
+	var userIds []int64
+	if group != nil {
+		userIds = make([]int64, 0, len(group.members))
+		for userId := range group.members {
+			userIds = append(userIds, userId)
+		}
+	}
+	p.mu.Unlock()
+
+	if cursor >= uint64(len(userIds)) {
+		return nil, 0, nil
+	}
+	sort.Slice(userIds, func(i, j int) bool { return userIds[i] < userIds[j] })
+	end := cursor + uint64(count)
+	if count <= 0 || end >= uint64(len(userIds)) {
+		return userIds[cursor:], 0, nil
+	}
+	return userIds[cursor:end], end, nil
+}
+
+func (p *memoryGroupDao) CountGroupMembers(ctx context.Context, appId int64, groupId string) (int64, error) {
+	key := groupInfoKey(appId, groupId)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if group := p.groups[key]; group != nil {
+		return int64(len(group.members)), nil
+	}
+	return 0, nil
+}
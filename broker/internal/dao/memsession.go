@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+// NewMemorySessionDao returns the memory implementation of SessionDao.
+func NewMemorySessionDao() service.SessionDao {
+	return &memorySessionDao{
+		now:      time.Now,
+		memoryKV: newMemoryKV(),
+	}
+}
+
+// memorySessionDao stores values by the same keys as sessionDaoImpl.
+type memorySessionDao struct {
+	now func() time.Time
+
+	mu sync.Mutex
+	memoryKV
+}
+
+// getSession must be called with p.mu held.
+func (p *memorySessionDao) getSession(key string, now time.Time) *data.SessionState {
+	state, _ := p.get(key, now).(*data.SessionState)
+	return state
+}
+
+func (p *memorySessionDao) GetSession(ctx context.Context, appId, userId int64, connectionId string) (*data.SessionState, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state := p.getSession(sessionKey(appId, userId, connectionId), now)
+	if state == nil {
+		return nil, nil
+	}
+	return proto.Clone(state).(*data.SessionState), nil
+}
+
+func (p *memorySessionDao) SaveSession(ctx context.Context, appId, userId int64, connectionId string, state *data.SessionState) error {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(sessionKey(appId, userId, connectionId), proto.Clone(state), sessionExpiration, now)
+	return nil
+}
+
+func (p *memorySessionDao) DeleteSession(ctx context.Context, appId, userId int64, connectionId string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.del(sessionKey(appId, userId, connectionId))
+	return nil
+}
+
+func (p *memorySessionDao) TransferSession(ctx context.Context, appId, userId int64, oldId, newId string) (*data.SessionState, error) {
+	now := p.now()
+	oldKey := sessionKey(appId, userId, oldId)
+	newKey := sessionKey(appId, userId, newId)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	oldState := p.getSession(oldKey, now)
+	merged := p.getSession(newKey, now)
+	if oldState != nil {
+		merged = mergeSessionState(oldState, merged)
+		p.set(newKey, merged, sessionExpiration, now)
+		p.del(oldKey)
+	}
+	p.set(resumedKey(appId, userId, oldId), newId, resumedExpiration, now)
+	if merged == nil {
+		return nil, nil
+	}
+	return proto.Clone(merged).(*data.SessionState), nil
+}
+
+func (p *memorySessionDao) IsResumed(ctx context.Context, appId, userId int64, connectionId string) (bool, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.get(resumedKey(appId, userId, connectionId), now) != nil, nil
+}
@@ -0,0 +1,178 @@
+package dao
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+// NewMemoryTokenDao returns the memory implementation of TokenDao.
+func NewMemoryTokenDao() service.TokenDao {
+	return &memoryTokenDao{
+		now:      time.Now,
+		memoryKV: newMemoryKV(),
+	}
+}
+
+type memoryValue struct {
+	value    interface{}
+	expireAt time.Time
+}
+
+// memoryKV is a map of values which expire, like plain Redis keys.
+// Its methods must be called with the lock of the owner held.
+type memoryKV struct {
+	values    map[string]*memoryValue
+	lastPurge time.Time
+}
+
+func newMemoryKV() memoryKV {
+	return memoryKV{values: make(map[string]*memoryValue)}
+}
+
+func (p *memoryKV) get(key string, now time.Time) interface{} {
+	v := p.values[key]
+	if v == nil {
+		return nil
+	}
+	if !now.Before(v.expireAt) {
+		delete(p.values, key)
+		return nil
+	}
+	return v.value
+}
+
+func (p *memoryKV) set(key string, value interface{}, ttl time.Duration, now time.Time) {
+	if now.Sub(p.lastPurge) >= memoryPurgeInterval {
+		p.lastPurge = now
+		for k, v := range p.values {
+			if !now.Before(v.expireAt) {
+				delete(p.values, k)
+			}
+		}
+	}
+	p.values[key] = &memoryValue{value: value, expireAt: now.Add(ttl)}
+}
+
+// setNX sets the value only if the key does not exist, it tells
+// whether the value is set.
+func (p *memoryKV) setNX(key string, value interface{}, ttl time.Duration, now time.Time) bool {
+	if p.get(key, now) != nil {
+		return false
+	}
+	p.set(key, value, ttl, now)
+	return true
+}
+
+func (p *memoryKV) del(key string) {
+	delete(p.values, key)
+}
+
+// memoryTokenDao stores values by the same keys as tokenDaoImpl.
+type memoryTokenDao struct {
+	now func() time.Time
+
+	mu sync.Mutex
+	memoryKV
+	revSeq uint64
+	revLog []*memoryRevocation // sorted by seq
+}
+
+type memoryRevocation struct {
+	seq      uint64
+	rev      *service.TokenRevocation
+	expireAt time.Time
+}
+
+func (p *memoryTokenDao) GetToken(ctx context.Context, token string) (*data.TokenInfo, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	info, _ := p.get(tokenKey(token), now).(*data.TokenInfo)
+	if info == nil {
+		return nil, nil
+	}
+	return proto.Clone(info).(*data.TokenInfo), nil
+}
+
+func (p *memoryTokenDao) SaveToken(ctx context.Context, info *data.TokenInfo, ttl time.Duration) error {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(tokenKey(info.Id), proto.Clone(info), ttl, now)
+	return nil
+}
+
+func (p *memoryTokenDao) RevokeToken(ctx context.Context, token string, ttl time.Duration) error {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(revokedTokenKey(token), true, ttl, now)
+	p.logRevocation(&service.TokenRevocation{Token: token}, ttl, now)
+	return nil
+}
+
+func (p *memoryTokenDao) RevokeUserTokens(ctx context.Context, appId, userId int64, beforeMsec int64, ttl time.Duration) error {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(revokedUserTokensKey(appId, userId), beforeMsec, ttl, now)
+	p.logRevocation(&service.TokenRevocation{AppId: appId, UserId: userId, BeforeMsec: beforeMsec}, ttl, now)
+	return nil
+}
+
+// logRevocation must be called with p.mu held.
+func (p *memoryTokenDao) logRevocation(rev *service.TokenRevocation, ttl time.Duration, now time.Time) {
+	log := p.revLog[:0]
+	for _, x := range p.revLog {
+		if now.Before(x.expireAt) {
+			log = append(log, x)
+		}
+	}
+	p.revSeq++
+	p.revLog = append(log, &memoryRevocation{seq: p.revSeq, rev: rev, expireAt: now.Add(ttl)})
+}
+
+func (p *memoryTokenDao) ListRevocations(ctx context.Context, cursor uint64) ([]*service.TokenRevocation, uint64, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	next := cursor
+	var revs []*service.TokenRevocation
+	for _, x := range p.revLog {
+		if x.seq > cursor && now.Before(x.expireAt) {
+			rev := *x.rev
+			revs = append(revs, &rev)
+			next = x.seq
+		}
+	}
+	return revs, next, nil
+}
+
+func (p *memoryTokenDao) IsTokenRevoked(ctx context.Context, appId, userId int64, token string, signTimeMsec int64) (bool, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.get(revokedTokenKey(token), now) != nil {
+		return true, nil
+	}
+	if userId > 0 {
+		if beforeMsec, ok := p.get(revokedUserTokensKey(appId, userId), now).(int64); ok && signTimeMsec <= beforeMsec {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *memoryTokenDao) MarkTokenUsed(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	now := p.now()
+	key := usedTokenKey(token)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.setNX(key, true, ttl, now), nil
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+// NewMemoryTokenKeyDao returns the memory implementation of TokenKeyDao.
+//
+// The keys are lost when the broker restarts, thus tokens signed
+// before the restart cannot be verified anymore.
+func NewMemoryTokenKeyDao() service.TokenKeyDao {
+	return &memoryTokenKeyDao{
+		now:      time.Now,
+		keys:     make(map[string]*data.TokenKeyInfo),
+		memoryKV: newMemoryKV(),
+	}
+}
+
+type memoryTokenKeyDao struct {
+	now func() time.Time
+
+	mu   sync.Mutex
+	keys map[string]*data.TokenKeyInfo
+	memoryKV
+}
+
+func (p *memoryTokenKeyDao) ListTokenKeys(ctx context.Context) ([]*data.TokenKeyInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keys := make([]*data.TokenKeyInfo, 0, len(p.keys))
+	for _, key := range p.keys {
+		keys = append(keys, proto.Clone(key).(*data.TokenKeyInfo))
+	}
+	return keys, nil
+}
+
+func (p *memoryTokenKeyDao) SaveTokenKey(ctx context.Context, key *data.TokenKeyInfo) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[key.KeyId] = proto.Clone(key).(*data.TokenKeyInfo)
+	return nil
+}
+
+func (p *memoryTokenKeyDao) DeleteTokenKeys(ctx context.Context, keyIds ...string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, keyId := range keyIds {
+		delete(p.keys, keyId)
+	}
+	return nil
+}
+
+func (p *memoryTokenKeyDao) AcquireRotation(ctx context.Context, ttl time.Duration) (bool, error) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.setNX(tokenKeyLockKey(), now.Unix(), ttl, now), nil
+}
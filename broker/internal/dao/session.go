@@ -0,0 +1,174 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+	"github.com/jxskiss/gopkg/set"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+const (
+	sessionExpiration = 24 * time.Hour
+	resumedExpiration = 10 * time.Minute
+)
+
+func NewSessionDao(redisClient redis.UniversalClient) service.SessionDao {
+	return &sessionDaoImpl{
+		redisCli: redisClient,
+	}
+}
+
+type sessionDaoImpl struct {
+	redisCli redis.UniversalClient
+}
+
+func (p *sessionDaoImpl) GetSession(ctx context.Context, appId, userId int64, connectionId string) (*data.SessionState, error) {
+	key := sessionKey(appId, userId, connectionId)
+	val, err := p.redisCli.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, errors.AddStack(err)
+	}
+	return unmarshalSessionState(val)
+}
+
+func (p *sessionDaoImpl) SaveSession(ctx context.Context, appId, userId int64, connectionId string, state *data.SessionState) error {
+	key := sessionKey(appId, userId, connectionId)
+	buf, err := proto.Marshal(state)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	err = p.redisCli.Set(ctx, key, buf, sessionExpiration).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *sessionDaoImpl) DeleteSession(ctx context.Context, appId, userId int64, connectionId string) error {
+	key := sessionKey(appId, userId, connectionId)
+	err := p.redisCli.Del(ctx, key).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// transferSessionScript writes the merged state to the new key and
+// marks the old session resumed, only if both session keys still hold
+// the values read before merging, else it returns 0.
+var transferSessionScript = redis.NewScript(`
+local oldKey, newKey, resumedKey = KEYS[1], KEYS[2], KEYS[3]
+local oldVal, newVal, merged, hasOld = ARGV[1], ARGV[2], ARGV[3], ARGV[4]
+if (redis.call("GET", oldKey) or "") ~= oldVal or (redis.call("GET", newKey) or "") ~= newVal then
+	return 0
+end
+if hasOld == "1" then
+	redis.call("SET", newKey, merged, "PX", ARGV[5])
+	redis.call("DEL", oldKey)
+end
+redis.call("SET", resumedKey, ARGV[6], "PX", ARGV[7])
+return 1
+`)
+
+// The merge is retried when the sessions are changed concurrently,
+// eg. two connections resuming the same old session.
+const maxTransferSessionRetries = 5
+
+func (p *sessionDaoImpl) TransferSession(ctx context.Context, appId, userId int64, oldId, newId string) (*data.SessionState, error) {
+	oldKey := sessionKey(appId, userId, oldId)
+	newKey := sessionKey(appId, userId, newId)
+	keys := []string{oldKey, newKey, resumedKey(appId, userId, oldId)}
+	for i := 0; i < maxTransferSessionRetries; i++ {
+		vals, err := p.redisCli.MGet(ctx, oldKey, newKey).Result()
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+		oldVal, hasOld := vals[0].(string)
+		newVal, _ := vals[1].(string)
+		var oldState, merged *data.SessionState
+		if newVal != "" {
+			if merged, err = unmarshalSessionState([]byte(newVal)); err != nil {
+				return nil, err
+			}
+		}
+		var buf []byte
+		var hasOldArg int
+		if hasOld {
+			hasOldArg = 1
+			if oldState, err = unmarshalSessionState([]byte(oldVal)); err != nil {
+				return nil, err
+			}
+			merged = mergeSessionState(oldState, merged)
+			if buf, err = proto.Marshal(merged); err != nil {
+				return nil, errors.AddStack(err)
+			}
+		}
+		ok, err := transferSessionScript.Run(ctx, p.redisCli, keys,
+			oldVal, newVal, buf, hasOldArg,
+			sessionExpiration.Milliseconds(), newId, resumedExpiration.Milliseconds()).Int()
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+		if ok == 1 {
+			return merged, nil
+		}
+	}
+	return nil, errors.Errorf("session transfer conflicted, old_id= %v, new_id= %v", oldId, newId)
+}
+
+func (p *sessionDaoImpl) IsResumed(ctx context.Context, appId, userId int64, connectionId string) (bool, error) {
+	n, err := p.redisCli.Exists(ctx, resumedKey(appId, userId, connectionId)).Result()
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	return n > 0, nil
+}
+
+func unmarshalSessionState(val []byte) (*data.SessionState, error) {
+	state := &data.SessionState{}
+	err := proto.Unmarshal(val, state)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return state, nil
+}
+
+// mergeSessionState merges old session state into the new one, values
+// in the new state take precedence over the old.
+func mergeSessionState(old, new *data.SessionState) *data.SessionState {
+	if new == nil {
+		return old
+	}
+	// The pending list is copied, so that it does not share memory
+	// with the old state.
+	pending := make([][]byte, 0, len(old.PendingMessages)+len(new.PendingMessages))
+	pending = append(pending, old.PendingMessages...)
+	pending = append(pending, new.PendingMessages...)
+	out := &data.SessionState{
+		Attributes:      make(map[string]string, len(old.Attributes)+len(new.Attributes)),
+		PendingMessages: pending,
+		SyncSeq:         old.SyncSeq,
+	}
+	tags := set.NewString(old.Tags...)
+	tags.Add(new.Tags...)
+	out.Tags = tags.Slice()
+	for k, v := range old.Attributes {
+		out.Attributes[k] = v
+	}
+	for k, v := range new.Attributes {
+		out.Attributes[k] = v
+	}
+	if new.SyncSeq > out.SyncSeq {
+		out.SyncSeq = new.SyncSeq
+	}
+	return out
+}
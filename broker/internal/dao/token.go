@@ -2,7 +2,9 @@ package dao
 
 import (
 	"context"
-	stderr "errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -13,24 +15,23 @@ import (
 	"github.com/jxskiss/nonamegw/proto/data"
 )
 
-var ErrTokenNotExists = stderr.New("token not exists")
-
-func NewTokenDao(redisClient *redis.Client) service.TokenDao {
+func NewTokenDao(redisClient redis.UniversalClient) service.TokenDao {
 	return &tokenDaoImpl{
 		redisCli: redisClient,
 	}
 }
 
 type tokenDaoImpl struct {
-	redisCli *redis.Client
+	redisCli redis.UniversalClient
 }
 
+// GetToken returns nil if the token does not exist.
 func (p *tokenDaoImpl) GetToken(ctx context.Context, token string) (*data.TokenInfo, error) {
 	key := tokenKey(token)
 	val, err := p.redisCli.Get(ctx, key).Bytes()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, ErrTokenNotExists
+			return nil, nil
 		}
 		return nil, errors.AddStack(err)
 	}
@@ -54,3 +55,152 @@ func (p *tokenDaoImpl) SaveToken(ctx context.Context, info *data.TokenInfo, ttl
 	}
 	return nil
 }
+
+func (p *tokenDaoImpl) RevokeToken(ctx context.Context, token string, ttl time.Duration) error {
+	key := revokedTokenKey(token)
+	err := p.redisCli.Set(ctx, key, 1, ttl).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return p.logRevocation(ctx, &service.TokenRevocation{Token: token}, ttl)
+}
+
+func (p *tokenDaoImpl) RevokeUserTokens(ctx context.Context, appId, userId int64, beforeMsec int64, ttl time.Duration) error {
+	key := revokedUserTokensKey(appId, userId)
+	err := p.redisCli.Set(ctx, key, beforeMsec, ttl).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	rev := &service.TokenRevocation{AppId: appId, UserId: userId, BeforeMsec: beforeMsec}
+	return p.logRevocation(ctx, rev, ttl)
+}
+
+// logRevocationScript appends a revocation to the log with the next
+// sequence number, and trims expired revocations from the log.
+//
+// KEYS: seq, log, expire
+// ARGV: member, expire_msec, now_msec
+var logRevocationScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('ZADD', KEYS[2], seq, ARGV[1])
+redis.call('ZADD', KEYS[3], ARGV[2], ARGV[1])
+local expired = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', ARGV[3], 'LIMIT', 0, 100)
+if #expired > 0 then
+	redis.call('ZREM', KEYS[2], unpack(expired))
+	redis.call('ZREM', KEYS[3], unpack(expired))
+end
+return seq
+`)
+
+func (p *tokenDaoImpl) logRevocation(ctx context.Context, rev *service.TokenRevocation, ttl time.Duration) error {
+	now := time.Now()
+	keys := []string{revocationSeqKey, revocationLogKey, revocationExpireKey}
+	err := logRevocationScript.Run(ctx, p.redisCli, keys,
+		formatRevocation(rev), now.Add(ttl).UnixNano()/1e6, now.UnixNano()/1e6).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *tokenDaoImpl) ListRevocations(ctx context.Context, cursor uint64) ([]*service.TokenRevocation, uint64, error) {
+	var seqCmd *redis.StringCmd
+	var logCmd *redis.ZSliceCmd
+	_, err := p.redisCli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		seqCmd = pipe.Get(ctx, revocationSeqKey)
+		logCmd = pipe.ZRangeByScoreWithScores(ctx, revocationLogKey, &redis.ZRangeBy{
+			Min: "(" + strconv.FormatUint(cursor, 10),
+			Max: "+inf",
+		})
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, 0, errors.AddStack(err)
+	}
+	if err = logCmd.Err(); err != nil {
+		return nil, 0, errors.AddStack(err)
+	}
+	seq, _ := strconv.ParseUint(seqCmd.Val(), 10, 64)
+	if seq < cursor {
+		// The log has been reset, list it from the beginning.
+		return p.ListRevocations(ctx, 0)
+	}
+	next := cursor
+	revs := make([]*service.TokenRevocation, 0, len(logCmd.Val()))
+	for _, z := range logCmd.Val() {
+		member, _ := z.Member.(string)
+		if rev := parseRevocation(member); rev != nil {
+			revs = append(revs, rev)
+		}
+		if x := uint64(z.Score); x > next {
+			next = x
+		}
+	}
+	return revs, next, nil
+}
+
+// formatRevocation formats a revocation as a member of the log,
+// "t:{token}" or "u:{app_id}:{user_id}:{before_msec}".
+func formatRevocation(rev *service.TokenRevocation) string {
+	if rev.Token != "" {
+		return "t:" + rev.Token
+	}
+	return fmt.Sprintf("u:%d:%d:%d", rev.AppId, rev.UserId, rev.BeforeMsec)
+}
+
+func parseRevocation(member string) *service.TokenRevocation {
+	switch {
+	case strings.HasPrefix(member, "t:"):
+		return &service.TokenRevocation{Token: member[2:]}
+	case strings.HasPrefix(member, "u:"):
+		parts := strings.Split(member[2:], ":")
+		if len(parts) != 3 {
+			return nil
+		}
+		appId, err1 := strconv.ParseInt(parts[0], 10, 64)
+		userId, err2 := strconv.ParseInt(parts[1], 10, 64)
+		beforeMsec, err3 := strconv.ParseInt(parts[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil
+		}
+		return &service.TokenRevocation{AppId: appId, UserId: userId, BeforeMsec: beforeMsec}
+	}
+	return nil
+}
+
+func (p *tokenDaoImpl) IsTokenRevoked(ctx context.Context, appId, userId int64, token string, signTimeMsec int64) (bool, error) {
+	// The keys may be in different cluster slots, they are read in
+	// a pipeline instead of MGET.
+	var tokenCmd, userCmd *redis.StringCmd
+	cmds, _ := p.redisCli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		tokenCmd = pipe.Get(ctx, revokedTokenKey(token))
+		if userId > 0 {
+			userCmd = pipe.Get(ctx, revokedUserTokensKey(appId, userId))
+		}
+		return nil
+	})
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && err != redis.Nil {
+			return false, errors.AddStack(err)
+		}
+	}
+	if tokenCmd.Err() == nil {
+		return true, nil
+	}
+	if userCmd != nil && userCmd.Err() == nil {
+		beforeMsec, _ := strconv.ParseInt(userCmd.Val(), 10, 64)
+		if signTimeMsec <= beforeMsec {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *tokenDaoImpl) MarkTokenUsed(ctx context.Context, token string, ttl time.Duration) (bool, error) {
+	key := usedTokenKey(token)
+	ok, err := p.redisCli.SetNX(ctx, key, 1, ttl).Result()
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	return ok, nil
+}
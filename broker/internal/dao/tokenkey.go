@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+func NewTokenKeyDao(redisClient redis.UniversalClient) service.TokenKeyDao {
+	return &tokenKeyDaoImpl{
+		redisCli: redisClient,
+	}
+}
+
+type tokenKeyDaoImpl struct {
+	redisCli redis.UniversalClient
+}
+
+func (p *tokenKeyDaoImpl) ListTokenKeys(ctx context.Context) ([]*data.TokenKeyInfo, error) {
+	val, err := p.redisCli.HGetAll(ctx, tokenKeysKey()).Result()
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	keys := make([]*data.TokenKeyInfo, 0, len(val))
+	for _, buf := range val {
+		key := &data.TokenKeyInfo{}
+		err = proto.Unmarshal([]byte(buf), key)
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (p *tokenKeyDaoImpl) SaveTokenKey(ctx context.Context, key *data.TokenKeyInfo) error {
+	buf, err := proto.Marshal(key)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	err = p.redisCli.HSet(ctx, tokenKeysKey(), key.KeyId, buf).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *tokenKeyDaoImpl) DeleteTokenKeys(ctx context.Context, keyIds ...string) error {
+	if len(keyIds) == 0 {
+		return nil
+	}
+	err := p.redisCli.HDel(ctx, tokenKeysKey(), keyIds...).Err()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (p *tokenKeyDaoImpl) AcquireRotation(ctx context.Context, ttl time.Duration) (bool, error) {
+	ok, err := p.redisCli.SetNX(ctx, tokenKeyLockKey(), time.Now().Unix(), ttl).Result()
+	if err != nil {
+		return false, errors.AddStack(err)
+	}
+	return ok, nil
+}
@@ -1,9 +1,11 @@
 package infra
 
-import "github.com/nats-io/nats.go"
+import (
+	"github.com/nats-io/nats.go"
 
-const natsServerAddr = "127.0.0.1:4222"
+	"github.com/jxskiss/nonamegw/broker/internal/config"
+)
 
-func InitNatsClient() (*nats.Conn, error) {
-	return nats.Connect(natsServerAddr)
+func InitNatsClient(cfg *config.Config) (*nats.Conn, error) {
+	return nats.Connect(cfg.Nats.Url, nats.Name(cfg.Nats.Name))
 }
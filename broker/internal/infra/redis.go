@@ -1,28 +1,61 @@
 package infra
 
 import (
+	"context"
+	"time"
+
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v8"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/broker/internal/config"
 	"github.com/jxskiss/nonamegw/pkg/zlog"
-	"time"
 )
 
-const miniRedisAddr = "127.0.0.1:6379"
+const redisPingTimeout = 3 * time.Second
 
-func InitRedis() (*redis.Client, error) {
-	zlog.Infof("running miniredis on %v", miniRedisAddr)
+// InitRedis returns a nil client if the memory store is used.
+func InitRedis(cfg *config.Config) (redis.UniversalClient, error) {
+	if cfg.Store == config.StoreMemory {
+		zlog.Warnf("using memory store, it works only for a single broker instance")
+		return nil, nil
+	}
+	conf := cfg.Redis
+	opts := &redis.UniversalOptions{
+		Addrs:            conf.Addrs,
+		MasterName:       conf.MasterName,
+		SentinelPassword: conf.SentinelPassword,
+		Password:         conf.Password,
+		DB:               conf.DB,
+		DialTimeout:      time.Duration(conf.DialTimeoutMsec) * time.Millisecond,
+		ReadTimeout:      time.Duration(conf.ReadTimeoutMsec) * time.Millisecond,
+		WriteTimeout:     time.Duration(conf.WriteTimeoutMsec) * time.Millisecond,
+		IdleTimeout:      time.Duration(conf.IdleTimeoutMsec) * time.Millisecond,
+	}
+
+	var client redis.UniversalClient
+	switch conf.Mode {
+	case config.RedisModeDev:
+		zlog.Warnf("running miniredis on %v, dev mode must not be used in production", conf.Addrs[0])
+		s := miniredis.NewMiniRedis()
+		if err := s.StartAddr(conf.Addrs[0]); err != nil {
+			return nil, errors.AddStack(err)
+		}
+		client = redis.NewClient(opts.Simple())
+	case config.RedisModeSentinel:
+		client = redis.NewFailoverClient(opts.Failover())
+	case config.RedisModeCluster:
+		client = redis.NewClusterClient(opts.Cluster())
+	default:
+		client = redis.NewClient(opts.Simple())
+	}
 
-	s := miniredis.NewMiniRedis()
-	err := s.StartAddr(miniRedisAddr)
-	if err != nil {
-		return nil, err
+	ctx, cancel := context.WithTimeout(context.Background(), redisPingTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, errors.AddStack(err)
 	}
-	client := redis.NewClient(&redis.Options{
-		Addr:         miniRedisAddr,
-		DialTimeout:  50 * time.Millisecond,
-		ReadTimeout:  100 * time.Millisecond,
-		WriteTimeout: 100 * time.Millisecond,
-		IdleTimeout:  time.Second,
-	})
+	zlog.Infof("connected to redis, mode= %v, addrs= %v", conf.Mode, conf.Addrs)
 	return client, nil
 }
@@ -8,4 +8,8 @@ import (
 type BizApi interface {
 	OnMessage(ctx context.Context, message *protocol.Message) error
 	OnEvent(ctx context.Context, event *protocol.Event) error
+
+	// Close flushes pending messages and events, and waits for
+	// in-flight calls to finish, the calls are canceled when ctx is done.
+	Close(ctx context.Context) error
 }
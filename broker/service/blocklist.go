@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jxskiss/errors"
+	"github.com/jxskiss/gopkg/set"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+)
+
+const DefaultBlocklistCacheTTL = 10 * time.Second
+
+type BlocklistDao interface {
+	GetBlocklist(ctx context.Context, appId int64) (*brokersvc.Blocklist, error)
+	AddBlocklist(ctx context.Context, appId int64, list *brokersvc.Blocklist) error
+	RemoveBlocklist(ctx context.Context, appId int64, list *brokersvc.Blocklist) error
+}
+
+func NewBlocklist(dao BlocklistDao) *Blocklist {
+	return &Blocklist{
+		CacheTTL: DefaultBlocklistCacheTTL,
+		dao:      dao,
+		cache:    make(map[int64]*compiledBlocklist),
+	}
+}
+
+// Blocklist rejects clients by IP, user ID or device ID at token
+// verification. Blocklists are stored in Redis and cached in memory
+// for CacheTTL, changes made by other broker instances take effect
+// after the cache expires.
+type Blocklist struct {
+	CacheTTL time.Duration
+
+	dao BlocklistDao
+
+	mu    sync.Mutex
+	cache map[int64]*compiledBlocklist
+}
+
+type compiledBlocklist struct {
+	expire    time.Time
+	nets      []*net.IPNet
+	userIds   set.Int64
+	deviceIds set.Int64
+}
+
+// Check returns errcode.ClientBlocked if the client is blocked.
+func (b *Blocklist) Check(ctx context.Context, appId, userId, deviceId int64, clientIp string) error {
+	list, err := b.get(ctx, appId)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	if userId > 0 && list.userIds.Contains(userId) {
+		return errors.AddStack(errcode.ClientBlocked)
+	}
+	if deviceId > 0 && list.deviceIds.Contains(deviceId) {
+		return errors.AddStack(errcode.ClientBlocked)
+	}
+	if ip := parseClientIp(clientIp); ip != nil {
+		for _, n := range list.nets {
+			if n.Contains(ip) {
+				return errors.AddStack(errcode.ClientBlocked)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Blocklist) Get(ctx context.Context, appId int64) (*brokersvc.Blocklist, error) {
+	list, err := b.dao.GetBlocklist(ctx, appId)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return list, nil
+}
+
+func (b *Blocklist) Update(ctx context.Context, appId int64, add, remove *brokersvc.Blocklist) error {
+	for _, cidr := range add.GetCidrs() {
+		if _, err := parseCIDR(cidr); err != nil {
+			return errors.AddStack(err)
+		}
+	}
+	if add != nil {
+		if err := b.dao.AddBlocklist(ctx, appId, add); err != nil {
+			return errors.AddStack(err)
+		}
+	}
+	if remove != nil {
+		if err := b.dao.RemoveBlocklist(ctx, appId, remove); err != nil {
+			return errors.AddStack(err)
+		}
+	}
+	b.mu.Lock()
+	delete(b.cache, appId)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *Blocklist) get(ctx context.Context, appId int64) (*compiledBlocklist, error) {
+	now := time.Now()
+	b.mu.Lock()
+	list := b.cache[appId]
+	b.mu.Unlock()
+	if list != nil && now.Before(list.expire) {
+		return list, nil
+	}
+
+	raw, err := b.dao.GetBlocklist(ctx, appId)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	list = &compiledBlocklist{
+		expire:    now.Add(b.CacheTTL),
+		userIds:   set.NewInt64(raw.GetUserIds()...),
+		deviceIds: set.NewInt64(raw.GetDeviceIds()...),
+	}
+	for _, cidr := range raw.GetCidrs() {
+		n, err := parseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		list.nets = append(list.nets, n)
+	}
+	b.mu.Lock()
+	b.cache[appId] = list
+	b.mu.Unlock()
+	return list, nil
+}
+
+// parseCIDR accepts a plain IP address as a single address network.
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, errors.Errorf("invalid IP address %q", cidr)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return n, nil
+}
+
+// parseClientIp parses an IP address which may have a port, it returns
+// nil if addr is not a valid IP address.
+func parseClientIp(addr string) net.IP {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return net.ParseIP(addr)
+}
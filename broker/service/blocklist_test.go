@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jxskiss/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+)
+
+func TestBlocklist(t *testing.T) {
+	ctx := context.Background()
+	blocklist := service.NewBlocklist(dao.NewBlocklistDao(newTestRedis(t)))
+
+	err := blocklist.Update(ctx, 1001, &brokersvc.Blocklist{Cidrs: []string{"10.0.0.0/8/"}}, nil)
+	assert.NotNil(t, err)
+
+	err = blocklist.Update(ctx, 1001, &brokersvc.Blocklist{
+		Cidrs:     []string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"},
+		UserIds:   []int64{123},
+		DeviceIds: []int64{456},
+	}, nil)
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		appId, userId, deviceId int64
+		clientIp                string
+		blocked                 bool
+	}{
+		{1001, 1, 1, "10.1.2.3", true},
+		{1001, 1, 1, "192.168.1.1", true},
+		{1001, 1, 1, "192.168.1.2", false},
+		{1001, 1, 1, "fd00::1", true},
+		{1001, 1, 1, "192.168.1.1:1234", true},
+		{1001, 1, 1, "[fd00::1]:1234", true},
+		{1001, 123, 1, "127.0.0.1", true},
+		{1001, 1, 456, "127.0.0.1", true},
+		{1002, 123, 456, "10.1.2.3", false},
+	} {
+		err = blocklist.Check(ctx, tc.appId, tc.userId, tc.deviceId, tc.clientIp)
+		if tc.blocked {
+			assert.Equal(t, errcode.ClientBlocked, errors.Cause(err), "%+v", tc)
+		} else {
+			assert.Nil(t, err, "%+v", tc)
+		}
+	}
+
+	err = blocklist.Update(ctx, 1001, nil, &brokersvc.Blocklist{UserIds: []int64{123}})
+	require.Nil(t, err)
+	assert.Nil(t, blocklist.Check(ctx, 1001, 123, 1, "127.0.0.1"))
+
+	list, err := blocklist.Get(ctx, 1001)
+	require.Nil(t, err)
+	assert.Len(t, list.Cidrs, 3)
+	assert.Len(t, list.UserIds, 0)
+	assert.Equal(t, []int64{456}, list.DeviceIds)
+}
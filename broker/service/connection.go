@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"github.com/jxskiss/nonamegw/proto/data"
 )
 
@@ -20,7 +22,7 @@ hash
 zset
 - Key: s:u:{app_id}:{user_id}
 - Member: connection_id
-- Score: connection last update time
+- Score: connection last update time in milliseconds
 
 (app_id, device_id)
 用于推送非登录用户。
@@ -31,16 +33,48 @@ zset
 - Key: "s:d:{app_id}:{device_id}"
 - Member, Score 同 (app_id, user_id) 索引
 
+(machine_id)
+用于 comet 机器下线时批量清理该机器上的连接。
+hash
+- Key: "m:c:{machine_id}"
+- Hash key: connection_id
+- Hash Value: connection meta information
+- HSCAN KEY 遍历一台机器上的所有连接
+- DEL KEY 机器下线清理完成后删除索引
+
+(app_id)
+用于按 app 分页遍历在线连接及统计在线数，数值为近似值。
+zset
+- Key: "a:c:{app_id}", Member: connection_id
+- Key: "a:u:{app_id}", Member: user_id
+- Key: "a:d:{app_id}", Member: device_id (仅非登录设备)
+- Score: 最近一次保存或 TOUCH 的时间，单位毫秒
+- ZSCAN "a:c:{app_id}" 分页遍历，连接信息从 machine 索引中读取
+- ZCOUNT KEY since +inf 统计最近活跃数
+用户或设备的连接全部删除后，从 "a:u" / "a:d" 中删除；
+后台清理时按超时阈值 ZREMRANGEBYSCORE 清理残留成员。
+
 过期连接清理
-每次有连接活跃时，清理不活跃连接
-- zrevrange zsetKey 0 5 (以最多保留5个连接为例)
-- IF len(members) > 5:
-  - Get outdated connection IDs from zrevrange zsetKey 5 -1 (仅保留5个连接)
-  - zremrangebyrank zsetKey 5 -1
-  - hdel hashKey [outdated connection IDs]...
-- Always do:
-  - expire hashKey purgeTTL
-  - expire zsetKey purgeTTL
+保存连接时，在同一个 Lua 脚本中原子地清理超出数量限制的连接 (以最多保留5个连接为例)
+- hset hashKey connection_id meta
+- zadd zsetKey score connection_id
+- expire hashKey purgeTTL
+- expire zsetKey purgeTTL
+- ids = zrevrange zsetKey 5 -1
+- zrem zsetKey [ids]...
+- hdel hashKey [ids]...
+- 返回被清理的连接，用于踢下线及发送 DISCONNECT 事件
+hashKey 和 zsetKey 使用相同的 hash tag，保证在 Redis 集群的同一个 slot。
+machineKey 可能在其他 slot，在脚本执行后单独更新。
+
+后台清理超时连接
+连接的 score 由 TOUCH 事件刷新，broker 定期 SCAN 所有 zsetKey，
+在 Lua 脚本中原子地清理 score 早于超时阈值的连接
+- ids = zrangebyscore zsetKey -inf (threshold
+- zrem zsetKey [ids]...
+- hdel hashKey [ids]...
+- 返回被清理的连接，用于发送 DISCONNECT 事件 (reason: timeout)
+多个 broker 实例同时清理时，每个连接只会被一个实例清理并发送事件。
 
 临时连接
 临时连接不关联 user_id / device_id，不接受 user_id / device_id 推送及广播推送。
@@ -48,10 +82,42 @@ zset
 */
 
 type ConnectionDao interface {
-	SaveConnection(ctx context.Context, connection *data.ConnectionInfo) error
+	// SaveConnection saves connection to the routing table, then keeps
+	// only the latest limit connections of the user or device, the older
+	// connections are evicted from the routing table and returned.
+	// A non-positive limit means no limit.
+	SaveConnection(ctx context.Context, connection *data.ConnectionInfo, limit int) (evicted []*data.ConnectionInfo, err error)
 	DeleteConnection(ctx context.Context, appId, userId, deviceId int64, connectionId string) error
 	TouchConnection(ctx context.Context, appId, userId, deviceId int64, connectionId string) error
 
 	ListUserConnections(ctx context.Context, appId int64, userIds []int64) (map[int64][]*data.ConnectionInfo, error)
 	ListDeviceConnections(ctx context.Context, appId int64, deviceIds []int64) (map[int64][]*data.ConnectionInfo, error)
+
+	// ScanMachineConnections iterates connections served by a comet machine,
+	// it returns the next cursor, a zero cursor means the iteration is done.
+	ScanMachineConnections(ctx context.Context, machineId string, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error)
+	DeleteMachineConnections(ctx context.Context, machineId string) error
+
+	// GetConnections returns connections of the app found by IDs.
+	GetConnections(ctx context.Context, appId int64, connectionIds []string) ([]*data.ConnectionInfo, error)
+
+	// ScanAppConnections iterates connections of an app which are active
+	// since activeSince, a zero activeSince means all connections.
+	// It returns the next cursor, a zero cursor means the iteration is done.
+	ScanAppConnections(ctx context.Context, appId int64, activeSince time.Time, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error)
+
+	// CountOnline counts users, devices and connections of an app which
+	// are active since activeSince, a zero activeSince counts all.
+	CountOnline(ctx context.Context, appId int64, activeSince time.Time) (*OnlineCount, error)
+
+	// SweepConnections removes connections which are not saved or touched
+	// since before from the routing table, fn is called with each batch
+	// of the removed connections, count is a hint of the batch size.
+	SweepConnections(ctx context.Context, before time.Time, count int64, fn func(removed []*data.ConnectionInfo)) error
+}
+
+type OnlineCount struct {
+	Users       int64
+	Devices     int64
+	Connections int64
 }
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jxskiss/errors"
+	"github.com/jxskiss/gopkg/set"
+
+	"github.com/jxskiss/nonamegw/pkg/connid"
+	"github.com/jxskiss/nonamegw/pkg/model"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+const (
+	DefaultResumeWindow = 30 * time.Second
+
+	// DefaultMaxConnections is the max number of connections kept in
+	// the routing table for a user or device.
+	DefaultMaxConnections = 5
+
+	deferredEventTimeout = 3 * time.Second
+
+	// DisconnectReasonTimeout is the reason of DISCONNECT events of the
+	// connections removed from the routing table for not being touched.
+	DisconnectReasonTimeout = "timeout"
+)
+
+func NewEventHandler(connDao ConnectionDao, sessionDao SessionDao, bizapi BizApi) *EventHandler {
+	return &EventHandler{
+		ResumeWindow:   DefaultResumeWindow,
+		MaxConnections: DefaultMaxConnections,
+		connDao:        connDao,
+		sessionDao:     sessionDao,
+		bizapi:         bizapi,
+		disconnects:    make(map[string]*deferredDisconnect),
+	}
+}
+
+// EventHandler handles connection events reported by comet servers,
+// it maintains the connection routing table and notifies the business
+// service about the events.
+type EventHandler struct {
+	// ResumeWindow is the duration a DISCONNECT event is held back,
+	// waiting for the client to resume its session by reconnecting.
+	// If the session is resumed in time, the business service receives
+	// only a RECONNECT event instead of a DISCONNECT/CONNECT pair.
+	// Zero disables holding back DISCONNECT events.
+	ResumeWindow time.Duration
+
+	// MaxConnections is the max number of connections of a user or
+	// device, AppMaxConnections overrides it for specific apps.
+	// When a new connection exceeds the limit, the oldest connections
+	// are evicted, DISCONNECT events are delivered for them and the
+	// eviction watchers are notified to kick them.
+	MaxConnections    int
+	AppMaxConnections map[int64]int
+
+	connDao    ConnectionDao
+	sessionDao SessionDao
+	bizapi     BizApi
+
+	mu              sync.Mutex
+	disconnects     map[string]*deferredDisconnect
+	evictionWatches []func(conns []*protocol.Connection)
+}
+
+type deferredDisconnect struct {
+	event *protocol.Event
+	timer *time.Timer
+}
+
+// HandleEvent updates the routing table according to event, then
+// delivers the event to business service.
+//
+// The event is always delivered to business service even if updating
+// routing table failed, in which case the routing error is returned.
+func (h *EventHandler) HandleEvent(ctx context.Context, event *protocol.Event) error {
+	switch event.GetType() {
+	case protocol.Event_CONNECT:
+		fillConnectionMeta(event.GetConn(), time.Now())
+		h.saveSession(ctx, event.GetConn())
+	case protocol.Event_RECONNECT:
+		// The session is resumed before updating the routing table,
+		// so that the new connection is routed with the merged state.
+		fillConnectionMeta(event.GetConn(), time.Now())
+		h.saveSession(ctx, event.GetConn())
+		err := h.resumeSession(ctx, event)
+		if err != nil {
+			zlog.Errorf("failed resume session, conn_id= %v, old_id= %v, err= %v",
+				event.GetConn().GetId(), event.GetReconnectData().GetOldId(), err)
+		}
+	}
+	routeErr := h.updateRoutingTable(ctx, event)
+	switch event.GetType() {
+	case protocol.Event_DISCONNECT:
+		if h.ResumeWindow > 0 {
+			h.deferDisconnect(event)
+			return routeErr
+		}
+		h.deleteSession(ctx, event)
+	case protocol.Event_KICKOFF:
+		h.deleteSession(ctx, event)
+	}
+	err := h.bizapi.OnEvent(ctx, event)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return routeErr
+}
+
+func (h *EventHandler) updateRoutingTable(ctx context.Context, event *protocol.Event) error {
+	conn := event.GetConn()
+	appId, userId, deviceId := conn.GetAppId(), conn.GetUserId(), conn.GetDeviceId()
+	if userId <= 0 && deviceId <= 0 {
+		// 临时连接不需要维护路由表
+		return nil
+	}
+
+	var err error
+	switch event.GetType() {
+	case protocol.Event_CONNECT:
+		err = h.saveConnection(ctx, conn)
+	case protocol.Event_TOUCH:
+		err = h.connDao.TouchConnection(ctx, appId, userId, deviceId, conn.GetId())
+	case protocol.Event_DISCONNECT, protocol.Event_KICKOFF:
+		err = h.connDao.DeleteConnection(ctx, appId, userId, deviceId, conn.GetId())
+	case protocol.Event_RECONNECT:
+		oldId := event.GetReconnectData().GetOldId()
+		if oldId != "" && oldId != conn.GetId() {
+			err = h.connDao.DeleteConnection(ctx, appId, userId, deviceId, oldId)
+			if err != nil {
+				break
+			}
+		}
+		err = h.saveConnection(ctx, conn)
+	default:
+		err = errors.Errorf("unknown event type %v", event.GetType())
+	}
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// fillConnectionMeta fills the fields of a new connection which are
+// not reported by the client, from token claims and by broker.
+func fillConnectionMeta(conn *protocol.Connection, now time.Time) {
+	if conn == nil {
+		return
+	}
+	if connId, err := connid.ParseConnectionId(conn.Id); err == nil {
+		conn.MachineId = connId.MachineId
+	}
+	nowMsec := now.UnixNano() / 1e6
+	if conn.ConnectTimeMsec == 0 {
+		conn.ConnectTimeMsec = nowMsec
+	}
+	conn.LastActiveTimeMsec = nowMsec
+
+	claims := conn.GetClaims()
+	if conn.Platform == "" {
+		conn.Platform = claims.GetPlatform()
+	}
+	if conn.Locale == "" {
+		conn.Locale = claims.GetLocale()
+	}
+	for k, v := range claims.GetExtra() {
+		if _, ok := conn.Attributes[k]; !ok {
+			if conn.Attributes == nil {
+				conn.Attributes = make(map[string]string, len(claims.GetExtra()))
+			}
+			conn.Attributes[k] = v
+		}
+	}
+}
+
+func (h *EventHandler) saveConnection(ctx context.Context, conn *protocol.Connection) error {
+	limit := h.MaxConnections
+	if n, ok := h.AppMaxConnections[conn.GetAppId()]; ok {
+		limit = n
+	}
+	evicted, err := h.connDao.SaveConnection(ctx, model.FromProtocolConnection(conn), limit)
+	if len(evicted) > 0 {
+		h.evictConnections(ctx, evicted)
+	}
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// WatchEviction registers a function which is called with the connections
+// evicted from the routing table, eg. to kick them from comet servers.
+func (h *EventHandler) WatchEviction(fn func(conns []*protocol.Connection)) {
+	h.mu.Lock()
+	h.evictionWatches = append(h.evictionWatches, fn)
+	h.mu.Unlock()
+}
+
+func (h *EventHandler) evictConnections(ctx context.Context, evicted []*data.ConnectionInfo) {
+	conns := make([]*protocol.Connection, 0, len(evicted))
+	for _, c := range evicted {
+		conns = append(conns, model.ToProtocolConnection(c))
+	}
+	zlog.Infof("evicted connections exceeding limit, conn_ids= %v", connectionIds(conns))
+
+	h.mu.Lock()
+	watchers := h.evictionWatches
+	h.mu.Unlock()
+	for _, fn := range watchers {
+		fn(conns)
+	}
+
+	for _, conn := range conns {
+		event := &protocol.Event{
+			Conn: conn,
+			Type: protocol.Event_DISCONNECT,
+		}
+		h.deleteSession(ctx, event)
+		if err := h.bizapi.OnEvent(ctx, event); err != nil {
+			zlog.Errorf("failed deliver disconnect event, conn_id= %v, err= %v", conn.GetId(), err)
+		}
+	}
+}
+
+// ExpireConnections delivers DISCONNECT events with reason timeout for
+// the connections which have been removed from the routing table.
+func (h *EventHandler) ExpireConnections(ctx context.Context, expired []*data.ConnectionInfo) {
+	for _, c := range expired {
+		event := &protocol.Event{
+			Conn: model.ToProtocolConnection(c),
+			Type: protocol.Event_DISCONNECT,
+			DisconnectData: &protocol.Event_DisconnectData{
+				Reason: DisconnectReasonTimeout,
+			},
+		}
+		h.deleteSession(ctx, event)
+		if err := h.bizapi.OnEvent(ctx, event); err != nil {
+			zlog.Errorf("failed deliver disconnect event, conn_id= %v, err= %v", c.Id, err)
+		}
+	}
+}
+
+func connectionIds(conns []*protocol.Connection) []string {
+	ids := make([]string, 0, len(conns))
+	for _, c := range conns {
+		ids = append(ids, c.GetId())
+	}
+	return ids
+}
+
+func (h *EventHandler) resumeSession(ctx context.Context, event *protocol.Event) error {
+	conn := event.GetConn()
+	oldId := event.GetReconnectData().GetOldId()
+	if oldId == "" || oldId == conn.GetId() {
+		return nil
+	}
+
+	// The DISCONNECT event of the old connection may be handled by
+	// another broker instance, or arrive later than this RECONNECT event,
+	// in which case it is suppressed by checking SessionDao.IsResumed.
+	h.mu.Lock()
+	if dd := h.disconnects[oldId]; dd != nil {
+		dd.timer.Stop()
+		delete(h.disconnects, oldId)
+	}
+	h.mu.Unlock()
+
+	state, err := h.sessionDao.TransferSession(ctx, conn.GetAppId(), conn.GetUserId(), oldId, conn.GetId())
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	applySessionState(conn, state)
+	return nil
+}
+
+// saveSession saves the session state known by broker when a connection
+// is established, temporary connections have no session.
+func (h *EventHandler) saveSession(ctx context.Context, conn *protocol.Connection) {
+	if conn.GetUserId() <= 0 && conn.GetDeviceId() <= 0 {
+		return
+	}
+	state := &data.SessionState{
+		Tags:       conn.GetClaims().GetTags(),
+		Attributes: conn.GetAttributes(),
+	}
+	err := h.sessionDao.SaveSession(ctx, conn.GetAppId(), conn.GetUserId(), conn.GetId(), state)
+	if err != nil {
+		zlog.Errorf("failed save session, conn_id= %v, err= %v", conn.GetId(), err)
+	}
+}
+
+// applySessionState sets the tags and attributes of a resumed session
+// to the new connection, the values of the new connection take precedence.
+func applySessionState(conn *protocol.Connection, state *data.SessionState) {
+	if state == nil {
+		return
+	}
+	if len(state.Tags) > 0 {
+		if conn.Claims == nil {
+			conn.Claims = &protocol.TokenClaims{}
+		}
+		tags := set.NewString(conn.Claims.Tags...)
+		for _, tag := range state.Tags {
+			if !tags.Contains(tag) {
+				tags.Add(tag)
+				conn.Claims.Tags = append(conn.Claims.Tags, tag)
+			}
+		}
+	}
+	for k, v := range state.Attributes {
+		if _, ok := conn.Attributes[k]; !ok {
+			if conn.Attributes == nil {
+				conn.Attributes = make(map[string]string, len(state.Attributes))
+			}
+			conn.Attributes[k] = v
+		}
+	}
+}
+
+func (h *EventHandler) deleteSession(ctx context.Context, event *protocol.Event) {
+	conn := event.GetConn()
+	err := h.sessionDao.DeleteSession(ctx, conn.GetAppId(), conn.GetUserId(), conn.GetId())
+	if err != nil {
+		zlog.Errorf("failed delete session, conn_id= %v, err= %v", conn.GetId(), err)
+	}
+}
+
+func (h *EventHandler) deferDisconnect(event *protocol.Event) {
+	connId := event.GetConn().GetId()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.disconnects[connId] != nil {
+		return
+	}
+	h.disconnects[connId] = &deferredDisconnect{
+		event: event,
+		timer: time.AfterFunc(h.ResumeWindow, func() {
+			h.fireDisconnect(connId)
+		}),
+	}
+}
+
+func (h *EventHandler) fireDisconnect(connId string) {
+	h.mu.Lock()
+	dd := h.disconnects[connId]
+	delete(h.disconnects, connId)
+	h.mu.Unlock()
+	if dd == nil {
+		return
+	}
+	h.deliverDisconnect(dd.event)
+}
+
+func (h *EventHandler) deliverDisconnect(event *protocol.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), deferredEventTimeout)
+	defer cancel()
+
+	conn := event.GetConn()
+	resumed, err := h.sessionDao.IsResumed(ctx, conn.GetAppId(), conn.GetUserId(), conn.GetId())
+	if err != nil {
+		zlog.Errorf("failed check session resumed, conn_id= %v, err= %v", conn.GetId(), err)
+	}
+	if resumed {
+		return
+	}
+	h.deleteSession(ctx, event)
+	err = h.bizapi.OnEvent(ctx, event)
+	if err != nil {
+		zlog.Errorf("failed deliver disconnect event, conn_id= %v, err= %v", conn.GetId(), err)
+	}
+}
+
+// Close delivers all held back DISCONNECT events immediately.
+func (h *EventHandler) Close() error {
+	h.mu.Lock()
+	disconnects := h.disconnects
+	h.disconnects = make(map[string]*deferredDisconnect)
+	h.mu.Unlock()
+
+	for _, dd := range disconnects {
+		dd.timer.Stop()
+		h.deliverDisconnect(dd.event)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+const (
+	testConnId1 = "0QNFX42SPVYVVQ0203ZG00800812A09Q05BK0E9G74JA8"
+	testConnId2 = "0QNFX42SPVYVVQ0203ZG00800812A09Q05BK0E9G74JB0"
+)
+
+type fakeBizApi struct {
+	mu       sync.Mutex
+	messages []*protocol.Message
+	events   []*protocol.Event
+}
+
+func (p *fakeBizApi) OnMessage(ctx context.Context, message *protocol.Message) error {
+	p.mu.Lock()
+	p.messages = append(p.messages, message)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *fakeBizApi) OnEvent(ctx context.Context, event *protocol.Event) error {
+	p.mu.Lock()
+	p.events = append(p.events, event)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *fakeBizApi) Close(ctx context.Context) error { return nil }
+
+func (p *fakeBizApi) eventTypes() []protocol.Event_Type {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []protocol.Event_Type
+	for _, e := range p.events {
+		out = append(out, e.GetType())
+	}
+	return out
+}
+
+func newTestRedis(t *testing.T) *redis.Client {
+	s, err := miniredis.Run()
+	require.Nil(t, err)
+	t.Cleanup(s.Close)
+	return redis.NewClient(&redis.Options{Addr: s.Addr()})
+}
+
+func newTestEvent(typ protocol.Event_Type, connId string) *protocol.Event {
+	return &protocol.Event{
+		Type: typ,
+		Conn: &protocol.Connection{
+			Id:            connId,
+			AppId:         1001,
+			UserId:        123,
+			DeviceId:      456,
+			ClientIp:      "127.0.0.1",
+			ClientVersion: "1.0.0",
+		},
+	}
+}
+
+func listUserConnIds(t *testing.T, connDao service.ConnectionDao, userId int64) []string {
+	conns, err := connDao.ListUserConnections(context.Background(), 1001, []int64{userId})
+	require.Nil(t, err)
+	var out []string
+	for _, c := range conns[userId] {
+		out = append(out, c.Id)
+	}
+	return out
+}
+
+func TestEventHandlerRoutingTable(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	handler := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), bizapi)
+	handler.ResumeWindow = 0
+
+	t.Run("connect", func(t *testing.T) {
+		err := handler.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, testConnId1))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{testConnId1}, listUserConnIds(t, connDao, 123))
+	})
+
+	t.Run("touch", func(t *testing.T) {
+		err := handler.HandleEvent(ctx, newTestEvent(protocol.Event_TOUCH, testConnId1))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{testConnId1}, listUserConnIds(t, connDao, 123))
+	})
+
+	t.Run("reconnect", func(t *testing.T) {
+		event := newTestEvent(protocol.Event_RECONNECT, testConnId2)
+		event.ReconnectData = &protocol.Event_ReconnectData{OldId: testConnId1}
+		err := handler.HandleEvent(ctx, event)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{testConnId2}, listUserConnIds(t, connDao, 123))
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		err := handler.HandleEvent(ctx, newTestEvent(protocol.Event_DISCONNECT, testConnId2))
+		assert.Nil(t, err)
+		assert.Len(t, listUserConnIds(t, connDao, 123), 0)
+	})
+
+	t.Run("kickoff", func(t *testing.T) {
+		err := handler.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, testConnId1))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{testConnId1}, listUserConnIds(t, connDao, 123))
+
+		err = handler.HandleEvent(ctx, newTestEvent(protocol.Event_KICKOFF, testConnId1))
+		assert.Nil(t, err)
+		assert.Len(t, listUserConnIds(t, connDao, 123), 0)
+	})
+
+	assert.Len(t, bizapi.events, 6)
+}
+
+func TestEventHandlerTemporaryConnection(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	handler := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), bizapi)
+
+	event := newTestEvent(protocol.Event_CONNECT, testConnId1)
+	event.Conn.UserId = 0
+	event.Conn.DeviceId = 0
+	err := handler.HandleEvent(ctx, event)
+	assert.Nil(t, err)
+	assert.Len(t, bizapi.events, 1)
+}
+
+func TestEventHandlerSessionResume(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	sessionDao := dao.NewSessionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	handler := service.NewEventHandler(connDao, sessionDao, bizapi)
+	handler.ResumeWindow = 50 * time.Millisecond
+
+	connect := newTestEvent(protocol.Event_CONNECT, testConnId1)
+	connect.Conn.Claims = &protocol.TokenClaims{Tags: []string{"room:1"}}
+	connect.Conn.Attributes = map[string]string{"locale": "en"}
+	err := handler.HandleEvent(ctx, connect)
+	require.Nil(t, err)
+
+	err = handler.HandleEvent(ctx, newTestEvent(protocol.Event_DISCONNECT, testConnId1))
+	require.Nil(t, err)
+	assert.Len(t, listUserConnIds(t, connDao, 123), 0)
+
+	event := newTestEvent(protocol.Event_RECONNECT, testConnId2)
+	event.ReconnectData = &protocol.Event_ReconnectData{OldId: testConnId1}
+	err = handler.HandleEvent(ctx, event)
+	require.Nil(t, err)
+	assert.Equal(t, []string{testConnId2}, listUserConnIds(t, connDao, 123))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []protocol.Event_Type{protocol.Event_CONNECT, protocol.Event_RECONNECT}, bizapi.eventTypes())
+
+	oldState, err := sessionDao.GetSession(ctx, 1001, 123, testConnId1)
+	assert.Nil(t, err)
+	assert.Nil(t, oldState)
+	newState, err := sessionDao.GetSession(ctx, 1001, 123, testConnId2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"room:1"}, newState.Tags)
+	assert.Equal(t, "en", newState.Attributes["locale"])
+
+	// The new connection is routed with the resumed tags and attributes.
+	conns, err := connDao.GetConnections(ctx, 1001, []string{testConnId2})
+	require.Nil(t, err)
+	require.Len(t, conns, 1)
+	assert.Equal(t, []string{"room:1"}, conns[0].GetClaims().GetTags())
+	assert.Equal(t, "en", conns[0].Attributes["locale"])
+}
+
+func TestEventHandlerDisconnectWithoutResume(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	sessionDao := dao.NewSessionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	handler := service.NewEventHandler(connDao, sessionDao, bizapi)
+	handler.ResumeWindow = 50 * time.Millisecond
+
+	err := handler.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, testConnId1))
+	require.Nil(t, err)
+	err = sessionDao.SaveSession(ctx, 1001, 123, testConnId1, &data.SessionState{SyncSeq: 1})
+	require.Nil(t, err)
+	err = handler.HandleEvent(ctx, newTestEvent(protocol.Event_DISCONNECT, testConnId1))
+	require.Nil(t, err)
+	assert.Equal(t, []protocol.Event_Type{protocol.Event_CONNECT}, bizapi.eventTypes())
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []protocol.Event_Type{protocol.Event_CONNECT, protocol.Event_DISCONNECT}, bizapi.eventTypes())
+	state, err := sessionDao.GetSession(ctx, 1001, 123, testConnId1)
+	assert.Nil(t, err)
+	assert.Nil(t, state)
+}
+
+func TestEventHandlerConnectionLimit(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	handler := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), bizapi)
+	handler.MaxConnections = 5
+	handler.AppMaxConnections = map[int64]int{1001: 2}
+
+	var kicked []string
+	handler.WatchEviction(func(conns []*protocol.Connection) {
+		for _, c := range conns {
+			kicked = append(kicked, c.GetId())
+		}
+	})
+
+	// Which connection is evicted is tested by the DAO tests, the handler
+	// kicks it and emits a DISCONNECT event for it.
+	connIds := []string{"conn-1", "conn-2", "conn-3"}
+	for _, connId := range connIds {
+		err := handler.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, connId))
+		require.Nil(t, err)
+	}
+	require.Len(t, kicked, 1)
+	remaining := listUserConnIds(t, connDao, 123)
+	assert.Len(t, remaining, 2)
+	assert.ElementsMatch(t, connIds, append(remaining, kicked[0]))
+	assert.Equal(t, []protocol.Event_Type{
+		protocol.Event_CONNECT,
+		protocol.Event_CONNECT,
+		protocol.Event_DISCONNECT,
+		protocol.Event_CONNECT,
+	}, bizapi.eventTypes())
+	assert.Equal(t, kicked[0], bizapi.events[2].GetConn().GetId())
+	assert.Equal(t, int64(123), bizapi.events[2].GetConn().GetUserId())
+}
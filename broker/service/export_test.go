@@ -0,0 +1,18 @@
+package service
+
+import "context"
+
+// NewTestNatsService returns a NatsService which publishes downgoing
+// messages by publish, it does not connect to NATS.
+func NewTestNatsService(registry *CometRegistry, publish PublishFunc) NatsService {
+	return &natsImpl{
+		registry:  registry,
+		publisher: NewPublisher(NewPublisherConfig(), publish),
+	}
+}
+
+// SyncRevocations syncs token revocations of a signer returned by
+// NewSigner from the store.
+func SyncRevocations(ctx context.Context, s Signer) error {
+	return s.(*signer).syncRevocations(ctx)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/pkg/filterexpr"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+// connectionFilter is a compiled brokersvc.ConnectionFilter,
+// a nil *connectionFilter matches any connection.
+type connectionFilter struct {
+	versions     map[string]bool
+	platforms    map[string]bool
+	os           map[string]bool
+	locales      map[string]bool
+	networkTypes map[string]bool
+	tags         []string
+	attributes   map[string]string
+}
+
+// newConnectionFilter compiles filter, versions and tags are added to
+// the ones in filter. It returns nil if nothing is to be filtered.
+func newConnectionFilter(filter *brokersvc.ConnectionFilter, versions, tags []string) *connectionFilter {
+	versions = append(versions[:len(versions):len(versions)], filter.GetClientVersions()...)
+	tags = append(tags[:len(tags):len(tags)], filter.GetTags()...)
+	f := &connectionFilter{
+		versions:     toStringSet(versions),
+		platforms:    toStringSet(filter.GetPlatforms()),
+		os:           toStringSet(filter.GetOs()),
+		locales:      toStringSet(filter.GetLocales()),
+		networkTypes: toStringSet(filter.GetNetworkTypes()),
+		tags:         tags,
+		attributes:   filter.GetAttributes(),
+	}
+	if f.versions == nil && f.platforms == nil && f.os == nil &&
+		f.locales == nil && f.networkTypes == nil &&
+		len(f.tags) == 0 && len(f.attributes) == 0 {
+		return nil
+	}
+	return f
+}
+
+func toStringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make(map[string]bool, len(values))
+	for _, v := range values {
+		out[v] = true
+	}
+	return out
+}
+
+func (f *connectionFilter) match(conn *data.ConnectionInfo) bool {
+	if f == nil {
+		return true
+	}
+	if !matchStringSet(f.versions, conn.ClientVersion) ||
+		!matchStringSet(f.platforms, conn.Platform) ||
+		!matchStringSet(f.os, conn.Os) ||
+		!matchStringSet(f.locales, conn.Locale) ||
+		!matchStringSet(f.networkTypes, conn.NetworkType) {
+		return false
+	}
+	for k, v := range f.attributes {
+		if val, ok := conn.Attributes[k]; !ok || val != v {
+			return false
+		}
+	}
+	for _, tag := range f.tags {
+		found := false
+		for _, t := range conn.GetClaims().GetTags() {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func matchStringSet(set map[string]bool, value string) bool {
+	return set == nil || set[value]
+}
+
+func (f *connectionFilter) filter(conns []*data.ConnectionInfo) []*data.ConnectionInfo {
+	if f == nil {
+		return conns
+	}
+	out := conns[:0]
+	for _, c := range conns {
+		if f.match(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+var connectionVars = map[string]bool{
+	"id":               true,
+	"app_id":           true,
+	"user_id":          true,
+	"device_id":        true,
+	"client_ip":        true,
+	"version":          true,
+	"platform":         true,
+	"os":               true,
+	"locale":           true,
+	"network_type":     true,
+	"machine_id":       true,
+	"connect_time":     true,
+	"last_active_time": true,
+	"tags":             true,
+	"roles":            true,
+}
+
+const attrVarPrefix = "attr."
+
+func isConnectionVar(name string) bool {
+	if strings.HasPrefix(name, attrVarPrefix) {
+		return len(name) > len(attrVarPrefix)
+	}
+	return connectionVars[name]
+}
+
+// compileConnectionExpr compiles an expression over connection attributes,
+// see brokersvc.PushTarget_Expression for the variables.
+func compileConnectionExpr(src string) (*filterexpr.Expr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.New("empty expression")
+	}
+	return filterexpr.Compile(src, isConnectionVar)
+}
+
+func connectionEnv(conn *data.ConnectionInfo) filterexpr.Env {
+	return func(name string) interface{} {
+		switch name {
+		case "id":
+			return conn.Id
+		case "app_id":
+			return float64(conn.AppId)
+		case "user_id":
+			return float64(conn.UserId)
+		case "device_id":
+			return float64(conn.DeviceId)
+		case "client_ip":
+			return conn.ClientIp
+		case "version":
+			return conn.ClientVersion
+		case "platform":
+			return conn.Platform
+		case "os":
+			return conn.Os
+		case "locale":
+			return conn.Locale
+		case "network_type":
+			return conn.NetworkType
+		case "machine_id":
+			return conn.MachineId
+		case "connect_time":
+			return float64(conn.ConnectTimeMsec)
+		case "last_active_time":
+			return float64(conn.LastActiveTimeMsec)
+		case "tags":
+			return conn.GetClaims().GetTags()
+		case "roles":
+			return conn.GetClaims().GetRoles()
+		}
+		if strings.HasPrefix(name, attrVarPrefix) {
+			if v, ok := conn.Attributes[name[len(attrVarPrefix):]]; ok {
+				return v
+			}
+		}
+		return nil
+	}
+}
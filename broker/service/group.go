@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+/*
+群组
+
+群组由业务方创建，成员为 user_id，推送 GROUP 目标时分批读取成员并查询路由表。
+
+- Key: g:i:{app_id:group_id}
+- Value: data.GroupInfo
+- SET KEY value NX 创建群组
+
+- Key: g:m:{app_id:group_id}
+- Set member: user_id
+- SADD/SREM 添加删除成员，群组不存在时不添加
+- SSCAN 分批遍历成员
+- SCARD 统计成员数
+*/
+
+const (
+	maxGroupIdLength    = 128
+	maxGroupMembersOnce = 1000
+)
+
+type GroupDao interface {
+	// CreateGroup creates a group, it returns false if the group exists.
+	CreateGroup(ctx context.Context, group *data.GroupInfo) (bool, error)
+	// GetGroup returns nil if the group does not exist.
+	GetGroup(ctx context.Context, appId int64, groupId string) (*data.GroupInfo, error)
+	DeleteGroup(ctx context.Context, appId int64, groupId string) error
+
+	// AddGroupMembers returns false if the group does not exist.
+	AddGroupMembers(ctx context.Context, appId int64, groupId string, userIds []int64) (bool, error)
+	RemoveGroupMembers(ctx context.Context, appId int64, groupId string, userIds []int64) error
+
+	// ScanGroupMembers iterates members of a group, it returns the next
+	// cursor, a zero cursor means the iteration is done.
+	ScanGroupMembers(ctx context.Context, appId int64, groupId string, cursor uint64, count int64) ([]int64, uint64, error)
+	CountGroupMembers(ctx context.Context, appId int64, groupId string) (int64, error)
+}
+
+func (p *Service) CreateGroup(ctx context.Context, request *brokersvc.CreateGroupRequest) (*brokersvc.CreateGroupResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	if err := checkGroupId(request.GetGroupId()); err != nil {
+		return nil, err
+	}
+	group := &data.GroupInfo{
+		AppId:          appId,
+		GroupId:        request.GetGroupId(),
+		Name:           request.GetName(),
+		CreateTimeMsec: time.Now().UnixNano() / 1e6,
+	}
+	created, err := p.groupDao.CreateGroup(ctx, group)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	if !created {
+		return nil, errors.AddStack(errcode.GroupExists)
+	}
+	return &brokersvc.CreateGroupResponse{}, nil
+}
+
+func (p *Service) DeleteGroup(ctx context.Context, request *brokersvc.DeleteGroupRequest) (*brokersvc.DeleteGroupResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	if err := checkGroupId(request.GetGroupId()); err != nil {
+		return nil, err
+	}
+	err := p.groupDao.DeleteGroup(ctx, appId, request.GetGroupId())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.DeleteGroupResponse{}, nil
+}
+
+func (p *Service) AddGroupMembers(ctx context.Context, request *brokersvc.AddGroupMembersRequest) (*brokersvc.AddGroupMembersResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	if err := checkGroupMembers(request.GetGroupId(), request.GetUserIds()); err != nil {
+		return nil, err
+	}
+	ok, err := p.groupDao.AddGroupMembers(ctx, appId, request.GetGroupId(), request.GetUserIds())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	if !ok {
+		return nil, errors.AddStack(errcode.GroupNotFound)
+	}
+	return &brokersvc.AddGroupMembersResponse{}, nil
+}
+
+func (p *Service) RemoveGroupMembers(ctx context.Context, request *brokersvc.RemoveGroupMembersRequest) (*brokersvc.RemoveGroupMembersResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	if err := checkGroupMembers(request.GetGroupId(), request.GetUserIds()); err != nil {
+		return nil, err
+	}
+	err := p.groupDao.RemoveGroupMembers(ctx, appId, request.GetGroupId(), request.GetUserIds())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.RemoveGroupMembersResponse{}, nil
+}
+
+func (p *Service) ListGroupMembers(ctx context.Context, request *brokersvc.ListGroupMembersRequest) (*brokersvc.ListGroupMembersResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	groupId := request.GetGroupId()
+	if err := checkGroupId(groupId); err != nil {
+		return nil, err
+	}
+	group, err := p.groupDao.GetGroup(ctx, appId, groupId)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	if group == nil {
+		return nil, errors.AddStack(errcode.GroupNotFound)
+	}
+	limit := int64(request.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	} else if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	userIds, next, err := p.groupDao.ScanGroupMembers(ctx, appId, groupId, request.GetCursor(), limit)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	total, err := p.groupDao.CountGroupMembers(ctx, appId, groupId)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.ListGroupMembersResponse{
+		Name:       group.Name,
+		UserIds:    userIds,
+		NextCursor: next,
+		Total:      total,
+	}, nil
+}
+
+// resolveGroupConnections calls fn with connections of the group members,
+// members are read in batches, so that a large group is not loaded
+// into memory at once.
+func (p *Service) resolveGroupConnections(ctx context.Context, appId int64, groupId string, fn func(conns []*data.ConnectionInfo) error) error {
+	group, err := p.groupDao.GetGroup(ctx, appId, groupId)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	if group == nil {
+		return errors.AddStack(errcode.GroupNotFound)
+	}
+	var cursor uint64
+	for {
+		userIds, next, err := p.groupDao.ScanGroupMembers(ctx, appId, groupId, cursor, resolveBatch)
+		if err != nil {
+			return errors.AddStack(err)
+		}
+		if err = p.streamUserConnections(ctx, appId, userIds, fn); err != nil {
+			return err
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func checkGroupId(groupId string) error {
+	if groupId == "" || len(groupId) > maxGroupIdLength {
+		return errors.AddStack(errcode.InvalidGroup.WithMessage("invalid group_id"))
+	}
+	return nil
+}
+
+func checkGroupMembers(groupId string, userIds []int64) error {
+	if err := checkGroupId(groupId); err != nil {
+		return err
+	}
+	if len(userIds) == 0 || len(userIds) > maxGroupMembersOnce {
+		return errors.AddStack(errcode.InvalidGroup.WithMessage(
+			"user_ids must be given and no more than %d at once", maxGroupMembersOnce))
+	}
+	for _, userId := range userIds {
+		if userId <= 0 {
+			return errors.AddStack(errcode.InvalidGroup.WithMessage("invalid user_id %d", userId))
+		}
+	}
+	return nil
+}
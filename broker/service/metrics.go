@@ -0,0 +1,30 @@
+package service
+
+import (
+	"expvar"
+)
+
+// Metrics of the broker, they are published by expvar at /debug/vars.
+var (
+	metrics = expvar.NewMap("broker")
+
+	metricSweptConnections = new(expvar.Int)
+
+	metricPublishedMessages = new(expvar.Int)
+	metricPublishErrors     = new(expvar.Int)
+	metricPublishDropped    = new(expvar.Int)
+	metricPublishQueued     = new(expvar.Int)
+)
+
+func init() {
+	metrics.Set("swept_connections", metricSweptConnections)
+	metrics.Set("published_messages", metricPublishedMessages)
+	metrics.Set("publish_errors", metricPublishErrors)
+	metrics.Set("publish_dropped", metricPublishDropped)
+	metrics.Set("publish_queued", metricPublishQueued)
+}
+
+// RegisterMetric publishes a metric of other packages in the broker map.
+func RegisterMetric(name string, v expvar.Var) {
+	metrics.Set(name, v)
+}
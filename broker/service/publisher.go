@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	stderr "errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+	"github.com/jxskiss/nonamegw/proto/messag"
+)
+
+const (
+	DefaultPublishWorkers   = 8
+	DefaultPublishQueueSize = 1024
+)
+
+var ErrPublisherClosed = stderr.New("publisher closed")
+
+type PublisherConfig struct {
+	// Workers is the number of goroutines publishing messages, messages
+	// to a machine are always published by the same worker in order.
+	Workers int
+
+	// QueueSize is the capacity of the queue of each worker, publishing
+	// blocks when the queue is full.
+	QueueSize int
+}
+
+func NewPublisherConfig() *PublisherConfig {
+	return &PublisherConfig{
+		Workers:   DefaultPublishWorkers,
+		QueueSize: DefaultPublishQueueSize,
+	}
+}
+
+// PublishFunc publishes a message to a comet machine.
+type PublishFunc func(machineId string, message *messag.DowngoingMessage) error
+
+type publishTask struct {
+	machineId string
+	message   *messag.DowngoingMessage
+}
+
+func NewPublisher(config *PublisherConfig, publish PublishFunc) *Publisher {
+	workers := config.Workers
+	if workers <= 0 {
+		workers = DefaultPublishWorkers
+	}
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultPublishQueueSize
+	}
+	p := &Publisher{
+		publish: publish,
+		queues:  make([]chan *publishTask, workers),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan *publishTask, queueSize)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+// Publisher publishes downgoing messages to comet machines by a pool
+// of workers, machines are sharded to the workers by ID.
+//
+// Each worker has a bounded queue, Publish blocks when the queue is
+// full until ctx is done, thus a slow NATS connection slows down the
+// callers instead of buffering messages without limit.
+type Publisher struct {
+	publish PublishFunc
+
+	mu     sync.RWMutex
+	closed bool
+	queues []chan *publishTask
+	wg     sync.WaitGroup
+}
+
+// Publish queues a message to be published to a machine.
+func (p *Publisher) Publish(ctx context.Context, machineId string, message *messag.DowngoingMessage) error {
+	if err := ctx.Err(); err != nil {
+		metricPublishDropped.Add(1)
+		return errors.AddStack(err)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		metricPublishDropped.Add(1)
+		return errors.AddStack(ErrPublisherClosed)
+	}
+	task := &publishTask{machineId: machineId, message: message}
+	select {
+	case p.queues[p.shard(machineId)] <- task:
+		metricPublishQueued.Add(1)
+		return nil
+	case <-ctx.Done():
+		metricPublishDropped.Add(1)
+		return errors.AddStack(ctx.Err())
+	}
+}
+
+func (p *Publisher) shard(machineId string) int {
+	h := fnv.New32a()
+	h.Write([]byte(machineId))
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+func (p *Publisher) work(queue chan *publishTask) {
+	defer p.wg.Done()
+	for task := range queue {
+		metricPublishQueued.Add(-1)
+		err := p.publish(task.machineId, task.message)
+		if err != nil {
+			metricPublishErrors.Add(1)
+			zlog.Errorf("failed publish downgoing message, machine_id= %v, conn_ids= %d, err= %v",
+				task.machineId, len(task.message.GetConnIds()), err)
+			continue
+		}
+		metricPublishedMessages.Add(1)
+	}
+}
+
+// Close stops accepting messages and waits for the queued ones
+// to be published.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, queue := range p.queues {
+			close(queue)
+		}
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+	return nil
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jxskiss/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/connid"
+	"github.com/jxskiss/nonamegw/proto/messag"
+)
+
+func TestPublisherOrder(t *testing.T) {
+	var mu sync.Mutex
+	published := make(map[string][]string)
+	publish := func(machineId string, message *messag.DowngoingMessage) error {
+		mu.Lock()
+		published[machineId] = append(published[machineId], message.ConnIds...)
+		mu.Unlock()
+		if message.ConnIds[0] == "fail" {
+			return errors.New("publish failed")
+		}
+		return nil
+	}
+	p := service.NewPublisher(&service.PublisherConfig{Workers: 4, QueueSize: 2}, publish)
+
+	ctx := context.Background()
+	want := make(map[string][]string)
+	for i := 0; i < 100; i++ {
+		machineId := fmt.Sprintf("m%d", i%5)
+		connId := fmt.Sprintf("c%d", i)
+		want[machineId] = append(want[machineId], connId)
+		err := p.Publish(ctx, machineId, &messag.DowngoingMessage{ConnIds: []string{connId}})
+		require.Nil(t, err)
+	}
+	require.Nil(t, p.Publish(ctx, "m0", &messag.DowngoingMessage{ConnIds: []string{"fail"}}))
+	want["m0"] = append(want["m0"], "fail")
+	require.Nil(t, p.Close())
+	assert.Equal(t, want, published)
+
+	err := p.Publish(ctx, "m0", &messag.DowngoingMessage{ConnIds: []string{"c0"}})
+	assert.Equal(t, service.ErrPublisherClosed, errors.Cause(err))
+}
+
+func TestPublisherBackpressure(t *testing.T) {
+	started := make(chan struct{}, 1)
+	block := make(chan struct{})
+	publish := func(machineId string, message *messag.DowngoingMessage) error {
+		started <- struct{}{}
+		<-block
+		return nil
+	}
+	p := service.NewPublisher(&service.PublisherConfig{Workers: 1, QueueSize: 1}, publish)
+
+	// One message is being published and one is queued.
+	ctx := context.Background()
+	msg := &messag.DowngoingMessage{ConnIds: []string{"c1"}}
+	require.Nil(t, p.Publish(ctx, "m1", msg))
+	<-started
+	require.Nil(t, p.Publish(ctx, "m1", msg))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	err := p.Publish(timeoutCtx, "m1", msg)
+	assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+
+	close(block)
+	require.Nil(t, p.Close())
+}
+
+func TestNatsPushMessages(t *testing.T) {
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	events := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), &fakeBizApi{})
+	registry := service.NewCometRegistry(connDao, dao.NewCometDao(redisCli), events)
+	defer registry.Close()
+
+	var mu sync.Mutex
+	published := make(map[string][]*messag.DowngoingMessage)
+	publish := func(machineId string, message *messag.DowngoingMessage) error {
+		mu.Lock()
+		published[machineId] = append(published[machineId], message)
+		mu.Unlock()
+		return nil
+	}
+	nats := service.NewTestNatsService(registry, publish)
+
+	// Two messages to 6 connections on 3 machines, connections of
+	// machine 3 are dead.
+	want := make(map[string][]string)
+	var connIds []string
+	for i := 0; i < 6; i++ {
+		connId := newTestConnId(byte(i%3+1), uint16(i))
+		id, err := connid.ParseConnectionId(connId)
+		require.Nil(t, err)
+		if i%3 < 2 {
+			want[id.MachineId] = append(want[id.MachineId], connId)
+		} else {
+			registry.Heartbeat(&messag.CometHeartbeat{
+				MachineId: id.MachineId,
+				TimeMsec:  time.Now().UnixNano() / 1e6,
+				Shutdown:  true,
+			})
+		}
+		connIds = append(connIds, connId)
+	}
+	messages := []*messag.DowngoingMessage{
+		{Data: &messag.DowngoingMessage_BinPacket{BinPacket: []byte("m1")}, ConnIds: connIds},
+		{Data: &messag.DowngoingMessage_BinPacket{BinPacket: []byte("m2")}, ConnIds: append(connIds, "invalid")},
+	}
+	require.Nil(t, nats.PushMessages(context.Background(), messages))
+	require.Nil(t, nats.Close())
+
+	assert.Len(t, published, 2)
+	for machineId, msgs := range published {
+		require.Len(t, msgs, 2, machineId)
+		assert.Equal(t, []byte("m1"), msgs[0].GetBinPacket())
+		assert.Equal(t, []byte("m2"), msgs[1].GetBinPacket())
+		for _, m := range msgs {
+			assert.Equal(t, want[machineId], m.ConnIds)
+		}
+	}
+}
@@ -2,38 +2,54 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/jxskiss/errors"
-	"github.com/jxskiss/gopkg/easy"
 	"github.com/nats-io/nats.go"
 
 	"github.com/jxskiss/nonamegw/pkg/connid"
 	"github.com/jxskiss/nonamegw/pkg/constants"
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/pkg/natsrpc"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
 	"github.com/jxskiss/nonamegw/proto/cometsvc"
 	"github.com/jxskiss/nonamegw/proto/messag"
 	"github.com/jxskiss/nonamegw/proto/protocol"
 )
 
-// TODO: context
-
 type NatsService interface {
 	Close() error
-	PushGroupedMessage(machineId string, message *messag.DowngoingMessage)
-	PushMessages(messages []*messag.DowngoingMessage)
+
+	// PushGroupedMessage publishes a message to connections on one machine,
+	// it blocks when the publish queue is full until ctx is done.
+	PushGroupedMessage(ctx context.Context, machineId string, message *messag.DowngoingMessage) error
+
+	// PushMessages groups connection IDs of each message by machine and
+	// publishes a message to each machine.
+	PushMessages(ctx context.Context, messages []*messag.DowngoingMessage) error
+
+	// GetConnectionInfo asks the comet machine for live details of a connection.
+	GetConnectionInfo(ctx context.Context, machineId, connId string) (*protocol.ConnectionDetail, error)
 }
 
-func NewNatsService(client *nats.Conn, bizapi BizApi, connDao ConnectionDao, signer Signer) (NatsService, error) {
+func NewNatsService(client *nats.Conn, pubConfig *PublisherConfig, bizapi BizApi, events *EventHandler, registry *CometRegistry, keyMgr *KeyManager, connDao ConnectionDao, signer Signer, blocklist *Blocklist) (NatsService, error) {
 	ec, err := nats.NewEncodedConn(client, "pb")
 	if err != nil {
 		return nil, err
 	}
 	impl := &natsImpl{
-		client:  ec,
-		bizapi:  bizapi,
-		connDao: connDao,
-		signer:  signer,
+		client:    ec,
+		bizapi:    bizapi,
+		events:    events,
+		registry:  registry,
+		keyMgr:    keyMgr,
+		connDao:   connDao,
+		signer:    signer,
+		blocklist: blocklist,
+		comets:    natsrpc.NewCometClient(client),
 	}
+	impl.publisher = NewPublisher(pubConfig, impl.publish)
 	if err = impl.Setup(); err != nil {
 		return nil, err
 	}
@@ -41,79 +57,150 @@ func NewNatsService(client *nats.Conn, bizapi BizApi, connDao ConnectionDao, sig
 }
 
 type natsImpl struct {
-	client  *nats.EncodedConn
-	bizapi  BizApi
-	connDao ConnectionDao
-	signer  Signer
+	client    *nats.EncodedConn
+	bizapi    BizApi
+	events    *EventHandler
+	registry  *CometRegistry
+	keyMgr    *KeyManager
+	connDao   ConnectionDao
+	signer    Signer
+	blocklist *Blocklist
+	comets    *natsrpc.CometClient
+	publisher *Publisher
+
+	subs []*nats.Subscription
 }
 
+const (
+	drainCheckInterval = 10 * time.Millisecond
+	drainTimeout       = 10 * time.Second
+
+	// handleTimeout bounds handling of a received message or event.
+	handleTimeout = 10 * time.Second
+)
+
 func (n *natsImpl) Setup() error {
 	// rpc through Nats
-	_, err := n.client.QueueSubscribe(
-		constants.BrokerRpcWildcardTopic,
-		constants.BrokerGroup,
-		n.serveRPC)
+	rpcServer := natsrpc.NewServer(n.client.Conn, &cometsvc.BrokerRequest{}, &cometsvc.BrokerResponse{})
+	rpcServer.Register(constants.BrokerRpcGetCometConfigurationTopic, n.rpcGetCometConfiguration)
+	rpcServer.Register(constants.BrokerRpcVerifyAuthTokenTopic, n.rpcVerifyAuthToken)
+	sub, err := rpcServer.Subscribe(constants.BrokerRpcWildcardTopic, constants.BrokerGroup)
 	if err != nil {
 		return errors.AddStack(err)
 	}
+	n.subs = append(n.subs, sub)
 
 	// consumer
-	_, err = n.client.QueueSubscribe(constants.UpgoingMessageTopic, constants.BrokerGroup, n.handleMessage)
+	sub, err = n.client.QueueSubscribe(constants.UpgoingMessageTopic, constants.BrokerGroup, n.handleMessage)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	n.subs = append(n.subs, sub)
+	sub, err = n.client.QueueSubscribe(constants.EventTopic, constants.BrokerGroup, n.handleEvent)
 	if err != nil {
 		return errors.AddStack(err)
 	}
-	_, err = n.client.QueueSubscribe(constants.EventTopic, constants.BrokerGroup, n.handleEvent)
+	n.subs = append(n.subs, sub)
+
+	// every broker instance keeps its own comet registry
+	sub, err = n.client.Subscribe(constants.CometHeartbeatTopic, n.registry.Heartbeat)
 	if err != nil {
 		return errors.AddStack(err)
 	}
+	n.subs = append(n.subs, sub)
+
+	n.keyMgr.Watch(n.publishCometConfiguration)
+	n.events.WatchEviction(n.kickConnections)
 
 	return nil
 }
 
+// Close stops receiving new messages and waits for the received messages
+// to be handled in drainTimeout, then waits for the queued downgoing messages to be
+// published. The connection is kept open, since messages may still
+// be published when other components are closing.
 func (n *natsImpl) Close() error {
-	// TODO
-	return n.client.Drain()
+	for _, sub := range n.subs {
+		if err := sub.Drain(); err != nil {
+			zlog.Errorf("failed drain nats subscription, subject= %v, err= %v", sub.Subject, err)
+		}
+	}
+	var drainErr error
+	deadline := time.Now().Add(drainTimeout)
+	for _, sub := range n.subs {
+		for sub.IsValid() && time.Now().Before(deadline) {
+			time.Sleep(drainCheckInterval)
+		}
+		if sub.IsValid() && drainErr == nil {
+			drainErr = errors.Errorf("timed out draining nats subscription, subject= %v", sub.Subject)
+		}
+	}
+	if err := n.publisher.Close(); err != nil {
+		return err
+	}
+	return drainErr
 }
 
-func (n *natsImpl) serveRPC(subject, reply string, req *cometsvc.BrokerRequest) {
-	switch subject {
-	case constants.BrokerRpcGetCometConfigurationTopic:
-		n.rpcGetCometConfiguration(reply, req.GetGetCometConfigurationRequest())
-	case constants.BrokerRpcVerifyAuthTokenTopic:
-		n.rpcVerifyAuthToken(reply, req.GetVerifyAuthTokenRequest())
-	default:
-		// TODO
+func (n *natsImpl) rpcGetCometConfiguration(ctx context.Context, req *cometsvc.GetCometConfigurationRequest) (*cometsvc.GetCometConfigurationResponse, error) {
+	resp := &cometsvc.GetCometConfigurationResponse{
+		Configuration: n.keyMgr.GetConfiguration(),
 	}
+	return resp, nil
 }
 
-func (n *natsImpl) rpcGetCometConfiguration(reply string, req *cometsvc.GetCometConfigurationRequest) {
-	// TODO
-	_ = req
-	resp := &cometsvc.GetCometConfigurationResponse{}
-	err := n.client.Publish(reply, resp)
-
-	// FIXME
-	easy.PanicOnError(err)
+func (n *natsImpl) publishCometConfiguration(config *messag.CometConfiguration) {
+	err := n.client.Publish(constants.CometConfigurationTopic, config)
+	if err != nil {
+		zlog.Errorf("failed publish comet configuration, err= %v", err)
+	}
 }
 
-func (n *natsImpl) rpcVerifyAuthToken(reply string, req *cometsvc.VerifyAuthTokenRequest) {
-	resp := &cometsvc.VerifyAuthTokenResponse{}
-	ctx := context.TODO()
-	token, err := n.signer.DecodeAuthToken(ctx, req.GetToken())
+// rpcVerifyAuthToken replies the reason in VerifyAuthTokenResponse.code
+// if a token is rejected, other errors are replied as RPC errors, eg.
+// the storage is unavailable, in which case the comet may retry.
+func (n *natsImpl) rpcVerifyAuthToken(ctx context.Context, req *cometsvc.VerifyAuthTokenRequest) (*cometsvc.VerifyAuthTokenResponse, error) {
+	token, err := n.signer.DecodeAuthToken(ctx, req.GetToken(), req.GetClientIp())
+	if err == nil {
+		err = n.blocklist.Check(ctx, token.AppId, token.UserId, token.DeviceId, req.GetClientIp())
+	}
 	if err != nil {
-		resp.Code = cometsvc.AuthToken_INVALID // TODO
-	} else {
-		resp.Code = cometsvc.AuthToken_SUCCESS
-		resp.Token = token
+		code, ok := tokenVerifyCode(err)
+		if !ok {
+			zlog.Errorf("failed verify auth token, err= %v", err)
+			return nil, err
+		}
+		return &cometsvc.VerifyAuthTokenResponse{Code: code}, nil
+	}
+	resp := &cometsvc.VerifyAuthTokenResponse{
+		Code:  cometsvc.AuthToken_SUCCESS,
+		Token: token,
 	}
-	err = n.client.Publish(reply, resp)
+	return resp, nil
+}
 
-	// FIXME
-	easy.PanicOnError(err)
+func tokenVerifyCode(err error) (cometsvc.AuthToken_VerifyCode, bool) {
+	switch errors.Cause(err) {
+	case errcode.InvalidAuthToken:
+		return cometsvc.AuthToken_INVALID, true
+	case errcode.ExpiredAuthToken:
+		return cometsvc.AuthToken_EXPIRED, true
+	case errcode.RevokedAuthToken:
+		return cometsvc.AuthToken_REVOKED, true
+	case errcode.UnknownTokenVersion:
+		return cometsvc.AuthToken_UNKNOWN_VERSION, true
+	case errcode.IllegalAuthToken:
+		return cometsvc.AuthToken_MALFORMED, true
+	case errcode.TokenIpMismatch:
+		return cometsvc.AuthToken_IP_MISMATCH, true
+	case errcode.ClientBlocked:
+		return cometsvc.AuthToken_BLOCKED, true
+	}
+	return cometsvc.AuthToken_INVALID, false
 }
 
 func (n *natsImpl) handleMessage(msg *messag.UpgoingMessage) {
-	ctx := context.TODO()
+	ctx, cancel := context.WithTimeout(context.Background(), handleTimeout)
+	defer cancel()
 	packet := msg.GetPacket()
 	bizMsg := &protocol.Message{
 		Conn: msg.GetConn(),
@@ -125,39 +212,86 @@ func (n *natsImpl) handleMessage(msg *messag.UpgoingMessage) {
 	}
 	err := n.bizapi.OnMessage(ctx, bizMsg)
 	if err != nil {
-		// TODO
+		zlog.Errorf("failed handle upgoing message, conn_id= %v, err= %v",
+			msg.GetConn().GetId(), err)
 	}
 }
 
 func (n *natsImpl) handleEvent(event *protocol.Event) {
-	ctx := context.TODO()
-	err := n.bizapi.OnEvent(ctx, event)
-
-	// TODO: manage connection data
+	ctx, cancel := context.WithTimeout(context.Background(), handleTimeout)
+	defer cancel()
+	err := n.events.HandleEvent(ctx, event)
+	if err != nil {
+		zlog.Errorf("failed handle event, conn_id= %v, type= %v, err= %v",
+			event.GetConn().GetId(), event.GetType(), err)
+	}
+}
 
-	// FIXME
-	easy.PanicOnError(err)
+func (n *natsImpl) PushGroupedMessage(ctx context.Context, machineId string, message *messag.DowngoingMessage) error {
+	if n.registry.IsDead(machineId) {
+		zlog.Debugf("skip pushing to dead comet, machine_id= %v", machineId)
+		return nil
+	}
+	return n.publisher.Publish(ctx, machineId, message)
 }
 
-func (n *natsImpl) PushGroupedMessage(machineId string, message *messag.DowngoingMessage) {
+func (n *natsImpl) publish(machineId string, message *messag.DowngoingMessage) error {
 	topic := constants.CometDowngoingMessageTopic(machineId)
-	err := n.client.Publish(topic, message)
-	if err != nil {
-		// TODO
+	return n.client.Publish(topic, message)
+}
+
+func (n *natsImpl) kickConnections(conns []*protocol.Connection) {
+	machineConns := make(map[string][]string)
+	for _, c := range conns {
+		connId, err := connid.ParseConnectionId(c.GetId())
+		if err != nil {
+			zlog.Warnf("cannot kick connection with invalid id, conn_id= %v", c.GetId())
+			continue
+		}
+		machineConns[connId.MachineId] = append(machineConns[connId.MachineId], c.GetId())
+	}
+	for machineId, connIds := range machineConns {
+		if n.registry.IsDead(machineId) {
+			continue
+		}
+		msg := &messag.KickMessage{
+			ConnIds: connIds,
+			Reason:  "exceeded connection limit",
+		}
+		err := n.client.Publish(constants.CometKickTopic(machineId), msg)
+		if err != nil {
+			zlog.Errorf("failed publish kick message, machine_id= %v, err= %v", machineId, err)
+		}
 	}
 }
 
-func (n *natsImpl) PushMessages(messages []*messag.DowngoingMessage) {
+func (n *natsImpl) PushMessages(ctx context.Context, messages []*messag.DowngoingMessage) error {
 	for _, msg := range messages {
-		for _, id := range msg.ConnIds {
-			connId, err := connid.ParseConnectionId(id)
+		for machineId, connIds := range groupConnIdsByMachine(msg.GetConnIds()) {
+			grouped := &messag.DowngoingMessage{
+				Data:    msg.Data,
+				ConnIds: connIds,
+			}
+			err := n.PushGroupedMessage(ctx, machineId, grouped)
 			if err != nil {
-				// TODO
+				return err
 			}
-			machineId := connId.MachineId
-			n.PushGroupedMessage(machineId, msg)
 		}
 	}
+	return nil
+}
+
+func groupConnIdsByMachine(ids []string) map[string][]string {
+	out := make(map[string][]string)
+	for _, id := range ids {
+		connId, err := connid.ParseConnectionId(id)
+		if err != nil {
+			zlog.Warnf("cannot push to connection with invalid id, conn_id= %v", id)
+			continue
+		}
+		out[connId.MachineId] = append(out[connId.MachineId], id)
+	}
+	return out
 }
 
 func init() {
@@ -173,3 +307,12 @@ func (pe ProtobufEncoder) Encode(subject string, msg interface{}) ([]byte, error
 func (pe ProtobufEncoder) Decode(subject string, data []byte, msg interface{}) (err error) {
 	return proto.Unmarshal(data, msg.(proto.Message))
 }
+
+func (n *natsImpl) GetConnectionInfo(ctx context.Context, machineId, connId string) (*protocol.ConnectionDetail, error) {
+	req := &cometsvc.GetConnectionInfoRequest{ConnId: connId}
+	resp, err := n.comets.GetConnectionInfo(ctx, machineId, req)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return resp.GetDetail(), nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/pkg/model"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/messag"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+const (
+	DefaultCometDeadTimeout = 30 * time.Second
+
+	cometCheckInterval  = 5 * time.Second
+	cometCleanupTimeout = 5 * time.Minute
+	cometCleanupBatch   = 100
+	cometReleaseTimeout = 3 * time.Second
+	cometCloseTimeout   = 10 * time.Second
+)
+
+type CometDao interface {
+	// AcquireCleanup tells whether the caller wins the right to clean up
+	// connections of a dead comet machine, only one broker instance wins.
+	AcquireCleanup(ctx context.Context, machineId string, ttl time.Duration) (bool, error)
+
+	// ReleaseCleanup releases the right acquired by AcquireCleanup,
+	// so that the machine can be cleaned up again if it dies again.
+	ReleaseCleanup(ctx context.Context, machineId string) error
+}
+
+func NewCometRegistry(connDao ConnectionDao, cometDao CometDao, events *EventHandler) *CometRegistry {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &CometRegistry{
+		DeadTimeout: DefaultCometDeadTimeout,
+		connDao:     connDao,
+		cometDao:    cometDao,
+		events:      events,
+		comets:      make(map[string]*CometState),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	go r.checkLoop()
+	return r
+}
+
+// CometRegistry keeps track of the comet machines reported by heartbeats.
+// When a machine stops heartbeating, it is marked dead and its connections
+// are removed from the routing table with DISCONNECT events emitted.
+type CometRegistry struct {
+	// DeadTimeout is the duration after the last heartbeat that a comet
+	// machine is considered to be dead.
+	DeadTimeout time.Duration
+
+	connDao  ConnectionDao
+	cometDao CometDao
+	events   *EventHandler
+
+	mu     sync.RWMutex
+	comets map[string]*CometState
+	closed bool
+
+	// ctx is canceled by Close to stop the running cleanups.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type CometState struct {
+	MachineId     string
+	Addr          string
+	StartTime     time.Time
+	LastHeartbeat time.Time
+	Connections   int64
+	Dead          bool
+}
+
+// Heartbeat registers or refreshes a comet machine.
+func (r *CometRegistry) Heartbeat(hb *messag.CometHeartbeat) {
+	machineId := hb.GetMachineId()
+	if machineId == "" {
+		return
+	}
+	r.mu.Lock()
+	state := r.comets[machineId]
+	if state == nil {
+		zlog.Infof("comet registered, machine_id= %v, addr= %v", machineId, hb.GetAddr())
+		state = &CometState{MachineId: machineId}
+		r.comets[machineId] = state
+	} else if state.Dead && !hb.GetShutdown() {
+		zlog.Warnf("dead comet comes back alive, machine_id= %v, addr= %v", machineId, hb.GetAddr())
+		state.Dead = false
+	}
+	state.Addr = hb.GetAddr()
+	state.StartTime = time.Unix(0, hb.GetStartTimeMsec()*1e6)
+	state.LastHeartbeat = time.Now()
+	state.Connections = hb.GetConnections()
+	markDead := hb.GetShutdown() && !state.Dead
+	if markDead {
+		state.Dead = true
+	}
+	r.mu.Unlock()
+
+	if markDead {
+		zlog.Infof("comet is shutting down, machine_id= %v", machineId)
+		r.startCleanup(machineId)
+	}
+}
+
+// IsDead tells whether a comet machine is known to be dead.
+// A machine never seen by the registry is not considered dead, since
+// the broker may just have started and not received its heartbeats yet.
+func (r *CometRegistry) IsDead(machineId string) bool {
+	r.mu.RLock()
+	state := r.comets[machineId]
+	r.mu.RUnlock()
+	return state != nil && state.Dead
+}
+
+// List returns states of all comet machines known by the registry.
+func (r *CometRegistry) List() []CometState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]CometState, 0, len(r.comets))
+	for _, state := range r.comets {
+		out = append(out, *state)
+	}
+	return out
+}
+
+func (r *CometRegistry) checkLoop() {
+	ticker := time.NewTicker(cometCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.checkDead()
+		}
+	}
+}
+
+func (r *CometRegistry) checkDead() {
+	var deadMachines []string
+	deadline := time.Now().Add(-r.DeadTimeout)
+	r.mu.Lock()
+	for machineId, state := range r.comets {
+		if !state.Dead && state.LastHeartbeat.Before(deadline) {
+			zlog.Warnf("comet is dead, machine_id= %v, last_heartbeat= %v", machineId, state.LastHeartbeat)
+			state.Dead = true
+			deadMachines = append(deadMachines, machineId)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, machineId := range deadMachines {
+		r.startCleanup(machineId)
+	}
+}
+
+func (r *CometRegistry) startCleanup(machineId string) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.wg.Add(1)
+	r.mu.Unlock()
+	go func() {
+		defer r.wg.Done()
+		err := r.cleanupMachine(machineId)
+		if err != nil {
+			zlog.Errorf("failed clean up dead comet, machine_id= %v, err= %v", machineId, err)
+		}
+	}()
+}
+
+func (r *CometRegistry) cleanupMachine(machineId string) error {
+	ctx, cancel := context.WithTimeout(r.ctx, cometCleanupTimeout)
+	defer cancel()
+
+	ok, err := r.cometDao.AcquireCleanup(ctx, machineId, cometCleanupTimeout)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	if !ok {
+		return nil
+	}
+	defer func() {
+		// The right is released even if the cleanup is canceled,
+		// so that another broker instance can take it over.
+		releaseCtx, cancel := context.WithTimeout(context.Background(), cometReleaseTimeout)
+		defer cancel()
+		if err := r.cometDao.ReleaseCleanup(releaseCtx, machineId); err != nil {
+			zlog.Errorf("failed release comet cleanup, machine_id= %v, err= %v", machineId, err)
+		}
+	}()
+
+	var cursor uint64
+	var total int
+	for {
+		if err = ctx.Err(); err != nil {
+			return errors.AddStack(err)
+		}
+		var conns []*data.ConnectionInfo
+		conns, cursor, err = r.connDao.ScanMachineConnections(ctx, machineId, cursor, cometCleanupBatch)
+		if err != nil {
+			return errors.AddStack(err)
+		}
+		for _, c := range conns {
+			if err = ctx.Err(); err != nil {
+				return errors.AddStack(err)
+			}
+			event := &protocol.Event{
+				Conn: model.ToProtocolConnection(c),
+				Type: protocol.Event_DISCONNECT,
+			}
+			err = r.events.HandleEvent(ctx, event)
+			if err != nil {
+				zlog.Errorf("failed handle disconnect event of dead comet, conn_id= %v, err= %v", c.Id, err)
+			}
+		}
+		total += len(conns)
+		if cursor == 0 {
+			break
+		}
+	}
+	err = r.connDao.DeleteMachineConnections(ctx, machineId)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	zlog.Infof("cleaned up dead comet, machine_id= %v, connections= %d", machineId, total)
+	return nil
+}
+
+// Close stops checking comet machines and cancels running cleanups,
+// the routes left in the routing table are removed by the sweeper.
+// It waits for the cleanups to stop no longer than cometCloseTimeout.
+func (r *CometRegistry) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(cometCloseTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return errors.New("timed out waiting comet cleanups to stop")
+	}
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/connid"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/messag"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+func TestCometRegistry(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	events := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), bizapi)
+	events.ResumeWindow = 0
+	registry := service.NewCometRegistry(connDao, dao.NewCometDao(redisCli), events)
+
+	connId, err := connid.ParseConnectionId(testConnId1)
+	require.Nil(t, err)
+	machineId := connId.MachineId
+
+	for _, id := range []string{testConnId1, testConnId2} {
+		err = events.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, id))
+		require.Nil(t, err)
+	}
+	assert.Len(t, listUserConnIds(t, connDao, 123), 2)
+
+	registry.Heartbeat(&messag.CometHeartbeat{
+		MachineId: machineId,
+		TimeMsec:  time.Now().UnixNano() / 1e6,
+	})
+	assert.False(t, registry.IsDead(machineId))
+	assert.False(t, registry.IsDead("unknown"))
+	assert.Len(t, registry.List(), 1)
+
+	registry.Heartbeat(&messag.CometHeartbeat{
+		MachineId: machineId,
+		TimeMsec:  time.Now().UnixNano() / 1e6,
+		Shutdown:  true,
+	})
+	assert.True(t, registry.IsDead(machineId))
+	require.Eventually(t, func() bool {
+		return len(bizapi.eventTypes()) == 4
+	}, 5*time.Second, time.Millisecond)
+	assert.Len(t, listUserConnIds(t, connDao, 123), 0)
+	assert.Equal(t, []protocol.Event_Type{
+		protocol.Event_CONNECT,
+		protocol.Event_CONNECT,
+		protocol.Event_DISCONNECT,
+		protocol.Event_DISCONNECT,
+	}, bizapi.eventTypes())
+
+	// The machine comes back and dies again, it is cleaned up again.
+	registry.Heartbeat(&messag.CometHeartbeat{
+		MachineId: machineId,
+		TimeMsec:  time.Now().UnixNano() / 1e6,
+	})
+	assert.False(t, registry.IsDead(machineId))
+	err = events.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, testConnId1))
+	require.Nil(t, err)
+	registry.Heartbeat(&messag.CometHeartbeat{
+		MachineId: machineId,
+		TimeMsec:  time.Now().UnixNano() / 1e6,
+		Shutdown:  true,
+	})
+	require.Eventually(t, func() bool {
+		return len(bizapi.eventTypes()) == 6
+	}, 5*time.Second, time.Millisecond)
+	assert.Len(t, listUserConnIds(t, connDao, 123), 0)
+	require.Nil(t, registry.Close())
+}
+
+// endlessScanConnectionDao never finishes scanning connections of
+// a machine, the cleanup stops only when it is canceled.
+type endlessScanConnectionDao struct {
+	service.ConnectionDao
+	scanned chan struct{}
+}
+
+func (p *endlessScanConnectionDao) ScanMachineConnections(ctx context.Context, machineId string, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error) {
+	select {
+	case p.scanned <- struct{}{}:
+	default:
+	}
+	conn := &data.ConnectionInfo{Id: testConnId1, AppId: 1001, UserId: 123, MachineId: machineId}
+	return []*data.ConnectionInfo{conn}, cursor + 1, nil
+}
+
+func TestCometRegistryCloseCancelsCleanup(t *testing.T) {
+	connDao := &endlessScanConnectionDao{
+		ConnectionDao: dao.NewMemoryConnectionDao(),
+		scanned:       make(chan struct{}, 1),
+	}
+	events := service.NewEventHandler(connDao, dao.NewMemorySessionDao(), &fakeBizApi{})
+	events.ResumeWindow = 0
+	registry := service.NewCometRegistry(connDao, dao.NewMemoryCometDao(), events)
+
+	registry.Heartbeat(&messag.CometHeartbeat{MachineId: "m1", Shutdown: true})
+	<-connDao.scanned
+	require.Nil(t, registry.Close())
+
+	// No cleanup is started after closed.
+	registry.Heartbeat(&messag.CometHeartbeat{MachineId: "m2", Shutdown: true})
+	require.Nil(t, registry.Close())
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/jxskiss/gopkg/set"
 
 	"github.com/jxskiss/nonamegw/pkg/connid"
+	"github.com/jxskiss/nonamegw/pkg/errcode"
 	"github.com/jxskiss/nonamegw/pkg/model"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
 	"github.com/jxskiss/nonamegw/proto/brokersvc"
 	"github.com/jxskiss/nonamegw/proto/data"
 	"github.com/jxskiss/nonamegw/proto/messag"
@@ -17,18 +19,39 @@ import (
 
 // TODO: app_id/app_secret auth middleware
 
-func NewService(signer Signer, connDao ConnectionDao, nats NatsService) *Service {
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+	listMaxScans     = 10
+	scanAppBatch     = 1000
+
+	// resolveBatch is the number of IDs whose connections are queried
+	// at once when resolving a push target.
+	resolveBatch = 500
+
+	// pushBatchSize is the max number of connection IDs carried by one
+	// message published to a comet.
+	pushBatchSize = 1000
+)
+
+func NewService(signer Signer, connDao ConnectionDao, groupDao GroupDao, nats NatsService, registry *CometRegistry, blocklist *Blocklist) *Service {
 	return &Service{
-		signer:  signer,
-		connDao: connDao,
-		nats:    nats,
+		signer:    signer,
+		connDao:   connDao,
+		groupDao:  groupDao,
+		nats:      nats,
+		registry:  registry,
+		blocklist: blocklist,
 	}
 }
 
 type Service struct {
-	signer  Signer
-	connDao ConnectionDao
-	nats    NatsService
+	signer    Signer
+	connDao   ConnectionDao
+	groupDao  GroupDao
+	nats      NatsService
+	registry  *CometRegistry
+	blocklist *Blocklist
 }
 
 func (p *Service) Query(ctx context.Context, request *brokersvc.QueryRequest) (*brokersvc.QueryResponse, error) {
@@ -52,7 +75,21 @@ func (p *Service) Query(ctx context.Context, request *brokersvc.QueryRequest) (*
 		}
 	}
 
+	var connections []*data.ConnectionInfo
+	if len(request.GetConnectionIds()) > 0 {
+		connections, err = p.connDao.GetConnections(ctx, appId, request.GetConnectionIds())
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+	}
+
 	resp := &brokersvc.QueryResponse{}
+	if len(connections) > 0 {
+		resp.Connections = make(map[string]*protocol.Connection, len(connections))
+		for _, c := range connections {
+			resp.Connections[c.Id] = model.ToProtocolConnection(c)
+		}
+	}
 	if len(userConnections) > 0 {
 		resp.UserConnections = make(map[int64]*protocol.ConnectionList, len(userConnections))
 		for userId, conns := range userConnections {
@@ -68,27 +105,83 @@ func (p *Service) Query(ctx context.Context, request *brokersvc.QueryRequest) (*
 	return resp, nil
 }
 
+func (p *Service) ListConnections(ctx context.Context, request *brokersvc.ListConnectionsRequest) (*brokersvc.ListConnectionsResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	limit := int(request.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	} else if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	activeSince := getActiveSince(request.GetActiveWithinMinutes())
+	filter := newConnectionFilter(request.GetFilter(), request.GetClientVersions(), request.GetTags())
+
+	// Connections not matching the filters are skipped, more pages are
+	// scanned to fill the limit, but not too many to respond in time.
+	resp := &brokersvc.ListConnectionsResponse{}
+	cursor := request.GetCursor()
+	for i := 0; i < listMaxScans; i++ {
+		conns, next, err := p.connDao.ScanAppConnections(ctx, appId, activeSince, cursor, int64(limit))
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+		for _, c := range conns {
+			if filter.match(c) {
+				resp.Connections = append(resp.Connections, model.ToProtocolConnection(c))
+			}
+		}
+		cursor = next
+		if cursor == 0 || len(resp.Connections) >= limit {
+			break
+		}
+	}
+	resp.NextCursor = cursor
+	return resp, nil
+}
+
+func (p *Service) CountOnline(ctx context.Context, request *brokersvc.CountOnlineRequest) (*brokersvc.CountOnlineResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	activeSince := getActiveSince(request.GetActiveWithinMinutes())
+	count, err := p.connDao.CountOnline(ctx, appId, activeSince)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.CountOnlineResponse{
+		Users:       count.Users,
+		Devices:     count.Devices,
+		Connections: count.Connections,
+	}, nil
+}
+
+func getActiveSince(minutes int32) time.Time {
+	if minutes <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-time.Duration(minutes) * time.Minute)
+}
+
 func (p *Service) Push(ctx context.Context, request *brokersvc.PushRequest) (*brokersvc.PushResponse, error) {
 	appId := request.GetAuth().GetAppId()
 	target := request.GetTarget()
 	content := request.GetContent()
-	groupConnIds, err := p.resolvePushTarget(ctx, appId, target)
-	if err != nil {
-		return nil, err
-	}
 	packet := &protocol.Packet{
 		BizFlag: content.GetBizFlag(),
 		Headers: content.GetHeaderSlice(),
 		Payload: content.GetPayload(),
 	}
-	for machineId, connIds := range groupConnIds {
+	// Messages are published while the target is being resolved,
+	// if resolving fails halfway, the published ones are not revoked.
+	err := p.resolvePushTarget(ctx, appId, target, func(machineId string, connIds []string) error {
 		message := &messag.DowngoingMessage{
 			Data: &messag.DowngoingMessage_Packet{
 				Packet: packet,
 			},
 			ConnIds: connIds,
 		}
-		p.nats.PushGroupedMessage(machineId, message)
+		return p.nats.PushGroupedMessage(ctx, machineId, message)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return &brokersvc.PushResponse{}, nil
 }
@@ -110,36 +203,151 @@ func (p *Service) SignToken(ctx context.Context, request *brokersvc.SignTokenReq
 	userId := request.GetUserId()
 	deviceId := request.GetDeviceId()
 
-	nowTime := time.Now()
-	token, err := p.signer.SignAuthToken(ctx, appId, userId, deviceId)
+	token, err := p.signer.SignAuthToken(ctx, appId, userId, deviceId, request.GetClaims(), request.GetBindIp())
 	if err != nil {
 		return nil, errors.AddStack(err)
 	}
 	resp := &brokersvc.SignTokenResponse{
 		Token:    token.Token,
-		ExpireAt: nowTime.Add(TokenExpiration).Unix(),
+		ExpireAt: token.ExpireTimeMsec / 1e3,
+	}
+	return resp, nil
+}
+
+func (p *Service) RefreshToken(ctx context.Context, request *brokersvc.RefreshTokenRequest) (*brokersvc.RefreshTokenResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	token, err := p.signer.RefreshAuthToken(ctx, appId, request.GetToken())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	resp := &brokersvc.RefreshTokenResponse{
+		Token:    token.Token,
+		ExpireAt: token.ExpireTimeMsec / 1e3,
 	}
 	return resp, nil
 }
 
-func (p *Service) resolvePushTarget(ctx context.Context, appId int64, target *brokersvc.PushTarget) (
-	map[string][]string, error,
-) {
-	var connectionIds []string
+func (p *Service) RevokeToken(ctx context.Context, request *brokersvc.RevokeTokenRequest) (*brokersvc.RevokeTokenResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	var err error
+	if request.GetToken() != "" {
+		err = p.signer.RevokeAuthToken(ctx, appId, request.GetToken())
+	} else if request.GetUserId() > 0 {
+		err = p.signer.RevokeUserTokens(ctx, appId, request.GetUserId())
+	} else {
+		err = errors.New("either token or user_id must be given")
+	}
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.RevokeTokenResponse{}, nil
+}
+
+func (p *Service) GetBlocklist(ctx context.Context, request *brokersvc.GetBlocklistRequest) (*brokersvc.GetBlocklistResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	list, err := p.blocklist.Get(ctx, appId)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.GetBlocklistResponse{Blocklist: list}, nil
+}
+
+func (p *Service) UpdateBlocklist(ctx context.Context, request *brokersvc.UpdateBlocklistRequest) (*brokersvc.UpdateBlocklistResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	err := p.blocklist.Update(ctx, appId, request.GetAdd(), request.GetRemove())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &brokersvc.UpdateBlocklistResponse{}, nil
+}
+
+func (p *Service) GetConnectionInfo(ctx context.Context, request *brokersvc.GetConnectionInfoRequest) (*brokersvc.GetConnectionInfoResponse, error) {
+	appId := request.GetAuth().GetAppId()
+	connId, err := connid.ParseConnectionId(request.GetConnId())
+	if err != nil {
+		return nil, errors.AddStack(errcode.ConnectionNotFound)
+	}
+	if p.registry.IsDead(connId.MachineId) {
+		return nil, errors.AddStack(errcode.ConnectionNotFound)
+	}
+	detail, err := p.nats.GetConnectionInfo(ctx, connId.MachineId, request.GetConnId())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	if detail.GetConn().GetAppId() != appId {
+		return nil, errors.AddStack(errcode.ConnectionNotFound)
+	}
+	return &brokersvc.GetConnectionInfoResponse{Detail: detail}, nil
+}
+
+// resolvePushTarget resolves connections of a push target batch by batch,
+// connection IDs are grouped by machine and passed to publish once
+// there are pushBatchSize of them on a machine, so that a large target
+// is never loaded into memory at once.
+// publish is called synchronously, a slow publisher slows down the
+// resolution, which is canceled when ctx is done or publish fails.
+func (p *Service) resolvePushTarget(ctx context.Context, appId int64, target *brokersvc.PushTarget, publish func(machineId string, connIds []string) error) error {
+	filter := newConnectionFilter(target.GetFilter(), target.GetVersionFilters(), nil)
+	excludeConnIds := set.NewString(target.GetExcludeConnectionIds()...)
+	excludeUserIds := set.NewInt64(target.GetExcludeUserIds()...)
+	batcher := newMachineBatcher(pushBatchSize, publish)
+
+	if target.GetType() == brokersvc.PushTarget_CONNECTION &&
+		filter == nil && excludeUserIds.Size() == 0 {
+		connectionIds := target.GetConnections().GetConnectionIds()
+		for _, id := range excludeStrings(connectionIds, excludeConnIds) {
+			if err := batcher.add(id); err != nil {
+				return err
+			}
+		}
+		return batcher.flush()
+	}
+
+	sink := func(conns []*data.ConnectionInfo) error {
+		for _, c := range conns {
+			if !filter.match(c) || excludeConnIds.Contains(c.Id) || excludeUserIds.Contains(c.UserId) {
+				continue
+			}
+			if err := batcher.add(c.Id); err != nil {
+				return err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.AddStack(err)
+		}
+		return nil
+	}
+	err := p.streamPushTarget(ctx, appId, target, sink)
+	if err != nil {
+		return err
+	}
+	return batcher.flush()
+}
+
+// streamPushTarget calls sink with connections of a push target,
+// at most resolveBatch users or devices are queried at once.
+func (p *Service) streamPushTarget(ctx context.Context, appId int64, target *brokersvc.PushTarget, sink func(conns []*data.ConnectionInfo) error) error {
 	switch target.GetType() {
 	case brokersvc.PushTarget_CONNECTION:
-		connectionIds = target.GetConnections().GetConnectionIds()
-	case brokersvc.PushTarget_USER:
-		userConnections, err := p.connDao.ListUserConnections(ctx, appId, target.GetUsers().GetUserIds())
-		if err != nil {
-			return nil, err
-		}
-		connectionIds = make([]string, 0, len(userConnections))
-		for _, conns := range userConnections {
-			for _, c := range conns {
-				connectionIds = append(connectionIds, c.Id)
+		connectionIds := target.GetConnections().GetConnectionIds()
+		for len(connectionIds) > 0 {
+			n := len(connectionIds)
+			if n > resolveBatch {
+				n = resolveBatch
+			}
+			conns, err := p.connDao.GetConnections(ctx, appId, connectionIds[:n])
+			if err != nil {
+				return err
 			}
+			if err = sink(conns); err != nil {
+				return err
+			}
+			connectionIds = connectionIds[n:]
 		}
+		return nil
+	case brokersvc.PushTarget_USER:
+		userIds := set.NewInt64(target.GetUsers().GetUserIds()...).Slice()
+		return p.streamUserConnections(ctx, appId, userIds, sink)
 	case brokersvc.PushTarget_USER_DEVICE:
 		userIds := set.NewInt64()
 		userDeviceIds := make(map[userDeviceId]struct{}, len(target.GetUserDevices().GetUserDevices()))
@@ -148,53 +356,182 @@ func (p *Service) resolvePushTarget(ctx context.Context, appId int64, target *br
 			udId := userDeviceId{userId: ud.UserId, deviceId: ud.DeviceId}
 			userDeviceIds[udId] = struct{}{}
 		}
-		userConnections, err := p.connDao.ListUserConnections(ctx, appId, userIds.Slice())
-		if err != nil {
-			return nil, err
-		}
-		connectionIds = make([]string, 0, len(userConnections))
-		for _, conns := range userConnections {
+		return p.streamUserConnections(ctx, appId, userIds.Slice(), func(conns []*data.ConnectionInfo) error {
+			matched := conns[:0]
 			for _, c := range conns {
 				udId := userDeviceId{userId: c.UserId, deviceId: c.DeviceId}
 				if _, ok := userDeviceIds[udId]; ok {
-					connectionIds = append(connectionIds, c.Id)
+					matched = append(matched, c)
 				}
 			}
-		}
-	case brokersvc.PushTarget_UNAUTHENTICATED_DEVICE:
-		deviceConnections, err := p.connDao.ListDeviceConnections(ctx, appId, target.GetDevices().GetDeviceIds())
+			return sink(matched)
+		})
+	case brokersvc.PushTarget_FILTER:
+		exprTarget := target.GetExpression()
+		expr, err := compileConnectionExpr(exprTarget.GetExpression())
 		if err != nil {
-			return nil, err
+			return errors.AddStack(errcode.InvalidPushTarget.WithMessage(err.Error()))
 		}
-		connectionIds = make([]string, 0, len(deviceConnections))
-		for _, conns := range deviceConnections {
+		match := func(conns []*data.ConnectionInfo) error {
+			matched := conns[:0]
 			for _, c := range conns {
-				connectionIds = append(connectionIds, c.Id)
+				if expr.Eval(connectionEnv(c)) {
+					matched = append(matched, c)
+				}
+			}
+			return sink(matched)
+		}
+		if len(exprTarget.GetUserIds()) > 0 {
+			userIds := set.NewInt64(exprTarget.GetUserIds()...).Slice()
+			return p.streamUserConnections(ctx, appId, userIds, match)
+		}
+		return p.scanAppConnections(ctx, appId, match)
+	case brokersvc.PushTarget_GROUP:
+		groupId := target.GetGroup().GetGroupId()
+		if err := checkGroupId(groupId); err != nil {
+			return err
+		}
+		return p.resolveGroupConnections(ctx, appId, groupId, sink)
+	case brokersvc.PushTarget_UNAUTHENTICATED_DEVICE:
+		deviceIds := set.NewInt64(target.GetDevices().GetDeviceIds()...).Slice()
+		for len(deviceIds) > 0 {
+			n := len(deviceIds)
+			if n > resolveBatch {
+				n = resolveBatch
+			}
+			deviceConnections, err := p.connDao.ListDeviceConnections(ctx, appId, deviceIds[:n])
+			if err != nil {
+				return err
 			}
+			if err = sinkConnections(deviceConnections, sink); err != nil {
+				return err
+			}
+			deviceIds = deviceIds[n:]
 		}
+		return nil
 	default:
-		return nil, errors.Errorf("unknown target type %v", target.GetType())
+		return errors.Errorf("unknown target type %v", target.GetType())
 	}
-	if len(connectionIds) == 0 {
-		return nil, nil
+}
+
+// streamUserConnections calls sink with connections of users,
+// at most resolveBatch users are queried at once.
+func (p *Service) streamUserConnections(ctx context.Context, appId int64, userIds []int64, sink func(conns []*data.ConnectionInfo) error) error {
+	for len(userIds) > 0 {
+		n := len(userIds)
+		if n > resolveBatch {
+			n = resolveBatch
+		}
+		userConnections, err := p.connDao.ListUserConnections(ctx, appId, userIds[:n])
+		if err != nil {
+			return err
+		}
+		if err = sinkConnections(userConnections, sink); err != nil {
+			return err
+		}
+		userIds = userIds[n:]
+	}
+	return nil
+}
+
+func sinkConnections(connections map[int64][]*data.ConnectionInfo, sink func(conns []*data.ConnectionInfo) error) error {
+	var conns []*data.ConnectionInfo
+	for _, x := range connections {
+		conns = append(conns, x...)
 	}
-	result := groupConnectionIds(connectionIds)
-	return result, nil
+	return sink(conns)
 }
 
-func groupConnectionIds(connectionIds []string) map[string][]string {
-	out := make(map[string][]string)
-	for _, id := range connectionIds {
-		connId, err := connid.ParseConnectionId(id)
+// scanAppConnections calls fn with connections of an app batch by batch.
+//
+// ZSCAN may return an element more than once, duplicates in a batch are
+// removed, but a connection may still be passed in two batches if the
+// set is rehashed during the scan, thus it may be pushed more than once.
+func (p *Service) scanAppConnections(ctx context.Context, appId int64, fn func(conns []*data.ConnectionInfo) error) error {
+	var cursor uint64
+	for {
+		conns, next, err := p.connDao.ScanAppConnections(ctx, appId, time.Time{}, cursor, scanAppBatch)
 		if err != nil {
-			// TODO: logging
-			continue
+			return errors.AddStack(err)
+		}
+		if err = fn(dedupConnections(conns)); err != nil {
+			return err
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func dedupConnections(conns []*data.ConnectionInfo) []*data.ConnectionInfo {
+	seen := set.NewStringWithSize(len(conns))
+	out := conns[:0]
+	for _, c := range conns {
+		if !seen.Contains(c.Id) {
+			seen.Add(c.Id)
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func excludeStrings(values []string, exclude set.String) []string {
+	if exclude.Size() == 0 {
+		return values
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !exclude.Contains(v) {
+			out = append(out, v)
 		}
-		out[connId.MachineId] = append(out[connId.MachineId], id)
 	}
 	return out
 }
 
+// machineBatcher groups connection IDs by machine, a batch is passed
+// to publish when it is full or flushed.
+type machineBatcher struct {
+	size    int
+	publish func(machineId string, connIds []string) error
+	batches map[string][]string
+}
+
+func newMachineBatcher(size int, publish func(machineId string, connIds []string) error) *machineBatcher {
+	return &machineBatcher{
+		size:    size,
+		publish: publish,
+		batches: make(map[string][]string),
+	}
+}
+
+func (b *machineBatcher) add(id string) error {
+	connId, err := connid.ParseConnectionId(id)
+	if err != nil {
+		zlog.Warnf("cannot push to connection with invalid id, conn_id= %v", id)
+		return nil
+	}
+	machineId := connId.MachineId
+	batch := append(b.batches[machineId], id)
+	if len(batch) < b.size {
+		b.batches[machineId] = batch
+		return nil
+	}
+	// The published batch is owned by publish, a new one is allocated.
+	delete(b.batches, machineId)
+	return b.publish(machineId, batch)
+}
+
+func (b *machineBatcher) flush() error {
+	for machineId, batch := range b.batches {
+		delete(b.batches, machineId)
+		if err := b.publish(machineId, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type userDeviceId struct {
 	userId, deviceId int64
 }
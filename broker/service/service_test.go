@@ -0,0 +1,446 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jxskiss/errors"
+	"github.com/jxskiss/gopkg/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/connid"
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/pkg/model"
+	"github.com/jxskiss/nonamegw/proto/brokersvc"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/messag"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+type fakeNats struct {
+	mu      sync.Mutex
+	pushed  map[string][]*messag.DowngoingMessage
+	details map[string]*protocol.ConnectionDetail
+}
+
+func (p *fakeNats) Close() error { return nil }
+
+func (p *fakeNats) PushGroupedMessage(ctx context.Context, machineId string, message *messag.DowngoingMessage) error {
+	p.mu.Lock()
+	if p.pushed == nil {
+		p.pushed = make(map[string][]*messag.DowngoingMessage)
+	}
+	p.pushed[machineId] = append(p.pushed[machineId], message)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *fakeNats) PushMessages(ctx context.Context, messages []*messag.DowngoingMessage) error {
+	return nil
+}
+
+func (p *fakeNats) GetConnectionInfo(ctx context.Context, machineId, connId string) (*protocol.ConnectionDetail, error) {
+	detail := p.details[connId]
+	if detail == nil {
+		return nil, errors.AddStack(errcode.ConnectionNotFound)
+	}
+	return detail, nil
+}
+
+func (p *fakeNats) reset() {
+	p.mu.Lock()
+	p.pushed = nil
+	p.mu.Unlock()
+}
+
+func (p *fakeNats) pushedMessages() map[string][]*messag.DowngoingMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make(map[string][]*messag.DowngoingMessage, len(p.pushed))
+	for machineId, msgs := range p.pushed {
+		out[machineId] = msgs
+	}
+	return out
+}
+
+func (p *fakeNats) pushedConnIds() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []string
+	for _, msgs := range p.pushed {
+		for _, m := range msgs {
+			out = append(out, m.ConnIds...)
+		}
+	}
+	return out
+}
+
+// testService is a Service with connections saved in memory and pushed
+// messages recorded by fakeNats.
+type testService struct {
+	*service.Service
+	connDao service.ConnectionDao
+	nats    *fakeNats
+}
+
+var testAuth = &brokersvc.Authorization{AppId: 1001}
+
+func newTestService(t *testing.T, conns ...*protocol.Connection) *testService {
+	svc := &testService{
+		connDao: dao.NewMemoryConnectionDao(),
+		nats:    &fakeNats{},
+	}
+	svc.Service = service.NewService(nil, svc.connDao, dao.NewMemoryGroupDao(), svc.nats, nil, nil)
+	for _, conn := range conns {
+		_, err := svc.connDao.SaveConnection(context.Background(), model.FromProtocolConnection(conn), 5)
+		require.Nil(t, err)
+	}
+	return svc
+}
+
+func newTestConn(connId string) *protocol.Connection {
+	return newTestEvent(protocol.Event_CONNECT, connId).Conn
+}
+
+func newPushRequest(target *brokersvc.PushTarget) *brokersvc.PushRequest {
+	return &brokersvc.PushRequest{
+		Auth:    testAuth,
+		Target:  target,
+		Content: &protocol.Content{Payload: []byte("hello")},
+	}
+}
+
+// pushTarget pushes to target and returns the IDs of the connections
+// pushed to.
+func pushTarget(t *testing.T, svc *testService, target *brokersvc.PushTarget) []string {
+	svc.nats.reset()
+	_, err := svc.Push(context.Background(), newPushRequest(target))
+	require.Nil(t, err)
+	return svc.nats.pushedConnIds()
+}
+
+func usersTarget(userIds ...int64) *brokersvc.PushTarget {
+	return &brokersvc.PushTarget{
+		Type: brokersvc.PushTarget_USER,
+		Target: &brokersvc.PushTarget_Users_{
+			Users: &brokersvc.PushTarget_Users{UserIds: userIds},
+		},
+	}
+}
+
+func connsTarget(connIds ...string) *brokersvc.PushTarget {
+	return &brokersvc.PushTarget{
+		Type: brokersvc.PushTarget_CONNECTION,
+		Target: &brokersvc.PushTarget_Connections_{
+			Connections: &brokersvc.PushTarget_Connections{ConnectionIds: connIds},
+		},
+	}
+}
+
+func exprTarget(expr string, userIds ...int64) *brokersvc.PushTarget {
+	return &brokersvc.PushTarget{
+		Type: brokersvc.PushTarget_FILTER,
+		Target: &brokersvc.PushTarget_Expression_{
+			Expression: &brokersvc.PushTarget_Expression{Expression: expr, UserIds: userIds},
+		},
+	}
+}
+
+func groupTarget(groupId string) *brokersvc.PushTarget {
+	return &brokersvc.PushTarget{
+		Type: brokersvc.PushTarget_GROUP,
+		Target: &brokersvc.PushTarget_Group_{
+			Group: &brokersvc.PushTarget_Group{GroupId: groupId},
+		},
+	}
+}
+
+func TestServiceQueryAndPush(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t, newTestConn(testConnId1), newTestConn(testConnId2))
+
+	queryResp, err := svc.Query(ctx, &brokersvc.QueryRequest{Auth: testAuth, UserIds: []int64{123, 124}})
+	require.Nil(t, err)
+	assert.Len(t, queryResp.UserConnections[123].GetConnections(), 2)
+	assert.Nil(t, queryResp.UserConnections[124])
+
+	assert.ElementsMatch(t, []string{testConnId1, testConnId2}, pushTarget(t, svc, usersTarget(123)))
+}
+
+func TestServiceListConnections(t *testing.T) {
+	ctx := context.Background()
+	conn2 := newTestConn(testConnId2)
+	conn2.ClientVersion = "2.0.0"
+	conn2.Claims = &protocol.TokenClaims{Tags: []string{"vip", "beta"}}
+	svc := newTestService(t, newTestConn(testConnId1), conn2)
+
+	queryResp, err := svc.Query(ctx, &brokersvc.QueryRequest{Auth: testAuth, ConnectionIds: []string{testConnId2}})
+	require.Nil(t, err)
+	assert.Len(t, queryResp.Connections, 1)
+	assert.Equal(t, "2.0.0", queryResp.Connections[testConnId2].GetClientVersion())
+
+	listIds := func(req *brokersvc.ListConnectionsRequest) []string {
+		req.Auth = testAuth
+		var ids []string
+		for {
+			resp, err := svc.ListConnections(ctx, req)
+			require.Nil(t, err)
+			for _, c := range resp.Connections {
+				ids = append(ids, c.Id)
+			}
+			if resp.NextCursor == 0 {
+				return ids
+			}
+			req.Cursor = resp.NextCursor
+		}
+	}
+	assert.ElementsMatch(t, []string{testConnId1, testConnId2}, listIds(&brokersvc.ListConnectionsRequest{Limit: 1}))
+	assert.Equal(t, []string{testConnId2}, listIds(&brokersvc.ListConnectionsRequest{ClientVersions: []string{"2.0.0"}}))
+	assert.Equal(t, []string{testConnId2}, listIds(&brokersvc.ListConnectionsRequest{Tags: []string{"vip"}}))
+	assert.Len(t, listIds(&brokersvc.ListConnectionsRequest{Tags: []string{"vip", "svip"}}), 0)
+
+	countResp, err := svc.CountOnline(ctx, &brokersvc.CountOnlineRequest{Auth: testAuth, ActiveWithinMinutes: 5})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), countResp.Users)
+	assert.Equal(t, int64(0), countResp.Devices)
+	assert.Equal(t, int64(2), countResp.Connections)
+}
+
+func TestServiceConnectionMeta(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+	handler := service.NewEventHandler(svc.connDao, dao.NewSessionDao(newTestRedis(t)), &fakeBizApi{})
+
+	event1 := newTestEvent(protocol.Event_CONNECT, testConnId1)
+	event1.Conn.Os = "Android 12"
+	event1.Conn.Attributes = map[string]string{"region": "us"}
+	event1.Conn.Claims = &protocol.TokenClaims{
+		Platform: "android",
+		Locale:   "de",
+		Extra:    map[string]string{"region": "eu", "plan": "pro"},
+	}
+	event2 := newTestEvent(protocol.Event_CONNECT, testConnId2)
+	event2.Conn.Platform = "ios"
+	event2.Conn.Locale = "en"
+	for _, event := range []*protocol.Event{event1, event2} {
+		require.Nil(t, handler.HandleEvent(ctx, event))
+	}
+
+	queryResp, err := svc.Query(ctx, &brokersvc.QueryRequest{Auth: testAuth, ConnectionIds: []string{testConnId1}})
+	require.Nil(t, err)
+	conn := queryResp.Connections[testConnId1]
+	require.NotNil(t, conn)
+	assert.Equal(t, "android", conn.Platform)
+	assert.Equal(t, "Android 12", conn.Os)
+	assert.Equal(t, "de", conn.Locale)
+	assert.Equal(t, map[string]string{"region": "us", "plan": "pro"}, conn.Attributes)
+	assert.NotEmpty(t, conn.MachineId)
+	assert.NotZero(t, conn.ConnectTimeMsec)
+	assert.NotZero(t, conn.LastActiveTimeMsec)
+
+	pushTo := func(filter *brokersvc.ConnectionFilter) []string {
+		target := usersTarget(123)
+		target.Filter = filter
+		return pushTarget(t, svc, target)
+	}
+	assert.ElementsMatch(t, []string{testConnId1, testConnId2}, pushTo(nil))
+	assert.Equal(t, []string{testConnId2}, pushTo(&brokersvc.ConnectionFilter{Platforms: []string{"ios"}}))
+	assert.Equal(t, []string{testConnId1}, pushTo(&brokersvc.ConnectionFilter{
+		Locales:    []string{"de", "fr"},
+		Attributes: map[string]string{"plan": "pro"},
+	}))
+	assert.Len(t, pushTo(&brokersvc.ConnectionFilter{NetworkTypes: []string{"5g"}}), 0)
+}
+
+func TestServicePushExpression(t *testing.T) {
+	ctx := context.Background()
+	conn2 := newTestConn(testConnId2)
+	conn2.UserId = 124
+	conn2.Platform = "ios"
+	conn2.ClientVersion = "3.10.0"
+	conn2.Locale = "de"
+	svc := newTestService(t, newTestConn(testConnId1), conn2)
+
+	assert.Equal(t, []string{testConnId2},
+		pushTarget(t, svc, exprTarget(`platform == "ios" && version >= "3.9" && locale in ["en", "de"]`)))
+	assert.Equal(t, []string{testConnId1}, pushTarget(t, svc, exprTarget(`user_id > 0`, 123)))
+	assert.Len(t, pushTarget(t, svc, exprTarget(`version >= "3.9"`, 123)), 0)
+
+	_, err := svc.Push(ctx, newPushRequest(exprTarget(`plaform == "ios"`)))
+	assert.NotNil(t, err)
+	_, err = svc.Push(ctx, newPushRequest(exprTarget(``)))
+	assert.NotNil(t, err)
+}
+
+func TestServicePushExclude(t *testing.T) {
+	conn2 := newTestConn(testConnId2)
+	conn2.UserId = 124
+	svc := newTestService(t, newTestConn(testConnId1), conn2)
+
+	target := usersTarget(123, 124)
+	target.ExcludeConnectionIds = []string{testConnId1}
+	assert.Equal(t, []string{testConnId2}, pushTarget(t, svc, target))
+	target = usersTarget(123, 124)
+	target.ExcludeUserIds = []int64{124}
+	assert.Equal(t, []string{testConnId1}, pushTarget(t, svc, target))
+
+	target = connsTarget(testConnId1, testConnId2)
+	target.ExcludeConnectionIds = []string{testConnId2}
+	assert.Equal(t, []string{testConnId1}, pushTarget(t, svc, target))
+	target = connsTarget(testConnId1, testConnId2)
+	target.ExcludeUserIds = []int64{123}
+	assert.Equal(t, []string{testConnId2}, pushTarget(t, svc, target))
+	target = connsTarget(testConnId1, testConnId2)
+	target.ExcludeConnectionIds = []string{testConnId1, testConnId2}
+	assert.Len(t, pushTarget(t, svc, target), 0)
+}
+
+func TestServiceGroup(t *testing.T) {
+	ctx := context.Background()
+	conn2 := newTestConn(testConnId2)
+	conn2.UserId = 124
+	svc := newTestService(t, newTestConn(testConnId1), conn2)
+
+	_, err := svc.Push(ctx, newPushRequest(groupTarget("g1")))
+	assert.Equal(t, errcode.GroupNotFound, errors.Cause(err))
+	_, err = svc.AddGroupMembers(ctx, &brokersvc.AddGroupMembersRequest{Auth: testAuth, GroupId: "g1", UserIds: []int64{123}})
+	assert.Equal(t, errcode.GroupNotFound, errors.Cause(err))
+
+	_, err = svc.CreateGroup(ctx, &brokersvc.CreateGroupRequest{Auth: testAuth, GroupId: "g1", Name: "group 1"})
+	require.Nil(t, err)
+	_, err = svc.CreateGroup(ctx, &brokersvc.CreateGroupRequest{Auth: testAuth, GroupId: "g1"})
+	assert.Equal(t, errcode.GroupExists, errors.Cause(err))
+	_, err = svc.CreateGroup(ctx, &brokersvc.CreateGroupRequest{Auth: testAuth})
+	assert.NotNil(t, err)
+
+	_, err = svc.AddGroupMembers(ctx, &brokersvc.AddGroupMembersRequest{Auth: testAuth, GroupId: "g1", UserIds: []int64{123, 124, 125}})
+	require.Nil(t, err)
+	_, err = svc.AddGroupMembers(ctx, &brokersvc.AddGroupMembersRequest{Auth: testAuth, GroupId: "g1", UserIds: []int64{0}})
+	assert.NotNil(t, err)
+
+	list, err := svc.ListGroupMembers(ctx, &brokersvc.ListGroupMembersRequest{Auth: testAuth, GroupId: "g1"})
+	require.Nil(t, err)
+	assert.Equal(t, "group 1", list.Name)
+	assert.ElementsMatch(t, []int64{123, 124, 125}, list.UserIds)
+	assert.Equal(t, int64(3), list.Total)
+	assert.Equal(t, uint64(0), list.NextCursor)
+
+	assert.ElementsMatch(t, []string{testConnId1, testConnId2}, pushTarget(t, svc, groupTarget("g1")))
+	target := groupTarget("g1")
+	target.ExcludeUserIds = []int64{123}
+	assert.Equal(t, []string{testConnId2}, pushTarget(t, svc, target))
+
+	_, err = svc.RemoveGroupMembers(ctx, &brokersvc.RemoveGroupMembersRequest{Auth: testAuth, GroupId: "g1", UserIds: []int64{124}})
+	require.Nil(t, err)
+	assert.Equal(t, []string{testConnId1}, pushTarget(t, svc, groupTarget("g1")))
+
+	_, err = svc.DeleteGroup(ctx, &brokersvc.DeleteGroupRequest{Auth: testAuth, GroupId: "g1"})
+	require.Nil(t, err)
+	_, err = svc.ListGroupMembers(ctx, &brokersvc.ListGroupMembersRequest{Auth: testAuth, GroupId: "g1"})
+	assert.Equal(t, errcode.GroupNotFound, errors.Cause(err))
+}
+
+var b32Enc = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+
+func newTestConnId(machine byte, incr uint16) string {
+	buf := make([]byte, 28)
+	buf[23] = machine
+	binary.BigEndian.PutUint16(buf[24:26], incr)
+	return b32Enc.EncodeToString(buf)
+}
+
+func TestServicePushLargeTarget(t *testing.T) {
+	const numUsers = 2500
+	var conns []*protocol.Connection
+	var userIds []int64
+	for i := 0; i < numUsers; i++ {
+		userId := int64(10000 + i)
+		conns = append(conns, &protocol.Connection{
+			Id:     newTestConnId(byte(i%2+1), uint16(i)),
+			AppId:  1001,
+			UserId: userId,
+		})
+		userIds = append(userIds, userId)
+	}
+	svc := newTestService(t, conns...)
+
+	pushed := set.NewString(pushTarget(t, svc, usersTarget(userIds...))...)
+	assert.Equal(t, numUsers, pushed.Size())
+	pushedMessages := svc.nats.pushedMessages()
+	assert.Len(t, pushedMessages, 2)
+	for _, msgs := range pushedMessages {
+		assert.Len(t, msgs, 2)
+		for _, m := range msgs {
+			assert.LessOrEqual(t, len(m.ConnIds), 1000)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := svc.Push(canceled, newPushRequest(usersTarget(userIds...)))
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+}
+
+func TestServiceGetConnectionInfo(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	events := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), &fakeBizApi{})
+	registry := service.NewCometRegistry(connDao, dao.NewCometDao(redisCli), events)
+	defer registry.Close()
+	nats := &fakeNats{
+		details: map[string]*protocol.ConnectionDetail{
+			testConnId1: {Conn: newTestConn(testConnId1)},
+		},
+	}
+	svc := service.NewService(nil, connDao, nil, nats, registry, nil)
+
+	resp, err := svc.GetConnectionInfo(ctx, &brokersvc.GetConnectionInfoRequest{Auth: testAuth, ConnId: testConnId1})
+	require.Nil(t, err)
+	assert.Equal(t, testConnId1, resp.GetDetail().GetConn().GetId())
+
+	_, err = svc.GetConnectionInfo(ctx, &brokersvc.GetConnectionInfoRequest{
+		Auth:   &brokersvc.Authorization{AppId: 1002},
+		ConnId: testConnId1,
+	})
+	assert.Equal(t, errcode.ConnectionNotFound, errors.Cause(err))
+
+	_, err = svc.GetConnectionInfo(ctx, &brokersvc.GetConnectionInfoRequest{Auth: testAuth, ConnId: "invalid"})
+	assert.Equal(t, errcode.ConnectionNotFound, errors.Cause(err))
+
+	connId, err := connid.ParseConnectionId(testConnId1)
+	require.Nil(t, err)
+	registry.Heartbeat(&messag.CometHeartbeat{
+		MachineId: connId.MachineId,
+		TimeMsec:  time.Now().UnixNano() / 1e6,
+		Shutdown:  true,
+	})
+	_, err = svc.GetConnectionInfo(ctx, &brokersvc.GetConnectionInfoRequest{Auth: testAuth, ConnId: testConnId1})
+	assert.Equal(t, errcode.ConnectionNotFound, errors.Cause(err))
+}
+
+// dupScanConnectionDao returns every connection twice from
+// ScanAppConnections, as ZSCAN may do.
+type dupScanConnectionDao struct {
+	service.ConnectionDao
+}
+
+func (p *dupScanConnectionDao) ScanAppConnections(ctx context.Context, appId int64, activeSince time.Time, cursor uint64, count int64) ([]*data.ConnectionInfo, uint64, error) {
+	conns, next, err := p.ConnectionDao.ScanAppConnections(ctx, appId, activeSince, cursor, count)
+	return append(conns, conns...), next, err
+}
+
+func TestServicePushScanDuplicates(t *testing.T) {
+	svc := newTestService(t, newTestConn(testConnId1), newTestConn(testConnId2))
+	svc.Service = service.NewService(nil, &dupScanConnectionDao{svc.connDao}, nil, svc.nats, nil, nil)
+
+	pushed := pushTarget(t, svc, exprTarget(`user_id == 123`))
+	assert.ElementsMatch(t, []string{testConnId1, testConnId2}, pushed)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+/*
+会话状态
+
+会话状态包括连接的 tags、attributes、未确认的下行消息及同步位置。
+客户端断线重连 (RECONNECT) 时，旧连接的会话状态转移到新连接上，
+合并后的 tags、attributes 写回新连接的路由信息。
+
+broker 在 CONNECT/RECONNECT 时写入 tags (claims.tags) 和 attributes。
+pending_messages 和 sync_seq 不在当前实现范围内：comet 不向 broker 上报
+下行消息的确认和同步位置，broker 不写入这两个字段，转移时只合并已有的值，
+由其他写入方 (如业务服务) 自行维护。
+
+- Key: sess:{{app_id}:{user_id}}:{connection_id}
+- Value: data.SessionState
+- GET/SET KEY 读写会话状态
+
+key 按 {app_id}:{user_id} 打 hash tag，同一用户新旧连接的会话位于
+Redis cluster 的同一个 slot，转移会话时由一个 Lua 脚本比较并写入新旧会话，
+并发恢复同一会话时，读取后被修改的会话会重新合并。
+
+会话恢复标记
+旧连接的会话被新连接恢复后，写入恢复标记，
+用于在断开旧连接时抑制 DISCONNECT 事件。
+
+- Key: sess:r:{{app_id}:{user_id}}:{connection_id}
+- Value: new connection_id
+*/
+
+type SessionDao interface {
+	GetSession(ctx context.Context, appId, userId int64, connectionId string) (*data.SessionState, error)
+	SaveSession(ctx context.Context, appId, userId int64, connectionId string, state *data.SessionState) error
+	DeleteSession(ctx context.Context, appId, userId int64, connectionId string) error
+
+	// TransferSession merges the session state of oldId into newId,
+	// and marks oldId as being resumed by newId. It returns the merged
+	// state, which is nil if neither connection has a session state.
+	// Only sessions of the same user are transferred.
+	TransferSession(ctx context.Context, appId, userId int64, oldId, newId string) (*data.SessionState, error)
+
+	// IsResumed tells whether the session of connectionId has been
+	// resumed by another connection.
+	IsResumed(ctx context.Context, appId, userId int64, connectionId string) (bool, error)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+const (
+	DefaultSweepInterval      = time.Minute
+	DefaultStaleConnectionAge = 5 * time.Minute
+
+	sweepTimeout = 5 * time.Minute
+	sweepBatch   = 100
+)
+
+type SweeperConfig struct {
+	// Interval between two sweeps, zero disables sweeping.
+	Interval time.Duration
+
+	// StaleAge is the duration after the last TOUCH event that
+	// a connection is considered to be gone.
+	StaleAge time.Duration
+}
+
+func NewSweeperConfig() *SweeperConfig {
+	return &SweeperConfig{
+		Interval: DefaultSweepInterval,
+		StaleAge: DefaultStaleConnectionAge,
+	}
+}
+
+func NewRouteSweeper(config *SweeperConfig, connDao ConnectionDao, events *EventHandler) *RouteSweeper {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &RouteSweeper{
+		Interval: config.Interval,
+		StaleAge: config.StaleAge,
+		connDao:  connDao,
+		events:   events,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	if s.Interval > 0 && s.StaleAge > 0 {
+		s.wg.Add(1)
+		go s.loop()
+	}
+	return s
+}
+
+// RouteSweeper removes stale connections from the routing table.
+//
+// Connections closed silently, eg. the DISCONNECT events are lost,
+// stop being touched by comet servers. They are removed by the sweeper
+// after StaleAge, and DISCONNECT events with reason "timeout" are
+// delivered to the business service for them.
+type RouteSweeper struct {
+	Interval time.Duration
+	StaleAge time.Duration
+
+	connDao ConnectionDao
+	events  *EventHandler
+
+	// ctx is canceled by Close to stop the running sweep.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (s *RouteSweeper) loop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep()
+		}
+	}
+}
+
+// Sweep removes the connections which are not touched in StaleAge.
+func (s *RouteSweeper) Sweep() {
+	ctx, cancel := context.WithTimeout(s.ctx, sweepTimeout)
+	defer cancel()
+
+	var total int
+	before := time.Now().Add(-s.StaleAge)
+	err := s.connDao.SweepConnections(ctx, before, sweepBatch, func(removed []*data.ConnectionInfo) {
+		total += len(removed)
+		metricSweptConnections.Add(int64(len(removed)))
+		s.events.ExpireConnections(ctx, removed)
+	})
+	if err != nil {
+		zlog.Errorf("failed sweep routing table, err= %v", err)
+	}
+	if total > 0 {
+		zlog.Infof("swept stale connections from routing table, count= %d", total)
+	}
+}
+
+// Close stops the background sweeping, the running sweep is canceled,
+// the connections left are swept later by any broker instance.
+func (s *RouteSweeper) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
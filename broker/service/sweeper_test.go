@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"context"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+func TestRouteSweeper(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	connDao := dao.NewConnectionDao(redisCli)
+	bizapi := &fakeBizApi{}
+	handler := service.NewEventHandler(connDao, dao.NewSessionDao(redisCli), bizapi)
+	sweeper := service.NewRouteSweeper(&service.SweeperConfig{StaleAge: 20 * time.Millisecond}, connDao, handler)
+	defer sweeper.Close()
+
+	require.Nil(t, handler.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, testConnId1)))
+	require.Nil(t, handler.HandleEvent(ctx, newTestEvent(protocol.Event_CONNECT, testConnId2)))
+	time.Sleep(30 * time.Millisecond)
+	require.Nil(t, handler.HandleEvent(ctx, newTestEvent(protocol.Event_TOUCH, testConnId2)))
+
+	swept := expvar.Get("broker").(*expvar.Map).Get("swept_connections").(*expvar.Int)
+	before := swept.Value()
+	sweeper.Sweep()
+
+	assert.Equal(t, []string{testConnId2}, listUserConnIds(t, connDao, 123))
+	assert.Equal(t, before+1, swept.Value())
+	events := bizapi.events
+	require.Len(t, events, 4)
+	assert.Equal(t, protocol.Event_DISCONNECT, events[3].GetType())
+	assert.Equal(t, testConnId1, events[3].GetConn().GetId())
+	assert.Equal(t, service.DisconnectReasonTimeout, events[3].GetDisconnectData().GetReason())
+}
@@ -2,20 +2,31 @@ package service
 
 import (
 	"context"
+	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jxskiss/errors"
 
 	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
 	"github.com/jxskiss/nonamegw/proto/cometsvc"
 	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/protocol"
 )
 
 const (
 	TokenVersion0   = "0"
+	TokenVersion1   = "1"
 	TokenExpiration = 10 * time.Minute
+
+	// TokenRefreshWindow is the duration after expiration that a token
+	// can still be refreshed.
+	TokenRefreshWindow = time.Hour
+
+	DefaultRevocationSyncInterval = 3 * time.Second
 )
 
 const (
@@ -25,65 +36,515 @@ const (
 type TokenDao interface {
 	GetToken(ctx context.Context, token string) (*data.TokenInfo, error)
 	SaveToken(ctx context.Context, info *data.TokenInfo, ttl time.Duration) error
+
+	// RevokeToken revokes a single token, the revocation is kept for ttl.
+	RevokeToken(ctx context.Context, token string, ttl time.Duration) error
+
+	// RevokeUserTokens revokes all tokens of a user signed not later
+	// than beforeMsec, the revocation is kept for ttl.
+	RevokeUserTokens(ctx context.Context, appId, userId int64, beforeMsec int64, ttl time.Duration) error
+
+	// IsTokenRevoked checks both single token revocation and user tokens
+	// revocation in one round trip.
+	IsTokenRevoked(ctx context.Context, appId, userId int64, token string, signTimeMsec int64) (bool, error)
+
+	// ListRevocations returns the revocations logged after cursor and
+	// the cursor to list later ones, a zero cursor lists all revocations
+	// which are not expired. If the log has been reset, all revocations
+	// in the log are returned.
+	ListRevocations(ctx context.Context, cursor uint64) ([]*TokenRevocation, uint64, error)
+
+	// MarkTokenUsed marks a token as used, it returns false if the token
+	// has already been marked.
+	MarkTokenUsed(ctx context.Context, token string, ttl time.Duration) (bool, error)
+}
+
+// TokenRevocation revokes a single token if Token is not empty,
+// else it revokes tokens of the user signed not later than BeforeMsec.
+type TokenRevocation struct {
+	Token      string
+	AppId      int64
+	UserId     int64
+	BeforeMsec int64
 }
 
 type Signer interface {
-	SignAuthToken(ctx context.Context, appId, userId, deviceId int64) (*cometsvc.AuthToken, error)
-	DecodeAuthToken(ctx context.Context, token string) (*cometsvc.AuthToken, error)
+	// SignAuthToken signs a token, if bindIp is not empty, the token
+	// can only be used by clients connecting from the IP, an invalid
+	// bindIp is rejected with errcode.InvalidBindIp.
+	SignAuthToken(ctx context.Context, appId, userId, deviceId int64, claims *protocol.TokenClaims, bindIp string) (*cometsvc.AuthToken, error)
+
+	// DecodeAuthToken verifies a token used by a client from clientIp,
+	// the error cause tells why the token is rejected, which is one of
+	// errcode.IllegalAuthToken, errcode.UnknownTokenVersion,
+	// errcode.InvalidAuthToken, errcode.ExpiredAuthToken,
+	// errcode.RevokedAuthToken and errcode.TokenIpMismatch.
+	DecodeAuthToken(ctx context.Context, token, clientIp string) (*cometsvc.AuthToken, error)
+
+	// RefreshAuthToken signs a new token to replace a valid or recently
+	// expired token, the old token is revoked.
+	RefreshAuthToken(ctx context.Context, appId int64, token string) (*cometsvc.AuthToken, error)
+
+	RevokeAuthToken(ctx context.Context, appId int64, token string) error
+	RevokeUserTokens(ctx context.Context, appId, userId int64) error
+
+	// Close stops syncing token revocations.
+	Close() error
 }
 
-func NewSigner(store TokenDao) Signer {
-	return &signer{
-		store: store,
+type SignerConfig struct {
+	// Version of new tokens, version 0 tokens are stored in Redis,
+	// version 1 tokens are signed with the keys managed by KeyManager.
+	Version string
+
+	// SingleUse makes a token can be verified only once, so that it
+	// cannot be replayed.
+	SingleUse bool
+
+	// TokenTTL is the lifetime of tokens, AppTokenTTL overrides it
+	// for specific apps.
+	TokenTTL    time.Duration
+	AppTokenTTL map[int64]time.Duration
+
+	// RevocationSyncInterval is the interval to sync token revocations
+	// from the store into memory, tokens are verified against the
+	// revocations in memory without querying the store. Revocations made
+	// by this signer take effect immediately, revocations made by other
+	// broker instances take effect after the next sync. Zero disables
+	// syncing, the store is queried to verify every token.
+	RevocationSyncInterval time.Duration
+
+	// Now returns the current time, it defaults to time.Now.
+	Now func() time.Time
+}
+
+func NewSignerConfig() *SignerConfig {
+	return &SignerConfig{
+		Version:                TokenVersion1,
+		TokenTTL:               TokenExpiration,
+		RevocationSyncInterval: DefaultRevocationSyncInterval,
+	}
+}
+
+func NewSigner(config *SignerConfig, store TokenDao, keyMgr *KeyManager) Signer {
+	now := config.Now
+	if now == nil {
+		now = time.Now
+	}
+	s := &signer{
+		config:        config,
+		store:         store,
+		keyMgr:        keyMgr,
+		now:           now,
+		revokedTokens: make(map[string]time.Time),
+		revokedUsers:  make(map[userKey]*userRevocation),
+		stop:          make(chan struct{}),
 	}
+	if config.RevocationSyncInterval > 0 {
+		s.wg.Add(1)
+		go s.syncLoop()
+	}
+	return s
 }
 
 type signer struct {
-	store TokenDao
+	config *SignerConfig
+	store  TokenDao
+	keyMgr *KeyManager
+	now    func() time.Time
+
+	// Revocations synced from the store, the values are expire times.
+	// Tokens are verified by querying the store until the first sync.
+	mu            sync.RWMutex
+	synced        bool
+	revCursor     uint64
+	revokedTokens map[string]time.Time
+	revokedUsers  map[userKey]*userRevocation
+	nextPurge     time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	wg       sync.WaitGroup
 }
 
-func (s *signer) SignAuthToken(ctx context.Context, appId, userId, deviceId int64) (*cometsvc.AuthToken, error) {
-	signTimeMsec := time.Now().UnixNano() / 1e6
-	token := newTokenUuid()
-	info := &data.TokenInfo{
-		Id:           token,
-		SignTimeMsec: signTimeMsec,
-		AppId:        appId,
-		UserId:       userId,
-		DeviceId:     deviceId,
+type userKey struct {
+	appId  int64
+	userId int64
+}
+
+type userRevocation struct {
+	beforeMsec int64
+	expire     time.Time
+}
+
+func (s *signer) nowMsec() int64 {
+	return s.now().UnixNano() / 1e6
+}
+
+func (s *signer) SignAuthToken(ctx context.Context, appId, userId, deviceId int64, claims *protocol.TokenClaims, bindIp string) (*cometsvc.AuthToken, error) {
+	if bindIp != "" {
+		ip := parseClientIp(bindIp)
+		if ip == nil {
+			return nil, errors.AddStack(errcode.InvalidBindIp)
+		}
+		bindIp = ip.String()
+	}
+	signTime := s.now()
+	token := &cometsvc.AuthToken{
+		SignTimeMsec:   signTime.UnixNano() / 1e6,
+		AppId:          appId,
+		UserId:         userId,
+		DeviceId:       deviceId,
+		ExpireTimeMsec: signTime.Add(s.tokenTTL(appId)).UnixNano() / 1e6,
+		Claims:         claims,
+		BindIp:         bindIp,
+	}
+	var err error
+	if s.config.Version == TokenVersion1 {
+		err = s.signV1Token(token)
+	} else {
+		err = s.signV0Token(ctx, token)
 	}
-	err := s.store.SaveToken(ctx, info, TokenExpiration)
 	if err != nil {
 		return nil, errors.AddStack(err)
 	}
-	result := &cometsvc.AuthToken{
-		Token:        token,
-		SignTimeMsec: signTimeMsec,
-		AppId:        appId,
-		UserId:       userId,
-		DeviceId:     deviceId,
+	return token, nil
+}
+
+func (s *signer) tokenTTL(appId int64) time.Duration {
+	if ttl := s.config.AppTokenTTL[appId]; ttl > 0 {
+		return ttl
+	}
+	if s.config.TokenTTL > 0 {
+		return s.config.TokenTTL
+	}
+	return TokenExpiration
+}
+
+// revocationTTL is long enough to cover the refresh window of any token.
+func (s *signer) revocationTTL() time.Duration {
+	ttl := s.tokenTTL(0)
+	for _, x := range s.config.AppTokenTTL {
+		if x > ttl {
+			ttl = x
+		}
+	}
+	return ttl + TokenRefreshWindow
+}
+
+func (s *signer) DecodeAuthToken(ctx context.Context, token, clientIp string) (*cometsvc.AuthToken, error) {
+	result, err := s.decode(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	nowMsec := s.nowMsec()
+	if result.ExpireTimeMsec < nowMsec {
+		return nil, errors.AddStack(errcode.ExpiredAuthToken)
+	}
+	if err = s.checkRevoked(ctx, result); err != nil {
+		return nil, err
+	}
+	if result.BindIp != "" {
+		bindIp := net.ParseIP(result.BindIp)
+		if bindIp == nil || !bindIp.Equal(parseClientIp(clientIp)) {
+			return nil, errors.AddStack(errcode.TokenIpMismatch)
+		}
+	}
+	if s.config.SingleUse {
+		ttl := time.Duration(result.ExpireTimeMsec-nowMsec) * time.Millisecond
+		first, err := s.store.MarkTokenUsed(ctx, token, ttl)
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+		if !first {
+			return nil, errors.AddStack(errcode.RevokedAuthToken)
+		}
 	}
 	return result, nil
 }
 
-func (s *signer) DecodeAuthToken(ctx context.Context, token string) (*cometsvc.AuthToken, error) {
+func (s *signer) RefreshAuthToken(ctx context.Context, appId int64, token string) (*cometsvc.AuthToken, error) {
+	old, err := s.decodeAppToken(ctx, appId, token)
+	if err != nil {
+		return nil, err
+	}
+	refreshDeadline := old.ExpireTimeMsec + int64(TokenRefreshWindow/time.Millisecond)
+	if refreshDeadline < s.nowMsec() {
+		return nil, errors.AddStack(errcode.ExpiredAuthToken)
+	}
+	if err = s.checkRevoked(ctx, old); err != nil {
+		return nil, err
+	}
+	result, err := s.SignAuthToken(ctx, old.AppId, old.UserId, old.DeviceId, old.Claims, old.BindIp)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	err = s.store.RevokeToken(ctx, token, s.revocationTTL())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	s.addRevocations(&TokenRevocation{Token: token})
+	return result, nil
+}
+
+func (s *signer) RevokeAuthToken(ctx context.Context, appId int64, token string) error {
+	_, err := s.decodeAppToken(ctx, appId, token)
+	if err != nil {
+		return err
+	}
+	err = s.store.RevokeToken(ctx, token, s.revocationTTL())
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	s.addRevocations(&TokenRevocation{Token: token})
+	return nil
+}
+
+func (s *signer) RevokeUserTokens(ctx context.Context, appId, userId int64) error {
+	beforeMsec := s.nowMsec()
+	err := s.store.RevokeUserTokens(ctx, appId, userId, beforeMsec, s.revocationTTL())
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	s.addRevocations(&TokenRevocation{AppId: appId, UserId: userId, BeforeMsec: beforeMsec})
+	return nil
+}
+
+func (s *signer) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.wg.Wait()
+	return nil
+}
+
+// decode decodes and verifies the signature of a token, it does not
+// check expiration and revocation.
+func (s *signer) decode(ctx context.Context, token string) (*cometsvc.AuthToken, error) {
+	switch {
+	case strings.HasPrefix(token, TokenVersion0):
+		return s.decodeV0Token(ctx, token)
+	case strings.HasPrefix(token, TokenVersion1):
+		return s.decodeV1Token(token)
+	}
+	return nil, errors.AddStack(errcode.UnknownTokenVersion)
+}
+
+func (s *signer) decodeAppToken(ctx context.Context, appId int64, token string) (*cometsvc.AuthToken, error) {
+	result, err := s.decode(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if result.AppId != appId {
+		return nil, errors.AddStack(errcode.InvalidAuthToken)
+	}
+	return result, nil
+}
+
+func (s *signer) checkRevoked(ctx context.Context, token *cometsvc.AuthToken) error {
+	revoked, synced := s.isRevoked(token)
+	if !synced {
+		var err error
+		revoked, err = s.store.IsTokenRevoked(ctx, token.AppId, token.UserId, token.Token, token.SignTimeMsec)
+		if err != nil {
+			return errors.AddStack(err)
+		}
+	}
+	if revoked {
+		return errors.AddStack(errcode.RevokedAuthToken)
+	}
+	return nil
+}
+
+// isRevoked checks a token against the revocations in memory, synced
+// is false if the revocations have not been synced from the store.
+func (s *signer) isRevoked(token *cometsvc.AuthToken) (revoked, synced bool) {
+	now := s.now()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.synced {
+		return false, false
+	}
+	if expire, ok := s.revokedTokens[token.Token]; ok && now.Before(expire) {
+		return true, true
+	}
+	if token.UserId > 0 {
+		u := s.revokedUsers[userKey{token.AppId, token.UserId}]
+		if u != nil && now.Before(u.expire) && token.SignTimeMsec <= u.beforeMsec {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+func (s *signer) syncLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.config.RevocationSyncInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.RevocationSyncInterval)
+		if err := s.syncRevocations(ctx); err != nil {
+			zlog.Errorf("failed sync token revocations, err= %v", err)
+		}
+		cancel()
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncRevocations reads the revocations logged since the last sync.
+func (s *signer) syncRevocations(ctx context.Context) error {
+	s.mu.RLock()
+	cursor := s.revCursor
+	s.mu.RUnlock()
+	revs, next, err := s.store.ListRevocations(ctx, cursor)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	s.addRevocations(revs...)
+	s.mu.Lock()
+	s.revCursor = next
+	s.synced = true
+	s.mu.Unlock()
+	return nil
+}
+
+// addRevocations adds revocations into memory, they expire after
+// the tokens cannot be refreshed anymore.
+func (s *signer) addRevocations(revs ...*TokenRevocation) {
+	now := s.now()
+	expire := now.Add(s.revocationTTL())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !now.Before(s.nextPurge) {
+		s.purgeRevocations(now)
+		s.nextPurge = now.Add(time.Minute)
+	}
+	for _, rev := range revs {
+		if rev.Token != "" {
+			s.revokedTokens[rev.Token] = expire
+			continue
+		}
+		key := userKey{rev.AppId, rev.UserId}
+		u := s.revokedUsers[key]
+		if u == nil {
+			u = &userRevocation{}
+			s.revokedUsers[key] = u
+		}
+		if rev.BeforeMsec > u.beforeMsec {
+			u.beforeMsec = rev.BeforeMsec
+		}
+		u.expire = expire
+	}
+}
+
+// purgeRevocations must be called with s.mu held.
+func (s *signer) purgeRevocations(now time.Time) {
+	for token, expire := range s.revokedTokens {
+		if !now.Before(expire) {
+			delete(s.revokedTokens, token)
+		}
+	}
+	for key, u := range s.revokedUsers {
+		if !now.Before(u.expire) {
+			delete(s.revokedUsers, key)
+		}
+	}
+}
+
+func (s *signer) signV0Token(ctx context.Context, token *cometsvc.AuthToken) error {
+	token.Token = newTokenUuid()
+	info := &data.TokenInfo{
+		Id:             token.Token,
+		SignTimeMsec:   token.SignTimeMsec,
+		AppId:          token.AppId,
+		UserId:         token.UserId,
+		DeviceId:       token.DeviceId,
+		ExpireTimeMsec: token.ExpireTimeMsec,
+		Claims:         token.Claims,
+		BindIp:         token.BindIp,
+	}
+	// Keep the token after expiration, so that we can tell it is expired
+	// and it can be refreshed.
+	ttl := s.tokenTTL(token.AppId) + TokenRefreshWindow
+	err := s.store.SaveToken(ctx, info, ttl)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (s *signer) decodeV0Token(ctx context.Context, token string) (*cometsvc.AuthToken, error) {
 	if len(token) != v0TokenLength {
 		return nil, errors.AddStack(errcode.IllegalAuthToken)
 	}
-	if !strings.HasPrefix(token, TokenVersion0) {
-		return nil, errors.AddStack(errcode.UnknownTokenVersion)
-	}
 
 	info, err := s.store.GetToken(ctx, token)
 	if err != nil {
 		return nil, errors.AddStack(err)
 	}
+	if info == nil {
+		return nil, errors.AddStack(errcode.InvalidAuthToken)
+	}
+	expireTimeMsec := info.ExpireTimeMsec
+	if expireTimeMsec == 0 {
+		expireTimeMsec = info.SignTimeMsec + int64(s.tokenTTL(info.AppId)/time.Millisecond)
+	}
+	result := &cometsvc.AuthToken{
+		Token:          info.Id,
+		SignTimeMsec:   info.SignTimeMsec,
+		AppId:          info.AppId,
+		UserId:         info.UserId,
+		DeviceId:       info.DeviceId,
+		ExpireTimeMsec: expireTimeMsec,
+		Claims:         info.Claims,
+		BindIp:         info.BindIp,
+	}
+	return result, nil
+}
+
+func (s *signer) signV1Token(token *cometsvc.AuthToken) error {
+	key := s.keyMgr.CurrentKey()
+	if key == nil {
+		return errors.New("no token key available")
+	}
+	payload := &data.TokenPayload{
+		KeyId:          key.KeyId,
+		AppId:          token.AppId,
+		UserId:         token.UserId,
+		DeviceId:       token.DeviceId,
+		SignTimeMsec:   token.SignTimeMsec,
+		ExpireTimeMsec: token.ExpireTimeMsec,
+		Claims:         token.Claims,
+		BindIp:         token.BindIp,
+	}
+	var err error
+	token.Token, err = encodeTokenV1(key, payload)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+func (s *signer) decodeV1Token(token string) (*cometsvc.AuthToken, error) {
+	payload, signed, sig, err := parseTokenV1(token)
+	if err != nil {
+		return nil, err
+	}
+	key := s.keyMgr.GetKey(payload.KeyId)
+	if key == nil || !verifyTokenV1(key, signed, sig) {
+		return nil, errors.AddStack(errcode.InvalidAuthToken)
+	}
 	result := &cometsvc.AuthToken{
-		Token:        info.Id,
-		SignTimeMsec: info.SignTimeMsec,
-		AppId:        info.AppId,
-		UserId:       info.UserId,
-		DeviceId:     info.DeviceId,
+		Token:          token,
+		SignTimeMsec:   payload.SignTimeMsec,
+		AppId:          payload.AppId,
+		UserId:         payload.UserId,
+		DeviceId:       payload.DeviceId,
+		ExpireTimeMsec: payload.ExpireTimeMsec,
+		Claims:         payload.Claims,
+		BindIp:         payload.BindIp,
 	}
 	return result, nil
 }
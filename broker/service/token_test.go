@@ -0,0 +1,285 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+func TestSignerV1Token(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	keyDao := dao.NewTokenKeyDao(redisCli)
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), keyDao)
+	require.Nil(t, err)
+	defer keyMgr.Close()
+	signer := service.NewSigner(service.NewSignerConfig(), dao.NewTokenDao(redisCli), keyMgr)
+
+	for _, alg := range []string{service.TokenAlgHmacSha256, service.TokenAlgEd25519} {
+		t.Run(alg, func(t *testing.T) {
+			keys, err := keyDao.ListTokenKeys(ctx)
+			require.Nil(t, err)
+			for _, k := range keys {
+				require.Nil(t, keyDao.DeleteTokenKeys(ctx, k.KeyId))
+			}
+			keyMgr.Algorithm = alg
+			_, err = keyMgr.Rotate(ctx, time.Now())
+			require.Nil(t, err)
+			assert.Equal(t, alg, keyMgr.CurrentKey().Algorithm)
+
+			token, err := signer.SignAuthToken(ctx, 1001, 123, 456, nil, "")
+			require.Nil(t, err)
+			assert.True(t, strings.HasPrefix(token.Token, service.TokenVersion1))
+
+			got, err := signer.DecodeAuthToken(ctx, token.Token, "")
+			require.Nil(t, err)
+			assert.Equal(t, int64(1001), got.AppId)
+			assert.Equal(t, int64(123), got.UserId)
+			assert.Equal(t, int64(456), got.DeviceId)
+			assert.Equal(t, token.SignTimeMsec, got.SignTimeMsec)
+
+			// Changes the first signature char, the last ones may carry
+			// padding bits which do not change the decoded signature.
+			dot := strings.IndexByte(token.Token, '.')
+			c := "A"
+			if token.Token[dot+1] == 'A' {
+				c = "B"
+			}
+			tampered := token.Token[:dot+1] + c + token.Token[dot+2:]
+			_, err = signer.DecodeAuthToken(ctx, tampered, "")
+			assert.Equal(t, errcode.InvalidAuthToken, errors.Cause(err))
+
+			_, err = signer.DecodeAuthToken(ctx, token.Token[:10], "")
+			assert.Equal(t, errcode.IllegalAuthToken, errors.Cause(err))
+		})
+	}
+}
+
+func TestSignerDecodeV0Token(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), dao.NewTokenKeyDao(redisCli))
+	require.Nil(t, err)
+	defer keyMgr.Close()
+	tokenDao := dao.NewTokenDao(redisCli)
+	signer := service.NewSigner(service.NewSignerConfig(), tokenDao, keyMgr)
+
+	v0Token := service.TokenVersion0 + strings.Repeat("a", 32)
+	err = tokenDao.SaveToken(ctx, &data.TokenInfo{
+		Id:           v0Token,
+		SignTimeMsec: time.Now().UnixNano() / 1e6,
+		AppId:        1001,
+		UserId:       123,
+		DeviceId:     456,
+	}, service.TokenExpiration)
+	require.Nil(t, err)
+
+	got, err := signer.DecodeAuthToken(ctx, v0Token, "")
+	require.Nil(t, err)
+	assert.Equal(t, int64(123), got.UserId)
+
+	_, err = signer.DecodeAuthToken(ctx, "9abc", "")
+	assert.Equal(t, errcode.UnknownTokenVersion, errors.Cause(err))
+}
+
+func TestSignerV0TokenExpired(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	tokenDao := dao.NewTokenDao(redisCli)
+	signer := service.NewSigner(service.NewSignerConfig(), tokenDao, nil)
+
+	v0Token := service.TokenVersion0 + strings.Repeat("b", 32)
+	signTime := time.Now().Add(-service.TokenExpiration - time.Minute)
+	err := tokenDao.SaveToken(ctx, &data.TokenInfo{
+		Id:           v0Token,
+		SignTimeMsec: signTime.UnixNano() / 1e6,
+		AppId:        1001,
+		UserId:       123,
+	}, time.Hour)
+	require.Nil(t, err)
+
+	_, err = signer.DecodeAuthToken(ctx, v0Token, "")
+	assert.Equal(t, errcode.ExpiredAuthToken, errors.Cause(err))
+
+	_, err = signer.DecodeAuthToken(ctx, service.TokenVersion0+strings.Repeat("c", 32), "")
+	assert.Equal(t, errcode.InvalidAuthToken, errors.Cause(err))
+}
+
+func TestSignerRefreshAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), dao.NewTokenKeyDao(redisCli))
+	require.Nil(t, err)
+	defer keyMgr.Close()
+	now := time.Now()
+	config := service.NewSignerConfig()
+	config.Now = func() time.Time { return now }
+	signer := service.NewSigner(config, dao.NewTokenDao(redisCli), keyMgr)
+
+	token1, err := signer.SignAuthToken(ctx, 1001, 123, 456, nil, "")
+	require.Nil(t, err)
+
+	_, err = signer.RefreshAuthToken(ctx, 1002, token1.Token)
+	assert.Equal(t, errcode.InvalidAuthToken, errors.Cause(err))
+
+	now = now.Add(time.Millisecond)
+	token2, err := signer.RefreshAuthToken(ctx, 1001, token1.Token)
+	require.Nil(t, err)
+	assert.NotEqual(t, token1.Token, token2.Token)
+	assert.Equal(t, int64(456), token2.DeviceId)
+
+	_, err = signer.DecodeAuthToken(ctx, token1.Token, "")
+	assert.Equal(t, errcode.RevokedAuthToken, errors.Cause(err))
+	_, err = signer.RefreshAuthToken(ctx, 1001, token1.Token)
+	assert.Equal(t, errcode.RevokedAuthToken, errors.Cause(err))
+	_, err = signer.DecodeAuthToken(ctx, token2.Token, "")
+	assert.Nil(t, err)
+
+	err = signer.RevokeUserTokens(ctx, 1001, 123)
+	require.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token2.Token, "")
+	assert.Equal(t, errcode.RevokedAuthToken, errors.Cause(err))
+
+	now = now.Add(time.Millisecond)
+	token3, err := signer.SignAuthToken(ctx, 1001, 123, 456, nil, "")
+	require.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token3.Token, "")
+	assert.Nil(t, err)
+}
+
+func TestSignerRevocationSync(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), dao.NewTokenKeyDao(redisCli))
+	require.Nil(t, err)
+	defer keyMgr.Close()
+	now := time.Now()
+	config := service.NewSignerConfig()
+	config.RevocationSyncInterval = time.Hour
+	config.Now = func() time.Time { return now }
+	store := dao.NewTokenDao(redisCli)
+	signer1 := service.NewSigner(config, store, keyMgr)
+	defer signer1.Close()
+	signer2 := service.NewSigner(config, store, keyMgr)
+	defer signer2.Close()
+	require.Nil(t, service.SyncRevocations(ctx, signer1))
+	require.Nil(t, service.SyncRevocations(ctx, signer2))
+
+	token1, err := signer1.SignAuthToken(ctx, 1001, 123, 456, nil, "")
+	require.Nil(t, err)
+	token2, err := signer1.SignAuthToken(ctx, 1001, 124, 456, nil, "")
+	require.Nil(t, err)
+	_, err = signer2.DecodeAuthToken(ctx, token1.Token, "")
+	require.Nil(t, err)
+
+	// Revocations made by signer1 take effect on signer1 immediately,
+	// and on signer2 after it syncs, it does not query the store to
+	// verify tokens.
+	require.Nil(t, signer1.RevokeAuthToken(ctx, 1001, token1.Token))
+	require.Nil(t, signer1.RevokeUserTokens(ctx, 1001, 124))
+	for _, token := range []string{token1.Token, token2.Token} {
+		_, err = signer1.DecodeAuthToken(ctx, token, "")
+		assert.Equal(t, errcode.RevokedAuthToken, errors.Cause(err))
+		_, err = signer2.DecodeAuthToken(ctx, token, "")
+		assert.Nil(t, err)
+	}
+
+	require.Nil(t, service.SyncRevocations(ctx, signer2))
+	for _, token := range []string{token1.Token, token2.Token} {
+		_, err = signer2.DecodeAuthToken(ctx, token, "")
+		assert.Equal(t, errcode.RevokedAuthToken, errors.Cause(err))
+	}
+
+	// Tokens signed after the user's tokens are revoked are valid.
+	now = now.Add(time.Millisecond)
+	token3, err := signer1.SignAuthToken(ctx, 1001, 124, 456, nil, "")
+	require.Nil(t, err)
+	_, err = signer2.DecodeAuthToken(ctx, token3.Token, "")
+	assert.Nil(t, err)
+
+	// A new signer loads all revocations.
+	signer3 := service.NewSigner(config, store, keyMgr)
+	defer signer3.Close()
+	require.Nil(t, service.SyncRevocations(ctx, signer3))
+	_, err = signer3.DecodeAuthToken(ctx, token2.Token, "")
+	assert.Equal(t, errcode.RevokedAuthToken, errors.Cause(err))
+}
+
+func TestSignerTokenClaims(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), dao.NewTokenKeyDao(redisCli))
+	require.Nil(t, err)
+	defer keyMgr.Close()
+
+	config := service.NewSignerConfig()
+	config.AppTokenTTL = map[int64]time.Duration{1001: time.Hour}
+	claims := &protocol.TokenClaims{
+		Tags:     []string{"room:1"},
+		Roles:    []string{"admin"},
+		Locale:   "en",
+		Platform: "ios",
+		Extra:    map[string]string{"vip": "1"},
+	}
+	for _, version := range []string{service.TokenVersion0, service.TokenVersion1} {
+		config.Version = version
+		signer := service.NewSigner(config, dao.NewTokenDao(redisCli), keyMgr)
+		token, err := signer.SignAuthToken(ctx, 1001, 123, 456, claims, "")
+		require.Nil(t, err)
+		assert.Equal(t, int64(time.Hour/time.Millisecond), token.ExpireTimeMsec-token.SignTimeMsec)
+
+		got, err := signer.DecodeAuthToken(ctx, token.Token, "")
+		require.Nil(t, err)
+		assert.True(t, proto.Equal(claims, got.Claims))
+		assert.Equal(t, token.ExpireTimeMsec, got.ExpireTimeMsec)
+
+		token, err = signer.SignAuthToken(ctx, 1002, 123, 456, nil, "")
+		require.Nil(t, err)
+		assert.Equal(t, int64(service.TokenExpiration/time.Millisecond), token.ExpireTimeMsec-token.SignTimeMsec)
+	}
+}
+
+func TestSignerBindIp(t *testing.T) {
+	ctx := context.Background()
+	redisCli := newTestRedis(t)
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), dao.NewTokenKeyDao(redisCli))
+	require.Nil(t, err)
+	defer keyMgr.Close()
+	signer := service.NewSigner(service.NewSignerConfig(), dao.NewTokenDao(redisCli), keyMgr)
+
+	token, err := signer.SignAuthToken(ctx, 1001, 123, 456, nil, "10.0.0.1")
+	require.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token.Token, "10.0.0.2")
+	assert.Equal(t, errcode.TokenIpMismatch, errors.Cause(err))
+	got, err := signer.DecodeAuthToken(ctx, token.Token, "10.0.0.1")
+	require.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", got.BindIp)
+	_, err = signer.DecodeAuthToken(ctx, token.Token, "10.0.0.1:5678")
+	assert.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token.Token, "")
+	assert.Equal(t, errcode.TokenIpMismatch, errors.Cause(err))
+
+	token, err = signer.SignAuthToken(ctx, 1001, 123, 456, nil, "[2001:DB8::1]:1234")
+	require.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token.Token, "2001:db8:0::1")
+	assert.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token.Token, "[2001:db8::1]:5678")
+	assert.Nil(t, err)
+	_, err = signer.DecodeAuthToken(ctx, token.Token, "2001:db8::2")
+	assert.Equal(t, errcode.TokenIpMismatch, errors.Cause(err))
+
+	_, err = signer.SignAuthToken(ctx, 1001, 123, 456, nil, "10.0.0")
+	assert.Equal(t, errcode.InvalidBindIp, errors.Cause(err))
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/messag"
+)
+
+const (
+	DefaultTokenKeyRotateInterval = 24 * time.Hour
+
+	// New keys are scheduled ahead of activation. A key is published to
+	// comet servers after it is enabled, and tokens are signed only with
+	// the key in the published configuration.
+	tokenKeyActivateDelay = 10 * time.Minute
+	// Superseded keys are kept to verify tokens signed before rotation.
+	tokenKeyRetention = 24 * time.Hour
+
+	tokenKeyCheckInterval = time.Minute
+	// A broker instance which loses the rotation when there is no key
+	// waits for the winner to create the first key.
+	tokenKeyLoadTimeout       = 2 * tokenKeyLockTTL
+	tokenKeyLoadRetryInterval = 500 * time.Millisecond
+	tokenKeyLockTTL       = 30 * time.Second
+	tokenKeySecretLength  = 32
+)
+
+type TokenKeyDao interface {
+	ListTokenKeys(ctx context.Context) ([]*data.TokenKeyInfo, error)
+	SaveTokenKey(ctx context.Context, key *data.TokenKeyInfo) error
+	DeleteTokenKeys(ctx context.Context, keyIds ...string) error
+
+	// AcquireRotation tells whether the caller wins the right to rotate
+	// token keys, only one broker instance wins in the given ttl.
+	AcquireRotation(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+type KeyManagerConfig struct {
+	RotateInterval time.Duration
+
+	// Algorithm of new keys, either TokenAlgHmacSha256 or TokenAlgEd25519.
+	Algorithm string
+}
+
+func NewKeyManagerConfig() *KeyManagerConfig {
+	return &KeyManagerConfig{
+		RotateInterval: DefaultTokenKeyRotateInterval,
+		Algorithm:      TokenAlgHmacSha256,
+	}
+}
+
+func NewKeyManager(config *KeyManagerConfig, dao TokenKeyDao) (*KeyManager, error) {
+	m := &KeyManager{
+		RotateInterval: config.RotateInterval,
+		Algorithm:      config.Algorithm,
+		dao:            dao,
+		stop:           make(chan struct{}),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tokenKeyLoadTimeout)
+	defer cancel()
+	if err := m.load(ctx); err != nil {
+		return nil, errors.AddStack(err)
+	}
+	m.wg.Add(1)
+	go m.loop()
+	return m, nil
+}
+
+// KeyManager manages the rotating keys to sign auth tokens.
+//
+// Keys are stored in Redis, a new key is scheduled to be enabled every
+// RotateInterval, superseded keys are kept for a retention period to
+// verify tokens signed before rotation. Comet servers get the keys
+// by the broker RPC getCometConfiguration, and are notified by watchers
+// when the keys change.
+type KeyManager struct {
+	RotateInterval time.Duration
+	Algorithm      string
+
+	dao TokenKeyDao
+
+	mu       sync.RWMutex
+	keys     []*data.TokenKeyInfo // sorted by enable time
+	config   *messag.CometConfiguration
+	watchers []func(config *messag.CometConfiguration)
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// GetConfiguration returns the comet configuration built from current keys.
+func (m *KeyManager) GetConfiguration() *messag.CometConfiguration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config
+}
+
+// CurrentKey returns the key to sign new tokens, it is the current key
+// in the configuration published to comet servers.
+func (m *KeyManager) CurrentKey() *data.TokenKeyInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.config == nil {
+		return nil
+	}
+	for _, k := range m.keys {
+		if k.KeyId == m.config.TokenKeyId {
+			return k
+		}
+	}
+	return nil
+}
+
+// GetKey returns an enabled key by id, it returns nil if the key
+// does not exist or is not enabled yet.
+func (m *KeyManager) GetKey(keyId string) *data.TokenKeyInfo {
+	nowSec := time.Now().Unix()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, k := range m.keys {
+		if k.KeyId == keyId && k.EnableTimeSec <= nowSec {
+			return k
+		}
+	}
+	return nil
+}
+
+// Watch registers a function which is called when the configuration
+// changes, eg. a new key is scheduled or enabled.
+func (m *KeyManager) Watch(fn func(config *messag.CometConfiguration)) {
+	m.mu.Lock()
+	m.watchers = append(m.watchers, fn)
+	m.mu.Unlock()
+}
+
+// Rotate schedules a new key to be enabled at enableTime.
+func (m *KeyManager) Rotate(ctx context.Context, enableTime time.Time) (*data.TokenKeyInfo, error) {
+	key, err := newTokenKey(m.Algorithm, enableTime)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	err = m.dao.SaveTokenKey(ctx, key)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	zlog.Infof("scheduled new token key, key_id= %v, enable_time= %v", key.KeyId, enableTime)
+	if err = m.refresh(ctx); err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return key, nil
+}
+
+// load maintains and loads keys until there is a key to sign tokens.
+func (m *KeyManager) load(ctx context.Context) error {
+	for {
+		if err := m.maintain(ctx); err != nil {
+			return err
+		}
+		if err := m.refresh(ctx); err != nil {
+			return err
+		}
+		if m.CurrentKey() != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("timed out waiting for the first token key")
+		case <-time.After(tokenKeyLoadRetryInterval):
+		}
+	}
+}
+
+func (m *KeyManager) loop() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(tokenKeyCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), tokenKeyLockTTL)
+			if err := m.maintain(ctx); err != nil {
+				zlog.Errorf("failed maintain token keys, err= %v", err)
+			}
+			if err := m.refresh(ctx); err != nil {
+				zlog.Errorf("failed refresh token keys, err= %v", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// maintain schedules new keys and purges expired keys.
+func (m *KeyManager) maintain(ctx context.Context) error {
+	ok, err := m.dao.AcquireRotation(ctx, tokenKeyLockTTL)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	if !ok {
+		return nil
+	}
+	keys, err := m.dao.ListTokenKeys(ctx)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	sortTokenKeys(keys)
+
+	now := time.Now()
+	if len(keys) == 0 {
+		_, err = m.Rotate(ctx, now)
+		return err
+	}
+
+	latest := keys[len(keys)-1]
+	nextEnableTime := time.Unix(latest.EnableTimeSec, 0).Add(m.RotateInterval)
+	if !now.Add(tokenKeyActivateDelay).Before(nextEnableTime) {
+		if earliest := now.Add(tokenKeyActivateDelay); nextEnableTime.Before(earliest) {
+			nextEnableTime = earliest
+		}
+		if _, err = m.Rotate(ctx, nextEnableTime); err != nil {
+			return err
+		}
+	}
+
+	// A key expires when the key next to it has been enabled longer
+	// than the retention period.
+	var expired []string
+	purgeBefore := now.Add(-tokenKeyRetention).Unix()
+	for i := 0; i < len(keys)-1; i++ {
+		if keys[i+1].EnableTimeSec < purgeBefore {
+			expired = append(expired, keys[i].KeyId)
+		}
+	}
+	if len(expired) > 0 {
+		zlog.Infof("purging expired token keys, key_ids= %v", expired)
+		if err = m.dao.DeleteTokenKeys(ctx, expired...); err != nil {
+			return errors.AddStack(err)
+		}
+	}
+	return nil
+}
+
+// refresh loads keys from storage and notifies the watchers if the
+// configuration changes.
+func (m *KeyManager) refresh(ctx context.Context) error {
+	keys, err := m.dao.ListTokenKeys(ctx)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	sortTokenKeys(keys)
+	config := buildCometConfiguration(keys, time.Now().Unix())
+
+	m.mu.Lock()
+	changed := !proto.Equal(config, m.config)
+	m.keys = keys
+	m.config = config
+	watchers := m.watchers
+	m.mu.Unlock()
+
+	if changed {
+		for _, fn := range watchers {
+			fn(config)
+		}
+	}
+	return nil
+}
+
+// Close stops the background rotation.
+func (m *KeyManager) Close() error {
+	m.stopOnce.Do(func() { close(m.stop) })
+	m.wg.Wait()
+	return nil
+}
+
+func newTokenKey(algorithm string, enableTime time.Time) (*data.TokenKeyInfo, error) {
+	secret := make([]byte, tokenKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	key := &data.TokenKeyInfo{
+		KeyId:         strconv.FormatInt(time.Now().UnixNano(), 36),
+		Secret:        secret,
+		EnableTimeSec: enableTime.Unix(),
+		Algorithm:     algorithm,
+	}
+	switch algorithm {
+	case TokenAlgHmacSha256:
+	case TokenAlgEd25519:
+		privKey := ed25519.NewKeyFromSeed(secret)
+		key.PublicKey = privKey.Public().(ed25519.PublicKey)
+	default:
+		return nil, errors.Errorf("unknown token key algorithm %q", algorithm)
+	}
+	return key, nil
+}
+
+// verifyKey returns the key distributed to comet servers to verify tokens.
+func verifyKey(key *data.TokenKeyInfo) string {
+	if key.Algorithm == TokenAlgEd25519 {
+		return base64.StdEncoding.EncodeToString(key.PublicKey)
+	}
+	return base64.StdEncoding.EncodeToString(key.Secret)
+}
+
+func sortTokenKeys(keys []*data.TokenKeyInfo) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].EnableTimeSec < keys[j].EnableTimeSec
+	})
+}
+
+func currentTokenKey(keys []*data.TokenKeyInfo, nowSec int64) *data.TokenKeyInfo {
+	var current *data.TokenKeyInfo
+	for _, k := range keys {
+		if k.EnableTimeSec <= nowSec {
+			current = k
+		}
+	}
+	return current
+}
+
+// buildCometConfiguration publishes the current key and the superseded
+// keys in retention, keys are sorted by enable time.
+func buildCometConfiguration(keys []*data.TokenKeyInfo, nowSec int64) *messag.CometConfiguration {
+	config := &messag.CometConfiguration{}
+	current := currentTokenKey(keys, nowSec)
+	if current == nil {
+		return config
+	}
+	config.TokenKey = verifyKey(current)
+	config.TokenKeyId = current.KeyId
+	config.TokenKeyAlgorithm = current.Algorithm
+	retainAfter := nowSec - int64(tokenKeyRetention/time.Second)
+	for i, k := range keys {
+		if k == current {
+			break
+		}
+		if keys[i+1].EnableTimeSec < retainAfter {
+			continue
+		}
+		config.OldTokenKeys = append(config.OldTokenKeys, &messag.TokenKey{
+			Key:           verifyKey(k),
+			EnableTimeSec: k.EnableTimeSec,
+			KeyId:         k.KeyId,
+			Algorithm:     k.Algorithm,
+		})
+	}
+	return config
+}
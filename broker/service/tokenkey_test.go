@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/broker/internal/dao"
+	"github.com/jxskiss/nonamegw/broker/service"
+	"github.com/jxskiss/nonamegw/proto/data"
+	"github.com/jxskiss/nonamegw/proto/messag"
+)
+
+func TestKeyManagerInitialKey(t *testing.T) {
+	keyDao := dao.NewTokenKeyDao(newTestRedis(t))
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), keyDao)
+	require.Nil(t, err)
+	defer keyMgr.Close()
+
+	current := keyMgr.CurrentKey()
+	require.NotNil(t, current)
+	assert.Len(t, current.Secret, 32)
+	config := keyMgr.GetConfiguration()
+	assert.Equal(t, current.KeyId, config.TokenKeyId)
+	assert.NotEmpty(t, config.TokenKey)
+	assert.Len(t, config.OldTokenKeys, 0)
+}
+
+func TestKeyManagerRotation(t *testing.T) {
+	ctx := context.Background()
+	keyDao := dao.NewTokenKeyDao(newTestRedis(t))
+	now := time.Now()
+	for i, enableTime := range []time.Time{
+		now.Add(-72 * time.Hour),
+		now.Add(-48 * time.Hour),
+		now.Add(-time.Hour),
+	} {
+		err := keyDao.SaveTokenKey(ctx, &data.TokenKeyInfo{
+			KeyId:         []string{"k1", "k2", "k3"}[i],
+			Secret:        []byte("secret"),
+			EnableTimeSec: enableTime.Unix(),
+		})
+		require.Nil(t, err)
+	}
+
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), keyDao)
+	require.Nil(t, err)
+	defer keyMgr.Close()
+
+	// k1 is purged, k2 is kept to verify old tokens
+	config := keyMgr.GetConfiguration()
+	assert.Equal(t, "k3", config.TokenKeyId)
+	require.Len(t, config.OldTokenKeys, 1)
+	assert.Equal(t, "k2", config.OldTokenKeys[0].KeyId)
+	assert.NotNil(t, keyMgr.GetKey("k2"))
+	assert.Nil(t, keyMgr.GetKey("k1"))
+
+	var notified *messag.CometConfiguration
+	keyMgr.Watch(func(config *messag.CometConfiguration) {
+		notified = config
+	})
+	newKey, err := keyMgr.Rotate(ctx, now.Add(time.Hour))
+	require.Nil(t, err)
+
+	// The scheduled key is not published until it is enabled.
+	assert.Nil(t, notified)
+	assert.Len(t, keyMgr.GetConfiguration().OldTokenKeys, 1)
+	assert.Nil(t, keyMgr.GetKey(newKey.KeyId))
+	assert.Equal(t, "k3", keyMgr.CurrentKey().KeyId)
+}
+
+// lostRotationDao never wins the rotation, as if another broker
+// instance holds it.
+type lostRotationDao struct {
+	service.TokenKeyDao
+}
+
+func (p lostRotationDao) AcquireRotation(ctx context.Context, ttl time.Duration) (bool, error) {
+	return false, nil
+}
+
+func TestKeyManagerLostRotation(t *testing.T) {
+	ctx := context.Background()
+	keyDao := dao.NewTokenKeyDao(newTestRedis(t))
+	now := time.Now()
+
+	// k2 is current, k1 expired but is not purged yet.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		for i, enableTime := range []time.Time{now.Add(-48 * time.Hour), now.Add(-72 * time.Hour)} {
+			_ = keyDao.SaveTokenKey(ctx, &data.TokenKeyInfo{
+				KeyId:         []string{"k2", "k1"}[i],
+				Secret:        []byte("secret"),
+				EnableTimeSec: enableTime.Unix(),
+			})
+		}
+	}()
+	keyMgr, err := service.NewKeyManager(service.NewKeyManagerConfig(), lostRotationDao{keyDao})
+	require.Nil(t, err)
+	defer keyMgr.Close()
+
+	require.NotNil(t, keyMgr.CurrentKey())
+	assert.Equal(t, "k2", keyMgr.CurrentKey().KeyId)
+	assert.Len(t, keyMgr.GetConfiguration().OldTokenKeys, 0)
+}
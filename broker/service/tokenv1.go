@@ -0,0 +1,93 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/data"
+)
+
+const (
+	TokenAlgHmacSha256 = "hmac-sha256"
+	TokenAlgEd25519    = "ed25519"
+)
+
+var tokenB64Enc = base64.RawURLEncoding
+
+// Version 1 tokens are self-contained, they can be verified without
+// looking up the storage, the format is:
+//
+//   "1" + base64url(data.TokenPayload) + "." + base64url(signature)
+//
+// The signature is computed over the part before ".", using the token
+// key identified by TokenPayload.key_id.
+
+func encodeTokenV1(key *data.TokenKeyInfo, payload *data.TokenPayload) (string, error) {
+	buf, err := proto.Marshal(payload)
+	if err != nil {
+		return "", errors.AddStack(err)
+	}
+	signed := TokenVersion1 + tokenB64Enc.EncodeToString(buf)
+	sig, err := signTokenV1(key, []byte(signed))
+	if err != nil {
+		return "", errors.AddStack(err)
+	}
+	return signed + "." + tokenB64Enc.EncodeToString(sig), nil
+}
+
+// parseTokenV1 decodes the payload of a version 1 token, the signature
+// is not verified.
+func parseTokenV1(token string) (payload *data.TokenPayload, signed, sig []byte, err error) {
+	dot := strings.IndexByte(token, '.')
+	if !strings.HasPrefix(token, TokenVersion1) || dot < 0 {
+		return nil, nil, nil, errors.AddStack(errcode.IllegalAuthToken)
+	}
+	buf, err := tokenB64Enc.DecodeString(token[len(TokenVersion1):dot])
+	if err != nil {
+		return nil, nil, nil, errors.AddStack(errcode.IllegalAuthToken)
+	}
+	sig, err = tokenB64Enc.DecodeString(token[dot+1:])
+	if err != nil {
+		return nil, nil, nil, errors.AddStack(errcode.IllegalAuthToken)
+	}
+	payload = &data.TokenPayload{}
+	if err = proto.Unmarshal(buf, payload); err != nil {
+		return nil, nil, nil, errors.AddStack(errcode.IllegalAuthToken)
+	}
+	return payload, []byte(token[:dot]), sig, nil
+}
+
+func signTokenV1(key *data.TokenKeyInfo, signed []byte) ([]byte, error) {
+	switch key.Algorithm {
+	case TokenAlgHmacSha256:
+		mac := hmac.New(sha256.New, key.Secret)
+		mac.Write(signed)
+		return mac.Sum(nil), nil
+	case TokenAlgEd25519:
+		privKey := ed25519.NewKeyFromSeed(key.Secret)
+		return ed25519.Sign(privKey, signed), nil
+	}
+	return nil, errors.Errorf("unknown token key algorithm %q", key.Algorithm)
+}
+
+func verifyTokenV1(key *data.TokenKeyInfo, signed, sig []byte) bool {
+	switch key.Algorithm {
+	case TokenAlgHmacSha256:
+		mac := hmac.New(sha256.New, key.Secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), sig)
+	case TokenAlgEd25519:
+		if len(key.PublicKey) != ed25519.PublicKeySize {
+			return false
+		}
+		return ed25519.Verify(key.PublicKey, signed, sig)
+	}
+	return false
+}
@@ -4,7 +4,7 @@ use std::time::{SystemTime, Duration, UNIX_EPOCH};
 use anyhow::Result;
 
 use crate::connid::ConnectionId;
-use crate::proto::protocol::Connection as ProtocolConnection;
+use crate::proto::protocol::{Connection as ProtocolConnection, ConnectionDetail};
 
 pub struct Connection {
     pub uid: ConnectionId,
@@ -72,6 +72,36 @@ impl Connection {
             SystemTime::now().duration_since(access_t).unwrap() > 0
     }
 
+    // Traffic counters and the pending queue are not tracked yet,
+    // they are reported as zero.
+    pub fn detail(&self) -> ConnectionDetail {
+        let access_t = self.get_access_time().duration_since(UNIX_EPOCH).unwrap();
+        ConnectionDetail {
+            conn: Some(self.meta.clone()),
+            connect_time_msec: (self.create_time_sec * 1000) as i64,
+            last_active_time_msec: access_t.as_millis() as i64,
+            ..Default::default()
+        }
+    }
+
+    pub async fn close(&self, reason: &str) -> Result<()> {
+        match self.stream
+            .send(Message::close_with(1008u16, reason))
+            .await {
+            Ok(_) => Ok(()),
+            Err(err) => Err(anyhow::Error::new(err)),
+        }
+    }
+
+    pub async fn send(&self, data: Vec<u8>) -> Result<()> {
+        match self.stream
+            .send(Message::binary(data))
+            .await {
+            Ok(_) => Ok(()),
+            Err(err) => Err(anyhow::Error::new(err)),
+        }
+    }
+
     pub async fn ping(&self) -> Result<()> {
         match self.stream
             .send(Message::ping(vec![])) // FIXME
@@ -54,26 +54,41 @@ impl Into<ShortConnectionId> for ConnectionId {
     }
 }
 
-impl ToString for ConnectionId {
-    fn to_string(&self) -> String {
-        let mut machine_id_type: u8;
+impl MachineId {
+    fn encode(&self) -> (u8, [u8; 18]) {
         let mut machine_id_buf = [0u8; 18];
-        match self.machine_id {
+        let machine_id_type = match self {
             MachineId::Random(rand) => {
-                machine_id_type = MachineIdType::RANDOM;
-                machine_id_buf[..].copy_from_slice(&rand);
+                machine_id_buf[..].copy_from_slice(rand);
+                MachineIdType::RANDOM
             }
             MachineId::AddressV4(addr_v4) => {
-                machine_id_type = MachineIdType::ADDRESS_V4;
                 BE::write_u32(&mut machine_id_buf[..4], (*addr_v4.ip()).into());
                 BE::write_u16(&mut machine_id_buf[4..6], addr_v4.port());
+                MachineIdType::ADDRESS_V4
             }
             MachineId::AddressV6(addr_v6) => {
-                machine_id_type = MachineIdType::ADDRESS_V6;
                 BE::write_u128(&mut machine_id_buf[..16], (*addr_v6.ip()).into());
                 BE::write_u16(&mut machine_id_buf[16..18], addr_v6.port());
+                MachineIdType::ADDRESS_V6
             }
-        }
+        };
+        (machine_id_type, machine_id_buf)
+    }
+}
+
+// Formats the machine ID as the part of connection IDs, brokers identify
+// comet servers by it, eg. in heartbeats and NATS subjects.
+impl ToString for MachineId {
+    fn to_string(&self) -> String {
+        let (_, machine_id_buf) = self.encode();
+        base32::encode(B32_ALPHABET, &machine_id_buf)
+    }
+}
+
+impl ToString for ConnectionId {
+    fn to_string(&self) -> String {
+        let (machine_id_type, mut machine_id_buf) = self.machine_id.encode();
 
         let mut buf = [0u8; 28];
         BE::write_u48(&mut buf[..6], (self.msec << 2) | machine_id_type as u64);
@@ -32,6 +32,10 @@ impl Generator {
         }
     }
 
+    pub fn machine_id(&self) -> MachineId {
+        self.machine_id
+    }
+
     pub fn generate(&self) -> ConnectionId {
         self.generate_with_time(SystemTime::now())
     }
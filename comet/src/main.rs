@@ -3,8 +3,10 @@
 use env_logger;
 use futures_util::{SinkExt, StreamExt};
 use log::*;
+use std::env;
 use std::net::SocketAddr;
 use std::str::FromStr;
+use std::sync::Arc;
 use tokio::net::{TcpListener, TcpStream};
 use tokio_tungstenite::{accept_async, tungstenite::Error};
 use tungstenite::Result;
@@ -21,6 +23,10 @@ mod manager;
 mod connection;
 mod linked_list;
 
+use crate::connid::Generator;
+use crate::manager::Manager;
+use crate::nats::{NatsConfig, NatsService};
+
 async fn handle_connection(peer: SocketAddr, stream: TcpStream) -> Result<()> {
     let mut ws_stream = accept_async(stream).await.expect("failed to accept");
     info!("new websocket connection: {}", peer);
@@ -43,15 +49,68 @@ async fn accept_connection(peer: SocketAddr, stream: TcpStream) {
     }
 }
 
+async fn shutdown_signal() {
+    let mut terminate = tokio::signal::unix::signal(
+        tokio::signal::unix::SignalKind::terminate()).unwrap();
+    tokio::select! {
+        _ = tokio::signal::ctrl_c() => {},
+        _ = terminate.recv() => {},
+    }
+}
+
 #[tokio::main]
 async fn main() {
     env_logger::init();
 
-    let app = server::routes2();
+    let addr: SocketAddr = env::var("COMET_ADDR")
+        .unwrap_or("127.0.0.1:9002".to_string())
+        .parse()
+        .unwrap();
+    let nats_url = env::var("COMET_NATS_URL").unwrap_or("127.0.0.1:4222".to_string());
 
-    let addr: SocketAddr = "127.0.0.1:9002".parse().unwrap();
-    hyper::Server::bind(&addr)
+    // Connection IDs carry the listening address as the machine ID,
+    // brokers route messages to this comet by it.
+    let manager = Arc::new(Manager::new(Generator::new_with_machine_id(addr)));
+    let nats_cfg = NatsConfig {
+        server_url: nats_url,
+        machine_id: manager.machine_id(),
+        addr: addr.to_string(),
+        send_message: {
+            let m = manager.clone();
+            Box::new(async move |msg| m.send_message(msg).await)
+        },
+        kick_connections: {
+            let m = manager.clone();
+            Box::new(async move |msg| {
+                m.kick_connections(msg).await;
+                Ok(())
+            })
+        },
+        count_connections: {
+            let m = manager.clone();
+            Box::new(move || m.count() as i64)
+        },
+        get_connection_info: {
+            let m = manager.clone();
+            Box::new(move |conn_id| m.get_connection_info(conn_id))
+        },
+    };
+    let nats = Arc::new(NatsService::new(nats_cfg).await);
+    nats.setup().await.unwrap();
+    info!("comet started, machine_id= {}, addr= {}", manager.machine_id(), addr);
+
+    let app = server::routes2();
+    let result = hyper::Server::bind(&addr)
         .serve(app.into_make_service())
-        .await
-        .unwrap();
+        .with_graceful_shutdown(shutdown_signal())
+        .await;
+    if let Err(err) = result {
+        error!("server error: {}", err);
+    }
+
+    // Brokers clean up the connections of this comet right away,
+    // instead of waiting for the heartbeat timeout.
+    if let Err(err) = nats.shutdown().await {
+        error!("failed send shutdown heartbeat: {}", err);
+    }
 }
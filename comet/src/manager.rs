@@ -1,25 +1,43 @@
 use log::{debug, error, info, warn};
+use prost::Message;
 use std::cell::RefCell;
 use std::collections::HashMap;
 use std::rc::Rc;
 use std::sync::atomic::{AtomicU64, Ordering, AtomicUsize};
 use std::sync::Arc;
+use std::str::FromStr;
 use std::time::{SystemTime, Duration};
 use tokio::sync::mpsc;
 
 use crate::connection::Connection;
 use crate::connid::{Generator, ShortConnectionId, ConnectionId};
 use crate::linked_list::{LinkedList, NodePtr, Node};
-use crate::nats::NatsService;
+use crate::proto::messag::{downgoing_message, DowngoingMessage, KickMessage};
+use crate::proto::protocol::ConnectionDetail;
 
 pub struct Manager {
     clients: dashmap::DashMap<ShortConnectionId, Arc<Connection>>,
     hb_manager: HeartbeatManager,
     uid_gen: Generator,
-    nats: NatsService,
 }
 
 impl Manager {
+    pub fn new(uid_gen: Generator) -> Self {
+        Self {
+            clients: dashmap::DashMap::new(),
+            hb_manager: HeartbeatManager::new(),
+            uid_gen,
+        }
+    }
+
+    pub fn machine_id(&self) -> String {
+        self.uid_gen.machine_id().to_string()
+    }
+
+    pub fn count(&self) -> usize {
+        self.clients.len()
+    }
+
     pub fn add_connection(&self, conn: Arc<Connection>) {
         let short_id = conn.uid.to_short();
         self.clients.insert(short_id, conn);
@@ -44,6 +62,56 @@ impl Manager {
             Some(kv) => Some(kv.value().clone()),
         }
     }
+
+    pub fn get_connection_info(&self, conn_id: &str) -> Option<ConnectionDetail> {
+        let uid = ConnectionId::from_str(conn_id).ok()?;
+        self.get(uid).map(|conn| conn.detail())
+    }
+
+    // Sends a message pushed by brokers to its connections on this comet.
+    pub async fn send_message(&self, msg: DowngoingMessage) -> anyhow::Result<()> {
+        let data = match msg.data {
+            Some(downgoing_message::Data::Packet(packet)) => packet.encode_to_vec(),
+            Some(downgoing_message::Data::BinPacket(buf)) => buf,
+            None => return Ok(()),
+        };
+        for id in msg.conn_ids.iter() {
+            let uid = match ConnectionId::from_str(id) {
+                Ok(x) => x,
+                Err(err) => {
+                    warn!("cannot send to connection with invalid id: conn_id= {}, err= {}", id, err);
+                    continue;
+                }
+            };
+            if let Some(conn) = self.get(uid) {
+                if let Err(err) = conn.send(data.clone()).await {
+                    debug!("failed send message: uid= {}, err= {}", id, err);
+                }
+            }
+        }
+        Ok(())
+    }
+
+    // Closes the connections kicked by brokers, they are removed before
+    // closing, so that no DISCONNECT event is reported for them.
+    pub async fn kick_connections(&self, msg: KickMessage) {
+        for id in msg.conn_ids.iter() {
+            let uid = match ConnectionId::from_str(id) {
+                Ok(x) => x,
+                Err(err) => {
+                    warn!("cannot kick connection with invalid id: conn_id= {}, err= {}", id, err);
+                    continue;
+                }
+            };
+            if let Some(conn) = self.get(uid) {
+                info!("kicking connection: uid= {}, reason= {}", id, msg.reason);
+                self.remove_connection(&conn);
+                if let Err(err) = conn.close(&msg.reason).await {
+                    debug!("failed close kicked connection: uid= {}, err= {}", id, err);
+                }
+            }
+        }
+    }
 }
 
 struct HeartbeatManager {
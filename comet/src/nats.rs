@@ -1,22 +1,57 @@
 use anyhow::{anyhow, Result};
 use async_nats as nats;
+use log::{error, info};
+use std::time::{Duration, SystemTime, UNIX_EPOCH};
 
-use crate::proto::messag::{UpgoingMessage, DowngoingMessage};
-use crate::proto::protocol::{Event};
+use crate::proto::messag::{UpgoingMessage, DowngoingMessage, CometHeartbeat, KickMessage};
+use crate::proto::protocol::{Event, ConnectionDetail};
+use crate::proto::cometsvc::{
+    CometRequest, CometResponse, GetConnectionInfoResponse, RpcError,
+    comet_request, comet_response,
+};
 
 const UPGOING_MESSAGE_TOPIC: &str = "broker.upgoingMessage";
 const EVENT_TOPIC: &str = "broker.event";
-const DOWNGOING_MESSAGE_TOPIC: &str = "broker.{}.downgoingMessage";
+const COMET_HEARTBEAT_TOPIC: &str = "broker.cometHeartbeat";
+const DOWNGOING_MESSAGE_TOPIC: &str = "comet.{}.downgoingMessage";
+const KICK_TOPIC: &str = "comet.{}.kick";
+const RPC_GET_CONNECTION_INFO_TOPIC: &str = "cometRpc.{}.getConnectionInfo";
+
+// Error codes replied to brokers, they must match pkg/errcode.
+const ERR_CONNECTION_NOT_FOUND: (i32, &str) = (110_001, "connection not found");
+const ERR_RPC_BAD_REQUEST: (i32, &str) = (200_001, "bad rpc request");
+
+// Brokers mark a comet dead if no heartbeat is received in 30 seconds.
+const HEARTBEAT_INTERVAL: Duration = Duration::from_secs(10);
 
 pub struct NatsConfig {
     pub server_url: String,
     pub machine_id: String,
+    // addr is the address clients connect to, reported in heartbeats.
+    pub addr: String,
     pub send_message: Box<dyn async Fn(DowngoingMessage) -> Result<()>>,
+    // kick_connections closes the connections kicked by brokers.
+    pub kick_connections: Box<dyn async Fn(KickMessage) -> Result<()>>,
+    pub count_connections: Box<dyn Fn() -> i64 + Send + Sync>,
+    // get_connection_info returns None if the connection is not on this comet.
+    pub get_connection_info: Box<dyn Fn(&str) -> Option<ConnectionDetail> + Send + Sync>,
 }
 
 pub struct NatsService {
     config: NatsConfig,
     conn: nats::Connection,
+    start_time_msec: i64,
+}
+
+fn now_msec() -> i64 {
+    SystemTime::now().duration_since(UNIX_EPOCH).unwrap().as_millis() as i64
+}
+
+fn rpc_error((code, message): (i32, &str)) -> CometResponse {
+    CometResponse {
+        error: Some(RpcError { code, message: message.to_string() }),
+        response: None,
+    }
 }
 
 impl NatsService {
@@ -28,28 +63,130 @@ impl NatsService {
         Self {
             config: cfg,
             conn: nc,
+            start_time_msec: now_msec(),
         }
     }
 
     pub async fn setup(&self) -> Result<()> {
         self._subscribe_downgoing_messages().await?;
+        self._subscribe_kick_messages().await?;
+        self._subscribe_rpc_requests().await?;
+        self._start_heartbeat();
         Ok(())
     }
 
+    // Registers the comet to brokers and keeps heartbeating, so that
+    // brokers know the comet is alive.
+    fn _start_heartbeat(&self) {
+        tokio::spawn(async move {
+            let mut ticker = tokio::time::interval(HEARTBEAT_INTERVAL);
+            loop {
+                ticker.tick().await;
+                if let Err(err) = self.send_heartbeat(self._heartbeat(false)).await {
+                    error!("failed send comet heartbeat: {}", err);
+                }
+            }
+        });
+    }
+
+    fn _heartbeat(&self, shutdown: bool) -> CometHeartbeat {
+        CometHeartbeat {
+            machine_id: self.config.machine_id.clone(),
+            addr: self.config.addr.clone(),
+            start_time_msec: self.start_time_msec,
+            time_msec: now_msec(),
+            connections: (self.config.count_connections)(),
+            shutdown,
+        }
+    }
+
+    // Tells brokers the comet is exiting, so that its connections are
+    // cleaned up without waiting for the heartbeat timeout.
+    pub async fn shutdown(&self) -> Result<()> {
+        info!("sending shutdown heartbeat, machine_id= {}", self.config.machine_id);
+        self.send_heartbeat(self._heartbeat(true)).await
+    }
+
     async fn _subscribe_downgoing_messages(&self) -> Result<()> {
         let subject = format!(DOWNGOING_MESSAGE_TOPIC, self.config.machine_id);
         let sub = self.conn.subscribe(&subject).await?;
         tokio::spawn(async move || {
             while let Some(msg) = sub.next().await {
-                let mut pb_msg: DowngoingMessage = DowngoingMessage::fixme(); // FIXME
-                pb_msg.decode(msg.data).unwrap();
-                self.config.send_message(pb_msg).await.unwrap();
+                let pb_msg = match DowngoingMessage::decode(msg.data) {
+                    Ok(x) => x,
+                    Err(err) => {
+                        error!("failed decode downgoing message: {}", err);
+                        continue;
+                    }
+                };
+                if let Err(err) = (self.config.send_message)(pb_msg).await {
+                    error!("failed send downgoing message: {}", err);
+                }
+            };
+            sub.drain().await;
+        });
+        Ok(())
+    }
+
+    // Brokers kick connections evicted for exceeding the connection limits,
+    // the DISCONNECT events are reported by brokers, the connections must
+    // be closed without reporting again.
+    async fn _subscribe_kick_messages(&self) -> Result<()> {
+        let subject = format!(KICK_TOPIC, self.config.machine_id);
+        let sub = self.conn.subscribe(&subject).await?;
+        tokio::spawn(async move || {
+            while let Some(msg) = sub.next().await {
+                let pb_msg = match KickMessage::decode(msg.data) {
+                    Ok(x) => x,
+                    Err(err) => {
+                        error!("failed decode kick message: {}", err);
+                        continue;
+                    }
+                };
+                if let Err(err) = (self.config.kick_connections)(pb_msg).await {
+                    error!("failed kick connections: {}", err);
+                }
             };
             sub.drain().await;
         });
         Ok(())
     }
 
+    // Serves RPCs called by brokers through pkg/natsrpc, requests and
+    // responses are wrapped in CometRequest and CometResponse.
+    async fn _subscribe_rpc_requests(&self) -> Result<()> {
+        let subject = format!(RPC_GET_CONNECTION_INFO_TOPIC, self.config.machine_id);
+        let sub = self.conn.subscribe(&subject).await?;
+        tokio::spawn(async move || {
+            while let Some(msg) = sub.next().await {
+                let resp = self._get_connection_info(&msg.data);
+                if let Err(err) = msg.respond(resp.encode_to_vec()).await {
+                    error!("failed respond rpc request: subject= {}, err= {}", msg.subject, err);
+                }
+            };
+            sub.drain().await;
+        });
+        Ok(())
+    }
+
+    fn _get_connection_info(&self, data: &[u8]) -> CometResponse {
+        let req = match CometRequest::decode(data) {
+            Ok(CometRequest {
+                request: Some(comet_request::Request::GetConnectionInfoRequest(x)),
+            }) => x,
+            _ => return rpc_error(ERR_RPC_BAD_REQUEST),
+        };
+        match (self.config.get_connection_info)(&req.conn_id) {
+            Some(detail) => CometResponse {
+                error: None,
+                response: Some(comet_response::Response::GetConnectionInfoResponse(
+                    GetConnectionInfoResponse { detail: Some(detail) },
+                )),
+            },
+            None => rpc_error(ERR_CONNECTION_NOT_FOUND),
+        }
+    }
+
     pub async fn call_rpc<REQ, RSP>(&self, subject: &str, req: REQ, resp: RSP) -> Result<()>
         where REQ: prost::Message,
               RSP: prost::Message,
@@ -73,4 +210,8 @@ impl NatsService {
     pub async fn send_upgoing_event(&self, event: Event) -> Result<()> {
         self._send(EVENT_TOPIC, event).await
     }
+
+    pub async fn send_heartbeat(&self, heartbeat: CometHeartbeat) -> Result<()> {
+        self._send(COMET_HEARTBEAT_TOPIC, heartbeat).await
+    }
 }
@@ -1,3 +1,12 @@
+/// RpcError is carried in response envelopes when a RPC fails,
+/// code is the error code registered in pkg/errcode.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct RpcError {
+    #[prost(int32, tag="1")]
+    pub code: i32,
+    #[prost(string, tag="2")]
+    pub message: ::prost::alloc::string::String,
+}
 // ---- broker RPC through Nats Server ---- //
 
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -12,6 +21,12 @@ pub struct AuthToken {
     pub user_id: i64,
     #[prost(int64, tag="5")]
     pub device_id: i64,
+    #[prost(int64, tag="6")]
+    pub expire_time_msec: i64,
+    #[prost(message, optional, tag="7")]
+    pub claims: ::core::option::Option<super::protocol::TokenClaims>,
+    #[prost(string, tag="8")]
+    pub bind_ip: ::prost::alloc::string::String,
 }
 /// Nested message and enum types in `AuthToken`.
 pub mod auth_token {
@@ -19,8 +34,19 @@ pub mod auth_token {
     #[repr(i32)]
     pub enum VerifyCode {
         Success = 0,
+        /// The token is not signed by broker, or does not exist.
         Invalid = 1,
+        /// The token is expired, client should fetch a new token.
         Expired = 2,
+        /// The token is revoked or has already been used, client should
+        /// not retry with it.
+        Revoked = 3,
+        UnknownVersion = 4,
+        Malformed = 5,
+        /// The token is bound to another client IP.
+        IpMismatch = 6,
+        /// The client IP, user or device is in the blocklist of the app.
+        Blocked = 7,
     }
 }
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -62,6 +88,8 @@ pub mod broker_request {
 }
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct BrokerResponse {
+    #[prost(message, optional, tag="100")]
+    pub error: ::core::option::Option<RpcError>,
     #[prost(oneof="broker_response::Response", tags="1, 2")]
     pub response: ::core::option::Option<broker_response::Response>,
 }
@@ -84,9 +112,10 @@ pub struct GetConnectionInfoRequest {
     #[prost(string, tag="1")]
     pub conn_id: ::prost::alloc::string::String,
 }
-/// TODO
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct GetConnectionInfoResponse {
+    #[prost(message, optional, tag="1")]
+    pub detail: ::core::option::Option<super::protocol::ConnectionDetail>,
 }
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct CometRequest {
@@ -103,6 +132,8 @@ pub mod comet_request {
 }
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct CometResponse {
+    #[prost(message, optional, tag="100")]
+    pub error: ::core::option::Option<RpcError>,
     #[prost(oneof="comet_response::Response", tags="1")]
     pub response: ::core::option::Option<comet_response::Response>,
 }
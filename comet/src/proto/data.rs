@@ -12,6 +12,23 @@ pub struct ConnectionInfo {
     pub client_ip: ::prost::alloc::string::String,
     #[prost(string, tag="12")]
     pub client_version: ::prost::alloc::string::String,
+    #[prost(message, optional, tag="13")]
+    pub claims: ::core::option::Option<super::protocol::TokenClaims>,    #[prost(string, tag="14")]
+    pub platform: ::prost::alloc::string::String,
+    #[prost(string, tag="15")]
+    pub os: ::prost::alloc::string::String,
+    #[prost(string, tag="16")]
+    pub locale: ::prost::alloc::string::String,
+    #[prost(string, tag="17")]
+    pub network_type: ::prost::alloc::string::String,
+    #[prost(map="string, string", tag="18")]
+    pub attributes: ::std::collections::HashMap<::prost::alloc::string::String, ::prost::alloc::string::String>,
+    #[prost(string, tag="19")]
+    pub machine_id: ::prost::alloc::string::String,
+    #[prost(int64, tag="20")]
+    pub connect_time_msec: i64,
+    #[prost(int64, tag="21")]
+    pub last_active_time_msec: i64,
 }
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct TokenInfo {
@@ -25,4 +42,72 @@ pub struct TokenInfo {
     pub user_id: i64,
     #[prost(int64, tag="5")]
     pub device_id: i64,
+    #[prost(int64, tag="6")]
+    pub expire_time_msec: i64,
+    #[prost(message, optional, tag="7")]
+    pub claims: ::core::option::Option<super::protocol::TokenClaims>,
+    #[prost(string, tag="8")]
+    pub bind_ip: ::prost::alloc::string::String,
+}
+/// TokenKeyInfo is a key used to sign auth tokens.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct TokenKeyInfo {
+    #[prost(string, tag="1")]
+    pub key_id: ::prost::alloc::string::String,
+    #[prost(bytes="vec", tag="2")]
+    pub secret: ::prost::alloc::vec::Vec<u8>,
+    #[prost(int64, tag="3")]
+    pub enable_time_sec: i64,
+    /// Algorithm is either "hmac-sha256" or "ed25519", for "ed25519",
+    /// secret is the private key seed.
+    #[prost(string, tag="4")]
+    pub algorithm: ::prost::alloc::string::String,
+    #[prost(bytes="vec", tag="5")]
+    pub public_key: ::prost::alloc::vec::Vec<u8>,
+}
+/// TokenPayload is the self-contained payload of version 1 auth tokens.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct TokenPayload {
+    #[prost(string, tag="1")]
+    pub key_id: ::prost::alloc::string::String,
+    #[prost(int64, tag="2")]
+    pub app_id: i64,
+    #[prost(int64, tag="3")]
+    pub user_id: i64,
+    #[prost(int64, tag="4")]
+    pub device_id: i64,
+    #[prost(int64, tag="5")]
+    pub sign_time_msec: i64,
+    #[prost(int64, tag="6")]
+    pub expire_time_msec: i64,
+    #[prost(message, optional, tag="7")]
+    pub claims: ::core::option::Option<super::protocol::TokenClaims>,
+    #[prost(string, tag="8")]
+    pub bind_ip: ::prost::alloc::string::String,
+}
+/// SessionState holds state tied to a connection, it is transferred to the
+/// new connection when a client resumes its session by reconnecting.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct SessionState {
+    #[prost(string, repeated, tag="1")]
+    pub tags: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(map="string, string", tag="2")]
+    pub attributes: ::std::collections::HashMap<::prost::alloc::string::String, ::prost::alloc::string::String>,
+    /// Serialized protocol.Packet which have been sent but not acked.
+    #[prost(bytes="vec", repeated, tag="3")]
+    pub pending_messages: ::prost::alloc::vec::Vec<::prost::alloc::vec::Vec<u8>>,
+    #[prost(int64, tag="4")]
+    pub sync_seq: i64,
+}
+/// GroupInfo is a group of users of an app, eg. a chat group or a live room.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct GroupInfo {
+    #[prost(int64, tag="1")]
+    pub app_id: i64,
+    #[prost(string, tag="2")]
+    pub group_id: ::prost::alloc::string::String,
+    #[prost(string, tag="3")]
+    pub name: ::prost::alloc::string::String,
+    #[prost(int64, tag="4")]
+    pub create_time_msec: i64,
 }
@@ -26,17 +26,59 @@ pub mod downgoing_message {
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct BroadcastMessage {
 }
+/// KickMessage tells a comet server to close the connections, eg. the
+/// connections evicted for exceeding the per-user limit. The broker
+/// reports DISCONNECT events for them, the comet must not report again.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct KickMessage {
+    #[prost(string, repeated, tag="1")]
+    pub conn_ids: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(string, tag="2")]
+    pub reason: ::prost::alloc::string::String,
+}
+/// CometHeartbeat is published by comet servers periodically to register
+/// themselves to the brokers.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct CometHeartbeat {
+    #[prost(string, tag="1")]
+    pub machine_id: ::prost::alloc::string::String,
+    #[prost(string, tag="2")]
+    pub addr: ::prost::alloc::string::String,
+    #[prost(int64, tag="3")]
+    pub start_time_msec: i64,
+    #[prost(int64, tag="4")]
+    pub time_msec: i64,
+    #[prost(int64, tag="5")]
+    pub connections: i64,
+    /// Shutdown tells that the comet server is exiting gracefully.
+    #[prost(bool, tag="6")]
+    pub shutdown: bool,
+}
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct TokenKey {
     #[prost(string, tag="1")]
     pub key: ::prost::alloc::string::String,
     #[prost(int64, tag="2")]
     pub enable_time_sec: i64,
+    #[prost(string, tag="3")]
+    pub key_id: ::prost::alloc::string::String,
+    #[prost(string, tag="4")]
+    pub algorithm: ::prost::alloc::string::String,
 }
+/// CometConfiguration is returned by the broker RPC getCometConfiguration,
+/// and published to comet servers when it changes.
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct CometConfiguration {
+    /// The key currently used to sign tokens, base64 encoded.
+    /// For algorithm "ed25519", it is the public key to verify tokens.
     #[prost(string, tag="1")]
     pub token_key: ::prost::alloc::string::String,
+    /// Keys which are superseded but still valid to verify tokens signed
+    /// before the rotation, and keys scheduled to be enabled in future.
     #[prost(message, repeated, tag="2")]
     pub old_token_keys: ::prost::alloc::vec::Vec<TokenKey>,
+    #[prost(string, tag="3")]
+    pub token_key_id: ::prost::alloc::string::String,
+    #[prost(string, tag="4")]
+    pub token_key_algorithm: ::prost::alloc::string::String,
 }
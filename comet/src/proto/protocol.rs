@@ -36,6 +36,65 @@ pub struct Connection {
     pub client_ip: ::prost::alloc::string::String,
     #[prost(string, tag="12")]
     pub client_version: ::prost::alloc::string::String,
+    #[prost(message, optional, tag="13")]
+    pub claims: ::core::option::Option<TokenClaims>,    /// Reported by the client when connecting, platform and locale default
+    /// to the ones in claims, attributes are merged with claims.extra.
+    #[prost(string, tag="14")]
+    pub platform: ::prost::alloc::string::String,
+    #[prost(string, tag="15")]
+    pub os: ::prost::alloc::string::String,
+    #[prost(string, tag="16")]
+    pub locale: ::prost::alloc::string::String,
+    #[prost(string, tag="17")]
+    pub network_type: ::prost::alloc::string::String,
+    #[prost(map="string, string", tag="18")]
+    pub attributes: ::std::collections::HashMap<::prost::alloc::string::String, ::prost::alloc::string::String>,
+    /// Filled by broker.
+    #[prost(string, tag="19")]
+    pub machine_id: ::prost::alloc::string::String,
+    #[prost(int64, tag="20")]
+    pub connect_time_msec: i64,
+    #[prost(int64, tag="21")]
+    pub last_active_time_msec: i64,
+}
+/// TokenClaims are custom claims given by the business service when
+/// signing an auth token, they are attached to the connection
+/// authenticated by the token.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct TokenClaims {
+    #[prost(string, repeated, tag="1")]
+    pub tags: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(string, repeated, tag="2")]
+    pub roles: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    #[prost(string, tag="3")]
+    pub locale: ::prost::alloc::string::String,
+    #[prost(string, tag="4")]
+    pub platform: ::prost::alloc::string::String,
+    #[prost(map="string, string", tag="5")]
+    pub extra: ::std::collections::HashMap<::prost::alloc::string::String, ::prost::alloc::string::String>,
+}
+/// ConnectionDetail is the live state of a connection kept by the comet.
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ConnectionDetail {
+    #[prost(message, optional, tag="1")]
+    pub conn: ::core::option::Option<Connection>,
+    #[prost(string, tag="2")]
+    pub remote_addr: ::prost::alloc::string::String,
+    #[prost(int64, tag="3")]
+    pub connect_time_msec: i64,
+    #[prost(int64, tag="4")]
+    pub last_active_time_msec: i64,
+    #[prost(int64, tag="5")]
+    pub bytes_in: i64,
+    #[prost(int64, tag="6")]
+    pub bytes_out: i64,
+    #[prost(int64, tag="7")]
+    pub packets_in: i64,
+    #[prost(int64, tag="8")]
+    pub packets_out: i64,
+    /// Number of downgoing packets waiting to be sent.
+    #[prost(int32, tag="9")]
+    pub pending_queue_length: i32,
 }
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct ConnectionList {
@@ -59,6 +118,8 @@ pub struct Event {
     pub r#type: i32,
     #[prost(message, optional, tag="6")]
     pub reconnect_data: ::core::option::Option<event::ReconnectData>,
+    #[prost(message, optional, tag="7")]
+    pub disconnect_data: ::core::option::Option<event::DisconnectData>,
 }
 /// Nested message and enum types in `Event`.
 pub mod event {
@@ -67,6 +128,14 @@ pub mod event {
         #[prost(string, tag="1")]
         pub old_id: ::prost::alloc::string::String,
     }
+    #[derive(Clone, PartialEq, ::prost::Message)]
+    pub struct DisconnectData {
+        /// reason is empty if the connection is closed by comet server
+        /// normally, or "timeout" if it is removed from the routing table
+        /// by broker for not being touched in time.
+        #[prost(string, tag="1")]
+        pub reason: ::prost::alloc::string::String,
+    }
     #[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
     #[repr(i32)]
     pub enum Type {
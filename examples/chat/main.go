@@ -264,6 +264,12 @@ func (p *RpcImpl) OnEvent(ctx context.Context, request *bizapi.OnEventRequest) (
 	case protocol.Event_CONNECT:
 		p.chat.lg.Infow("onEvent: registering connection", "uid", uid)
 		p.register(event.GetConn())
+	case protocol.Event_RECONNECT:
+		oldUid := event.GetReconnectData().GetOldId()
+		p.chat.lg.Infow("onEvent: resuming connection", "uid", uid, "oldUid", oldUid)
+		if !p.chat.Resume(oldUid, uid) {
+			p.register(event.GetConn())
+		}
 	case protocol.Event_DISCONNECT:
 		p.chat.lg.Infow("onEvent: removing connection", "uid", uid)
 		p.remove(event.GetConn())
@@ -273,6 +279,26 @@ func (p *RpcImpl) OnEvent(ctx context.Context, request *bizapi.OnEventRequest) (
 	return &bizapi.OnEventResponse{}, nil
 }
 
+func (p *RpcImpl) OnMessageBatch(ctx context.Context, request *bizapi.OnMessageBatchRequest) (*bizapi.OnMessageBatchResponse, error) {
+	for _, message := range request.GetMessages() {
+		_, err := p.OnMessage(ctx, &bizapi.OnMessageRequest{Message: message})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &bizapi.OnMessageBatchResponse{}, nil
+}
+
+func (p *RpcImpl) OnEventBatch(ctx context.Context, request *bizapi.OnEventBatchRequest) (*bizapi.OnEventBatchResponse, error) {
+	for _, event := range request.GetEvents() {
+		_, err := p.OnEvent(ctx, &bizapi.OnEventRequest{Event: event})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &bizapi.OnEventBatchResponse{}, nil
+}
+
 func (p *RpcImpl) register(conn *protocol.Connection) *User {
 	uid := conn.GetId()
 	user, ok := p.chat.GetUser(uid)
@@ -451,6 +477,21 @@ func (c *Chat) Register(conn *protocol.Connection) *User {
 	return user
 }
 
+// Resume binds the user of a previous connection to a new connection,
+// it returns false if the previous connection is not found.
+func (c *Chat) Resume(oldUid, uid string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	user, ok := c.ids[oldUid]
+	if !ok {
+		return false
+	}
+	delete(c.ids, oldUid)
+	user.uid = uid
+	c.ids[uid] = user
+	return true
+}
+
 // Remove removes user from chat.
 func (c *Chat) Remove(user *User) {
 	c.mu.Lock()
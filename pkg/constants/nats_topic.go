@@ -9,12 +9,18 @@ const (
 
 	UpgoingMessageTopic = "broker.upgoingMessage"
 	EventTopic          = "broker.event"
+	CometHeartbeatTopic = "broker.cometHeartbeat"
+)
+
+const (
+	CometConfigurationTopic = "comet.configuration"
 )
 
 const (
 	cometRpcGetConnectionInfo = "cometRpc.%s.getConnectionInfo"
 
 	cometDowngoingMessageTopic = "comet.%s.downgoingMessage"
+	cometKickTopic             = "comet.%s.kick"
 )
 
 func CometRpcGetConnectionInfoTopic(machineId string) string {
@@ -25,6 +31,10 @@ func CometDowngoingMessageTopic(machineId string) string {
 	return fmt.Sprintf(cometDowngoingMessageTopic, machineId)
 }
 
+func CometKickTopic(machineId string) string {
+	return fmt.Sprintf(cometKickTopic, machineId)
+}
+
 const (
 	BrokerGroup = "brokerGroup"
 )
@@ -2,9 +2,45 @@ package errcode
 
 import "github.com/jxskiss/gopkg/errcode"
 
-var reg = errcode.New()
+var (
+	reg   = errcode.New()
+	codes = make(map[int32]*errcode.Code)
+)
+
+var (
+	IllegalAuthToken    = register(100_001, "illegal auth token")
+	UnknownTokenVersion = register(100_002, "unknown token version")
+	ExpiredAuthToken    = register(100_003, "auth token expired")
+	RevokedAuthToken    = register(100_004, "auth token revoked")
+	InvalidAuthToken    = register(100_005, "invalid auth token")
+	TokenIpMismatch     = register(100_006, "auth token bound to another IP")
+	ClientBlocked       = register(100_007, "client blocked")
+	InvalidBindIp       = register(100_008, "invalid bind IP")
+)
 
 var (
-	IllegalAuthToken    = reg.Register(100_001, "illegal auth token")
-	UnknownTokenVersion = reg.Register(100_002, "unknown token version")
+	ConnectionNotFound = register(110_001, "connection not found")
+	InvalidPushTarget  = register(110_002, "invalid push target")
+	GroupNotFound      = register(110_003, "group not found")
+	GroupExists        = register(110_004, "group already exists")
+	InvalidGroup       = register(110_005, "invalid group request")
 )
+
+var (
+	RpcBadRequest    = register(200_001, "bad rpc request")
+	RpcUnknownMethod = register(200_002, "unknown rpc method")
+	RpcTimeout       = register(200_003, "rpc timeout")
+	RpcInternalError = register(200_004, "rpc internal error")
+)
+
+func register(code int32, msg string) *errcode.Code {
+	c := reg.Register(code, msg)
+	codes[code] = c
+	return c
+}
+
+// Lookup returns the registered error code, it returns nil if code
+// is not registered.
+func Lookup(code int32) *errcode.Code {
+	return codes[code]
+}
@@ -0,0 +1,544 @@
+// Package filterexpr implements boolean expressions to filter items by
+// their attributes, eg.
+//
+//	platform == "ios" && version >= "3.1" && locale in ["en", "de"]
+//
+// Supported operators by precedence from low to high:
+//
+//	||
+//	&&
+//	!
+//	== != < <= > >= in
+//
+// Operands are variables, string, number and boolean literals, and lists
+// of literals. Strings consisting of dot separated numbers are compared
+// as versions, eg. "3.10" > "3.9". Variables are resolved by the caller,
+// they can be strings, numbers, booleans or string lists.
+//
+// Evaluation never fails, comparing values of different types is false.
+package filterexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxLength is the max length of an expression.
+	MaxLength = 4096
+	// MaxDepth is the max nesting depth of an expression.
+	MaxDepth = 32
+)
+
+// Vars tells whether a variable name is valid.
+type Vars func(name string) bool
+
+// Env resolves a variable to a string, float64, bool or []string value,
+// unknown variables resolve to nil.
+type Env func(name string) interface{}
+
+// Expr is a compiled expression, it is safe for concurrent use.
+type Expr struct {
+	src  string
+	root node
+}
+
+// Compile parses an expression, variables are validated by vars.
+func Compile(src string, vars Vars) (*Expr, error) {
+	if len(src) > MaxLength {
+		return nil, fmt.Errorf("filterexpr: expression too long, max length is %d", MaxLength)
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, vars: vars}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string { return e.src }
+
+// Eval evaluates the expression with variables resolved by env.
+func (e *Expr) Eval(env Env) bool {
+	return truthy(e.root.eval(env))
+}
+
+// ---- lexer ---- //
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	str  string  // value of tokString
+	num  float64 // value of tokNumber
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(src) && (isIdentStart(src[j]) || isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[i:j], pos: i})
+			i = j
+		case isDigit(c) || (c == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			j := i + 1
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			num, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("filterexpr: invalid number %q at %d", src[i:j], i)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[i:j], pos: i, num: num})
+			i = j
+		case c == '"' || c == '\'':
+			str, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, fmt.Errorf("filterexpr: invalid string at %d: %v", i, err)
+			}
+			tokens = append(tokens, token{kind: tokString, text: src[i : i+n], pos: i, str: str})
+			i += n
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("filterexpr: unexpected character %q at %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, text: "end of expression", pos: len(src)})
+	return tokens, nil
+}
+
+// lexString reads a quoted string with backslash escapes, it returns
+// the unquoted string and the length of the quoted one.
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\':
+			if i+1 >= len(s) {
+				return "", 0, fmt.Errorf("unterminated escape")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '\\', '"', '\'':
+				b.WriteByte(s[i])
+			default:
+				return "", 0, fmt.Errorf("unknown escape \\%c", s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// ---- parser ---- //
+
+type parser struct {
+	tokens []token
+	pos    int
+	vars   Vars
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isOp(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && tok.text == text
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return fmt.Errorf("filterexpr: %s at %d", fmt.Sprintf(format, args...), tok.pos)
+}
+
+func (p *parser) checkDepth(depth int) error {
+	if depth > MaxDepth {
+		return p.errorf(p.peek(), "expression nested too deep")
+	}
+	return nil
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	if err := p.checkDepth(depth); err != nil {
+		return nil, err
+	}
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		p.next()
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot(depth int) (node, error) {
+	if p.isOp("!") {
+		p.next()
+		if err := p.checkDepth(depth + 1); err != nil {
+			return nil, err
+		}
+		x, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{x: x}, nil
+	}
+	return p.parseCompare(depth)
+}
+
+func (p *parser) parseCompare(depth int) (node, error) {
+	left, err := p.parseOperand(depth)
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	switch {
+	case tok.kind == tokOp && isCompareOp(tok.text):
+		p.next()
+		right, err := p.parseOperand(depth)
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: tok.text, left: left, right: right}, nil
+	case tok.kind == tokIdent && tok.text == "in":
+		p.next()
+		right, err := p.parseOperand(depth)
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func isCompareOp(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOperand(depth int) (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &literalNode{value: tok.str}, nil
+	case tokNumber:
+		return &literalNode{value: tok.num}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "in":
+			return nil, p.errorf(tok, "unexpected %q", tok.text)
+		}
+		if p.vars != nil && !p.vars(tok.text) {
+			return nil, p.errorf(tok, "unknown variable %q", tok.text)
+		}
+		return &varNode{name: tok.text}, nil
+	case tokOp:
+		switch tok.text {
+		case "(":
+			x, err := p.parseOr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if !p.isOp(")") {
+				return nil, p.errorf(p.peek(), "expecting \")\"")
+			}
+			p.next()
+			return x, nil
+		case "[":
+			return p.parseList()
+		}
+	}
+	return nil, p.errorf(tok, "unexpected %q", tok.text)
+}
+
+func (p *parser) parseList() (node, error) {
+	var values []interface{}
+	for !p.isOp("]") {
+		if len(values) > 0 {
+			if !p.isOp(",") {
+				return nil, p.errorf(p.peek(), "expecting \",\" or \"]\"")
+			}
+			p.next()
+		}
+		tok := p.next()
+		switch tok.kind {
+		case tokString:
+			values = append(values, tok.str)
+		case tokNumber:
+			values = append(values, tok.num)
+		default:
+			return nil, p.errorf(tok, "expecting string or number in list")
+		}
+	}
+	p.next()
+	return &literalNode{value: values}, nil
+}
+
+// ---- evaluation ---- //
+
+type node interface {
+	eval(env Env) interface{}
+}
+
+type literalNode struct{ value interface{} }
+
+type varNode struct{ name string }
+
+type notNode struct{ x node }
+
+type andNode struct{ left, right node }
+
+type orNode struct{ left, right node }
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+type inNode struct{ left, right node }
+
+func (n *literalNode) eval(env Env) interface{} { return n.value }
+
+func (n *varNode) eval(env Env) interface{} {
+	if env == nil {
+		return nil
+	}
+	return env(n.name)
+}
+
+func (n *notNode) eval(env Env) interface{} { return !truthy(n.x.eval(env)) }
+
+func (n *andNode) eval(env Env) interface{} {
+	return truthy(n.left.eval(env)) && truthy(n.right.eval(env))
+}
+
+func (n *orNode) eval(env Env) interface{} {
+	return truthy(n.left.eval(env)) || truthy(n.right.eval(env))
+}
+
+func (n *compareNode) eval(env Env) interface{} {
+	left, right := n.left.eval(env), n.right.eval(env)
+	switch n.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+	c, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+func (n *inNode) eval(env Env) interface{} {
+	left := n.left.eval(env)
+	switch list := n.right.eval(env).(type) {
+	case []interface{}:
+		for _, v := range list {
+			if equal(left, v) {
+				return true
+			}
+		}
+	case []string:
+		for _, v := range list {
+			if equal(left, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func truthy(v interface{}) bool {
+	switch x := v.(type) {
+	case bool:
+		return x
+	case string:
+		return x != ""
+	case float64:
+		return x != 0
+	case []string:
+		return len(x) > 0
+	case []interface{}:
+		return len(x) > 0
+	}
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case float64:
+		y, ok := b.(float64)
+		return ok && x == y
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	}
+	return false
+}
+
+// compare compares numbers, versions or strings, ok is false if the
+// values are not comparable.
+func compare(a, b interface{}) (c int, ok bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		if isVersion(x) && isVersion(y) {
+			return compareVersions(x, y), true
+		}
+		return strings.Compare(x, y), true
+	}
+	return 0, false
+}
+
+func isVersion(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) && s[i] != '.' {
+			return false
+		}
+	}
+	return !strings.Contains(s, "..")
+}
+
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y uint64
+		if i < len(as) {
+			x, _ = strconv.ParseUint(as[i], 10, 64)
+		}
+		if i < len(bs) {
+			y, _ = strconv.ParseUint(bs[i], 10, 64)
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
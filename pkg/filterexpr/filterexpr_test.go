@@ -0,0 +1,69 @@
+package filterexpr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEnv(vars map[string]interface{}) Env {
+	return func(name string) interface{} { return vars[name] }
+}
+
+func TestEval(t *testing.T) {
+	env := testEnv(map[string]interface{}{
+		"platform":    "ios",
+		"version":     "3.10.2",
+		"locale":      "de",
+		"user_id":     float64(123),
+		"tags":        []string{"vip", "beta"},
+		"attr.region": "eu",
+	})
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{`platform == "ios" && version >= "3.1" && locale in ["en","de"]`, true},
+		{`platform == 'android' || version < "3.9"`, false},
+		{`version > "3.9"`, true},
+		{`version == "3.10.2"`, true},
+		{`"vip" in tags && !("svip" in tags)`, true},
+		{`user_id >= 100 && user_id in [1, 123]`, true},
+		{`user_id == "123"`, false},
+		{`attr.region != "us"`, true},
+		{`unknown == ""`, false},
+		{`!unknown`, true},
+		{`locale > "ca" && locale <= "de"`, true},
+		{`(platform == "ios" || platform == "android") && !(locale in [])`, true},
+		{`true && !false`, true},
+	}
+	for _, c := range cases {
+		expr, err := Compile(c.expr, nil)
+		require.Nil(t, err, c.expr)
+		assert.Equal(t, c.want, expr.Eval(env), c.expr)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	vars := func(name string) bool { return name == "platform" }
+	for _, src := range []string{
+		``,
+		`platform ==`,
+		`platform == "ios" &&`,
+		`(platform == "ios"`,
+		`platform in ["ios",]`,
+		`platform in [platform]`,
+		`platform == "ios`,
+		`platform = "ios"`,
+		`os == "ios"`,
+		`platform == "ios" platform`,
+		strings.Repeat("(", MaxDepth+1) + "platform" + strings.Repeat(")", MaxDepth+1),
+		strings.Repeat("!", MaxDepth+1) + "platform",
+		`platform == "` + strings.Repeat("x", MaxLength) + `"`,
+	} {
+		_, err := Compile(src, vars)
+		assert.NotNil(t, err, src)
+	}
+}
@@ -13,6 +13,17 @@ func ToProtocolConnection(conn *data.ConnectionInfo) *protocol.Connection {
 		DeviceId:      conn.DeviceId,
 		ClientIp:      conn.ClientIp,
 		ClientVersion: conn.ClientVersion,
+		Claims:        conn.Claims,
+
+		Platform:    conn.Platform,
+		Os:          conn.Os,
+		Locale:      conn.Locale,
+		NetworkType: conn.NetworkType,
+		Attributes:  conn.Attributes,
+
+		MachineId:          conn.MachineId,
+		ConnectTimeMsec:    conn.ConnectTimeMsec,
+		LastActiveTimeMsec: conn.LastActiveTimeMsec,
 	}
 }
 
@@ -25,3 +36,25 @@ func ToProtocolConnectionList(conns []*data.ConnectionInfo) *protocol.Connection
 	}
 	return out
 }
+
+func FromProtocolConnection(conn *protocol.Connection) *data.ConnectionInfo {
+	return &data.ConnectionInfo{
+		Id:            conn.Id,
+		AppId:         conn.AppId,
+		UserId:        conn.UserId,
+		DeviceId:      conn.DeviceId,
+		ClientIp:      conn.ClientIp,
+		ClientVersion: conn.ClientVersion,
+		Claims:        conn.Claims,
+
+		Platform:    conn.Platform,
+		Os:          conn.Os,
+		Locale:      conn.Locale,
+		NetworkType: conn.NetworkType,
+		Attributes:  conn.Attributes,
+
+		MachineId:          conn.MachineId,
+		ConnectTimeMsec:    conn.ConnectTimeMsec,
+		LastActiveTimeMsec: conn.LastActiveTimeMsec,
+	}
+}
@@ -0,0 +1,82 @@
+package natsrpc
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+	"github.com/nats-io/nats.go"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+)
+
+// NewClient creates a Client which sends requests wrapped in reqEnvelope
+// and receives responses wrapped in respEnvelope.
+func NewClient(conn *nats.Conn, reqEnvelope, respEnvelope proto.Message) *Client {
+	return &Client{
+		Timeout:  DefaultTimeout,
+		request:  conn.RequestWithContext,
+		reqType:  newEnvelopeType(reqEnvelope, false),
+		respType: newEnvelopeType(respEnvelope, true),
+	}
+}
+
+// Client calls RPCs served by Server.
+type Client struct {
+	// Timeout applies if ctx of a call has no deadline or the
+	// deadline is later than Timeout.
+	Timeout time.Duration
+
+	request  func(ctx context.Context, subject string, data []byte) (*nats.Msg, error)
+	reqType  *envelopeType
+	respType *envelopeType
+}
+
+// Call sends req to subject and waits for the response to be decoded
+// into resp. An error replied by the server is returned as the
+// registered error code if it is known, eg. errcode.RpcTimeout.
+func (c *Client) Call(ctx context.Context, subject string, req, resp proto.Message) error {
+	data, err := c.encode(req)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+	msg, err := c.request(ctx, subject, data)
+	if err != nil {
+		if err == context.DeadlineExceeded || err == nats.ErrTimeout {
+			return errors.AddStack(errcode.RpcTimeout)
+		}
+		return errors.AddStack(err)
+	}
+	return c.decode(msg.Data, resp)
+}
+
+func (c *Client) encode(req proto.Message) ([]byte, error) {
+	env, err := c.reqType.wrap(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(env)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return data, nil
+}
+
+func (c *Client) decode(data []byte, resp proto.Message) error {
+	env, inner, err := c.respType.decode(data)
+	if err != nil {
+		return err
+	}
+	if rpcErr := c.respType.getError(env); rpcErr != nil {
+		return fromRpcError(rpcErr)
+	}
+	if inner == nil || reflect.TypeOf(inner) != reflect.TypeOf(resp) {
+		return errors.Errorf("natsrpc: unexpected response type %T", inner)
+	}
+	proto.Merge(resp, inner)
+	return nil
+}
@@ -0,0 +1,31 @@
+package natsrpc
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/jxskiss/nonamegw/pkg/constants"
+	"github.com/jxskiss/nonamegw/proto/cometsvc"
+)
+
+// NewCometClient creates a client to call RPCs served by comet servers,
+// requests are sent to the subjects cometRpc.{machineId}.*.
+func NewCometClient(conn *nats.Conn) *CometClient {
+	return &CometClient{
+		Client: NewClient(conn, &cometsvc.CometRequest{}, &cometsvc.CometResponse{}),
+	}
+}
+
+type CometClient struct {
+	*Client
+}
+
+func (c *CometClient) GetConnectionInfo(ctx context.Context, machineId string, req *cometsvc.GetConnectionInfoRequest) (*cometsvc.GetConnectionInfoResponse, error) {
+	resp := &cometsvc.GetConnectionInfoResponse{}
+	err := c.Call(ctx, constants.CometRpcGetConnectionInfoTopic(machineId), req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package natsrpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jxskiss/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/pkg/constants"
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/cometsvc"
+	"github.com/jxskiss/nonamegw/proto/protocol"
+)
+
+// newFakeComet serves GetConnectionInfo as the comet of machineId does,
+// requests of the returned client are routed to comets by subject.
+func newFakeComet(machineId string, conns map[string]*protocol.Connection) (*Server, *CometClient) {
+	comet := NewServer(nil, &cometsvc.CometRequest{}, &cometsvc.CometResponse{})
+	comet.Register(constants.CometRpcGetConnectionInfoTopic(machineId),
+		func(ctx context.Context, req *cometsvc.GetConnectionInfoRequest) (*cometsvc.GetConnectionInfoResponse, error) {
+			conn := conns[req.ConnId]
+			if conn == nil {
+				return nil, errors.AddStack(errcode.ConnectionNotFound)
+			}
+			return &cometsvc.GetConnectionInfoResponse{
+				Detail: &protocol.ConnectionDetail{Conn: conn, RemoteAddr: "10.0.0.1:5678"},
+			}, nil
+		})
+	client := NewCometClient(nil)
+	client.request = func(ctx context.Context, subject string, data []byte) (*nats.Msg, error) {
+		if !strings.HasPrefix(subject, "cometRpc."+machineId+".") {
+			return nil, nats.ErrTimeout
+		}
+		return &nats.Msg{Subject: subject, Data: comet.Handle(ctx, subject, data)}, nil
+	}
+	return comet, client
+}
+
+func TestCometClientGetConnectionInfo(t *testing.T) {
+	ctx := context.Background()
+	conns := map[string]*protocol.Connection{
+		"conn1": {Id: "conn1", AppId: 1001, UserId: 123},
+	}
+	_, client := newFakeComet("m1", conns)
+
+	resp, err := client.GetConnectionInfo(ctx, "m1", &cometsvc.GetConnectionInfoRequest{ConnId: "conn1"})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1001), resp.GetDetail().GetConn().GetAppId())
+	assert.Equal(t, "10.0.0.1:5678", resp.GetDetail().GetRemoteAddr())
+
+	_, err = client.GetConnectionInfo(ctx, "m1", &cometsvc.GetConnectionInfoRequest{ConnId: "conn2"})
+	assert.Equal(t, errcode.ConnectionNotFound, errors.Cause(err))
+
+	_, err = client.GetConnectionInfo(ctx, "m2", &cometsvc.GetConnectionInfoRequest{ConnId: "conn1"})
+	assert.Equal(t, errcode.RpcTimeout, errors.Cause(err))
+}
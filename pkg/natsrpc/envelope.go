@@ -0,0 +1,111 @@
+package natsrpc
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/cometsvc"
+)
+
+// Requests and responses are sent in envelope messages, eg.
+// cometsvc.BrokerRequest and cometsvc.BrokerResponse, the concrete
+// message is wrapped in the only oneof field of the envelope.
+// A response envelope also has a field "error" of type cometsvc.RpcError.
+
+const envelopeErrorField = "error"
+
+type envelopeType struct {
+	msgType protoreflect.MessageType
+	oneof   protoreflect.OneofDescriptor
+	errFd   protoreflect.FieldDescriptor
+}
+
+func newEnvelopeType(envelope proto.Message, isResponse bool) *envelopeType {
+	msg := proto.MessageReflect(envelope)
+	desc := msg.Descriptor()
+	if desc.Oneofs().Len() != 1 {
+		panic("natsrpc: envelope must have exactly one oneof field: " + string(desc.FullName()))
+	}
+	typ := &envelopeType{
+		msgType: msg.Type(),
+		oneof:   desc.Oneofs().Get(0),
+	}
+	if isResponse {
+		typ.errFd = desc.Fields().ByName(envelopeErrorField)
+		if typ.errFd == nil {
+			panic("natsrpc: response envelope has no error field: " + string(desc.FullName()))
+		}
+	}
+	return typ
+}
+
+// field returns the oneof field of which the message type is msgName.
+func (t *envelopeType) field(msgName protoreflect.FullName) protoreflect.FieldDescriptor {
+	fields := t.oneof.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() != nil && fd.Message().FullName() == msgName {
+			return fd
+		}
+	}
+	return nil
+}
+
+func (t *envelopeType) wrap(msg proto.Message) (proto.Message, error) {
+	inner := proto.MessageReflect(msg)
+	fd := t.field(inner.Descriptor().FullName())
+	if fd == nil {
+		return nil, errors.Errorf("natsrpc: message %v not found in envelope %v",
+			inner.Descriptor().FullName(), t.msgType.Descriptor().FullName())
+	}
+	env := t.msgType.New()
+	env.Set(fd, protoreflect.ValueOfMessage(inner))
+	return proto.MessageV1(env.Interface()), nil
+}
+
+func (t *envelopeType) wrapError(rpcErr *cometsvc.RpcError) proto.Message {
+	env := t.msgType.New()
+	env.Set(t.errFd, protoreflect.ValueOfMessage(proto.MessageReflect(rpcErr)))
+	return proto.MessageV1(env.Interface())
+}
+
+func (t *envelopeType) decode(data []byte) (env protoreflect.Message, inner proto.Message, err error) {
+	env = t.msgType.New()
+	err = proto.Unmarshal(data, proto.MessageV1(env.Interface()))
+	if err != nil {
+		return nil, nil, errors.AddStack(err)
+	}
+	if fd := env.WhichOneof(t.oneof); fd != nil {
+		inner = proto.MessageV1(env.Get(fd).Message().Interface())
+	}
+	return env, inner, nil
+}
+
+func (t *envelopeType) getError(env protoreflect.Message) *cometsvc.RpcError {
+	if !env.Has(t.errFd) {
+		return nil
+	}
+	return proto.MessageV1(env.Get(t.errFd).Message().Interface()).(*cometsvc.RpcError)
+}
+
+func toRpcError(err error) *cometsvc.RpcError {
+	if code, ok := errors.Cause(err).(interface {
+		Code() int32
+		Message() string
+	}); ok {
+		return &cometsvc.RpcError{Code: code.Code(), Message: code.Message()}
+	}
+	return &cometsvc.RpcError{
+		Code:    errcode.RpcInternalError.Code(),
+		Message: err.Error(),
+	}
+}
+
+func fromRpcError(rpcErr *cometsvc.RpcError) error {
+	if code := errcode.Lookup(rpcErr.Code); code != nil {
+		return errors.AddStack(code)
+	}
+	return errors.Errorf("rpc error [%d] %s", rpcErr.Code, rpcErr.Message)
+}
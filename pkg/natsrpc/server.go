@@ -0,0 +1,175 @@
+package natsrpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jxskiss/errors"
+	"github.com/nats-io/nats.go"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/pkg/zlog"
+)
+
+const DefaultTimeout = 3 * time.Second
+
+var (
+	ctxType   = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	protoType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+)
+
+// NewServer creates a Server which accepts requests wrapped in
+// reqEnvelope and replies responses wrapped in respEnvelope.
+func NewServer(conn *nats.Conn, reqEnvelope, respEnvelope proto.Message) *Server {
+	return &Server{
+		Timeout:  DefaultTimeout,
+		conn:     conn,
+		reqType:  newEnvelopeType(reqEnvelope, false),
+		respType: newEnvelopeType(respEnvelope, true),
+		handlers: make(map[string]*handler),
+	}
+}
+
+// Server dispatches requests to handlers registered by subject.
+//
+// A handler is called with a context which is canceled after Timeout,
+// if the handler does not return in time, errcode.RpcTimeout is replied.
+// Panics in handlers are recovered and replied as errcode.RpcInternalError.
+type Server struct {
+	Timeout time.Duration
+
+	conn     *nats.Conn
+	reqType  *envelopeType
+	respType *envelopeType
+	handlers map[string]*handler
+}
+
+type handler struct {
+	fn      reflect.Value
+	reqType reflect.Type
+}
+
+// Register registers a typed handler for subject, handler must be
+// a function of the signature:
+//
+//	func(ctx context.Context, req *ReqType) (*RespType, error)
+//
+// where ReqType and RespType are message types in the oneof fields
+// of the request and response envelopes.
+// Register must be called before Subscribe.
+func (s *Server) Register(subject string, handlerFunc interface{}) {
+	fn := reflect.ValueOf(handlerFunc)
+	ft := fn.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 2 || ft.NumOut() != 2 ||
+		ft.In(0) != ctxType || !ft.In(1).Implements(protoType) ||
+		!ft.Out(0).Implements(protoType) || ft.Out(1) != errorType {
+		panic(fmt.Sprintf("natsrpc: invalid handler type %v for subject %s", ft, subject))
+	}
+	reqName := reflect.Zero(ft.In(1)).Interface().(proto.Message)
+	if s.reqType.field(proto.MessageReflect(reqName).Descriptor().FullName()) == nil {
+		panic(fmt.Sprintf("natsrpc: request type %v not found in envelope", ft.In(1)))
+	}
+	respName := reflect.Zero(ft.Out(0)).Interface().(proto.Message)
+	if s.respType.field(proto.MessageReflect(respName).Descriptor().FullName()) == nil {
+		panic(fmt.Sprintf("natsrpc: response type %v not found in envelope", ft.Out(0)))
+	}
+	s.handlers[subject] = &handler{fn: fn, reqType: ft.In(1)}
+}
+
+// Subscribe subscribes subject with queue group, subject may be
+// a wildcard subject matching all registered subjects.
+func (s *Server) Subscribe(subject, queue string) (*nats.Subscription, error) {
+	sub, err := s.conn.QueueSubscribe(subject, queue, s.serveMsg)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return sub, nil
+}
+
+func (s *Server) serveMsg(msg *nats.Msg) {
+	if msg.Reply == "" {
+		zlog.Warnf("natsrpc: dropped request without reply subject, subject= %v", msg.Subject)
+		return
+	}
+	out := s.Handle(context.Background(), msg.Subject, msg.Data)
+	err := s.conn.Publish(msg.Reply, out)
+	if err != nil {
+		zlog.Errorf("natsrpc: failed publish response, subject= %v, err= %v", msg.Subject, err)
+	}
+}
+
+// Handle dispatches a request received from subject, it returns the
+// encoded response envelope.
+func (s *Server) Handle(ctx context.Context, subject string, data []byte) []byte {
+	var resp proto.Message
+	h := s.handlers[subject]
+	if h == nil {
+		zlog.Warnf("natsrpc: unknown subject %v", subject)
+		resp = s.respType.wrapError(toRpcError(errcode.RpcUnknownMethod))
+	} else {
+		resp = s.call(ctx, subject, h, data)
+	}
+	out, err := proto.Marshal(resp)
+	if err != nil {
+		zlog.Errorf("natsrpc: failed marshal response, subject= %v, err= %v", subject, err)
+		out, _ = proto.Marshal(s.respType.wrapError(toRpcError(err)))
+	}
+	return out
+}
+
+func (s *Server) call(ctx context.Context, subject string, h *handler, data []byte) proto.Message {
+	_, req, err := s.reqType.decode(data)
+	if err != nil || req == nil || reflect.TypeOf(req) != h.reqType {
+		zlog.Warnf("natsrpc: bad request, subject= %v, err= %v", subject, err)
+		return s.respType.wrapError(toRpcError(errcode.RpcBadRequest))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	type result struct {
+		resp proto.Message
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				zlog.Errorf("natsrpc: handler panics, subject= %v, err= %v\n%s", subject, r, debug.Stack())
+				done <- result{err: errors.AddStack(errcode.RpcInternalError)}
+			}
+		}()
+		out := h.fn.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(req)})
+		var r result
+		if !out[1].IsNil() {
+			r.err = out[1].Interface().(error)
+		} else if !out[0].IsNil() {
+			r.resp = out[0].Interface().(proto.Message)
+		}
+		done <- r
+	}()
+
+	var r result
+	select {
+	case r = <-done:
+	case <-ctx.Done():
+		zlog.Warnf("natsrpc: handler timeout, subject= %v", subject)
+		r.err = errors.AddStack(errcode.RpcTimeout)
+	}
+	if r.err != nil {
+		return s.respType.wrapError(toRpcError(r.err))
+	}
+	if r.resp == nil {
+		r.resp = reflect.New(h.fn.Type().Out(0).Elem()).Interface().(proto.Message)
+	}
+	resp, err := s.respType.wrap(r.resp)
+	if err != nil {
+		return s.respType.wrapError(toRpcError(err))
+	}
+	return resp
+}
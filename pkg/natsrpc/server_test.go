@@ -0,0 +1,86 @@
+package natsrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jxskiss/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jxskiss/nonamegw/pkg/errcode"
+	"github.com/jxskiss/nonamegw/proto/cometsvc"
+)
+
+func newTestServerClient() (*Server, *Client) {
+	server := NewServer(nil, &cometsvc.BrokerRequest{}, &cometsvc.BrokerResponse{})
+	server.Timeout = 50 * time.Millisecond
+	client := NewClient(nil, &cometsvc.BrokerRequest{}, &cometsvc.BrokerResponse{})
+	return server, client
+}
+
+func TestServerHandle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestServerClient()
+	server.Register("verify", func(ctx context.Context, req *cometsvc.VerifyAuthTokenRequest) (*cometsvc.VerifyAuthTokenResponse, error) {
+		switch req.Token {
+		case "expired":
+			return nil, errors.AddStack(errcode.ExpiredAuthToken)
+		case "panic":
+			panic("test panic")
+		case "slow":
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+		case "unknown":
+			return nil, errors.New("some error")
+		}
+		return &cometsvc.VerifyAuthTokenResponse{Token: &cometsvc.AuthToken{Token: req.Token}}, nil
+	})
+
+	call := func(subject, token string) (*cometsvc.VerifyAuthTokenResponse, error) {
+		data, err := client.encode(&cometsvc.VerifyAuthTokenRequest{Token: token})
+		require.Nil(t, err)
+		resp := &cometsvc.VerifyAuthTokenResponse{}
+		err = client.decode(server.Handle(ctx, subject, data), resp)
+		return resp, err
+	}
+
+	resp, err := call("verify", "abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", resp.GetToken().GetToken())
+
+	_, err = call("verify", "expired")
+	assert.Equal(t, errcode.ExpiredAuthToken, errors.Cause(err))
+
+	_, err = call("verify", "panic")
+	assert.Equal(t, errcode.RpcInternalError, errors.Cause(err))
+
+	_, err = call("verify", "slow")
+	assert.Equal(t, errcode.RpcTimeout, errors.Cause(err))
+
+	_, err = call("verify", "unknown")
+	assert.Equal(t, errcode.RpcInternalError, errors.Cause(err))
+
+	_, err = call("unknownSubject", "abc")
+	assert.Equal(t, errcode.RpcUnknownMethod, errors.Cause(err))
+
+	data, err := client.encode(&cometsvc.GetCometConfigurationRequest{})
+	require.Nil(t, err)
+	err = client.decode(server.Handle(ctx, "verify", data), &cometsvc.VerifyAuthTokenResponse{})
+	assert.Equal(t, errcode.RpcBadRequest, errors.Cause(err))
+}
+
+func TestServerRegisterInvalidHandler(t *testing.T) {
+	server, _ := newTestServerClient()
+	assert.Panics(t, func() {
+		server.Register("x", func(req *cometsvc.VerifyAuthTokenRequest) (*cometsvc.VerifyAuthTokenResponse, error) {
+			return nil, nil
+		})
+	})
+	assert.Panics(t, func() {
+		server.Register("x", func(ctx context.Context, req *cometsvc.GetConnectionInfoRequest) (*cometsvc.VerifyAuthTokenResponse, error) {
+			return nil, nil
+		})
+	})
+}
@@ -191,6 +191,176 @@ func (*OnEventResponse) Descriptor() ([]byte, []int) {
 	return file_bizapi_proto_rawDescGZIP(), []int{3}
 }
 
+type OnMessageBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messages []*protocol.Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *OnMessageBatchRequest) Reset() {
+	*x = OnMessageBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bizapi_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnMessageBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnMessageBatchRequest) ProtoMessage() {}
+
+func (x *OnMessageBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bizapi_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnMessageBatchRequest.ProtoReflect.Descriptor instead.
+func (*OnMessageBatchRequest) Descriptor() ([]byte, []int) {
+	return file_bizapi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OnMessageBatchRequest) GetMessages() []*protocol.Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type OnMessageBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OnMessageBatchResponse) Reset() {
+	*x = OnMessageBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bizapi_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnMessageBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnMessageBatchResponse) ProtoMessage() {}
+
+func (x *OnMessageBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bizapi_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnMessageBatchResponse.ProtoReflect.Descriptor instead.
+func (*OnMessageBatchResponse) Descriptor() ([]byte, []int) {
+	return file_bizapi_proto_rawDescGZIP(), []int{5}
+}
+
+type OnEventBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*protocol.Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *OnEventBatchRequest) Reset() {
+	*x = OnEventBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bizapi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnEventBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnEventBatchRequest) ProtoMessage() {}
+
+func (x *OnEventBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bizapi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnEventBatchRequest.ProtoReflect.Descriptor instead.
+func (*OnEventBatchRequest) Descriptor() ([]byte, []int) {
+	return file_bizapi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OnEventBatchRequest) GetEvents() []*protocol.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type OnEventBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OnEventBatchResponse) Reset() {
+	*x = OnEventBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bizapi_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnEventBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnEventBatchResponse) ProtoMessage() {}
+
+func (x *OnEventBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bizapi_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnEventBatchResponse.ProtoReflect.Descriptor instead.
+func (*OnEventBatchResponse) Descriptor() ([]byte, []int) {
+	return file_bizapi_proto_rawDescGZIP(), []int{7}
+}
+
 var File_bizapi_proto protoreflect.FileDescriptor
 
 var file_bizapi_proto_rawDesc = []byte{
@@ -206,19 +376,41 @@ var file_bizapi_proto_rawDesc = []byte{
 	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05,
 	0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x86, 0x01, 0x0a, 0x06, 0x42, 0x69, 0x7a,
-	0x41, 0x70, 0x69, 0x12, 0x40, 0x0a, 0x09, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x18, 0x2e, 0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x69, 0x7a,
-	0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x12, 0x16, 0x2e, 0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x69, 0x7a, 0x61, 0x70,
-	0x69, 0x2e, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73, 0x2f, 0x6e, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x67, 0x77,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x3b, 0x62, 0x69,
-	0x7a, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x0a, 0x15, 0x4f, 0x6e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2d, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x22, 0x18, 0x0a, 0x16, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3e, 0x0a, 0x13, 0x4f, 0x6e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x27, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x4f, 0x6e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x32, 0xa2, 0x02, 0x0a, 0x06, 0x42, 0x69, 0x7a, 0x41, 0x70, 0x69, 0x12, 0x40, 0x0a,
+	0x09, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x2e, 0x62, 0x69, 0x7a,
+	0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3a, 0x0a, 0x07, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x62, 0x69, 0x7a,
+	0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x4f,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1d, 0x2e,
+	0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62,
+	0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c,
+	0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x62,
+	0x69, 0x7a, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x69, 0x7a, 0x61,
+	0x70, 0x69, 0x2e, 0x4f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73, 0x2f, 0x6e, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x67, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x69, 0x7a,
+	0x61, 0x70, 0x69, 0x3b, 0x62, 0x69, 0x7a, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -233,27 +425,37 @@ func file_bizapi_proto_rawDescGZIP() []byte {
 	return file_bizapi_proto_rawDescData
 }
 
-var file_bizapi_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bizapi_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_bizapi_proto_goTypes = []interface{}{
-	(*OnMessageRequest)(nil),  // 0: bizapi.OnMessageRequest
-	(*OnMessageResponse)(nil), // 1: bizapi.OnMessageResponse
-	(*OnEventRequest)(nil),    // 2: bizapi.OnEventRequest
-	(*OnEventResponse)(nil),   // 3: bizapi.OnEventResponse
-	(*protocol.Message)(nil),  // 4: protocol.Message
-	(*protocol.Event)(nil),    // 5: protocol.Event
+	(*OnMessageRequest)(nil),       // 0: bizapi.OnMessageRequest
+	(*OnMessageResponse)(nil),      // 1: bizapi.OnMessageResponse
+	(*OnEventRequest)(nil),         // 2: bizapi.OnEventRequest
+	(*OnEventResponse)(nil),        // 3: bizapi.OnEventResponse
+	(*OnMessageBatchRequest)(nil),  // 4: bizapi.OnMessageBatchRequest
+	(*OnMessageBatchResponse)(nil), // 5: bizapi.OnMessageBatchResponse
+	(*OnEventBatchRequest)(nil),    // 6: bizapi.OnEventBatchRequest
+	(*OnEventBatchResponse)(nil),   // 7: bizapi.OnEventBatchResponse
+	(*protocol.Message)(nil),       // 8: protocol.Message
+	(*protocol.Event)(nil),         // 9: protocol.Event
 }
 var file_bizapi_proto_depIdxs = []int32{
-	4, // 0: bizapi.OnMessageRequest.message:type_name -> protocol.Message
-	5, // 1: bizapi.OnEventRequest.event:type_name -> protocol.Event
-	0, // 2: bizapi.BizApi.OnMessage:input_type -> bizapi.OnMessageRequest
-	2, // 3: bizapi.BizApi.OnEvent:input_type -> bizapi.OnEventRequest
-	1, // 4: bizapi.BizApi.OnMessage:output_type -> bizapi.OnMessageResponse
-	3, // 5: bizapi.BizApi.OnEvent:output_type -> bizapi.OnEventResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	8, // 0: bizapi.OnMessageRequest.message:type_name -> protocol.Message
+	9, // 1: bizapi.OnEventRequest.event:type_name -> protocol.Event
+	8, // 2: bizapi.OnMessageBatchRequest.messages:type_name -> protocol.Message
+	9, // 3: bizapi.OnEventBatchRequest.events:type_name -> protocol.Event
+	0, // 4: bizapi.BizApi.OnMessage:input_type -> bizapi.OnMessageRequest
+	2, // 5: bizapi.BizApi.OnEvent:input_type -> bizapi.OnEventRequest
+	4, // 6: bizapi.BizApi.OnMessageBatch:input_type -> bizapi.OnMessageBatchRequest
+	6, // 7: bizapi.BizApi.OnEventBatch:input_type -> bizapi.OnEventBatchRequest
+	1, // 8: bizapi.BizApi.OnMessage:output_type -> bizapi.OnMessageResponse
+	3, // 9: bizapi.BizApi.OnEvent:output_type -> bizapi.OnEventResponse
+	5, // 10: bizapi.BizApi.OnMessageBatch:output_type -> bizapi.OnMessageBatchResponse
+	7, // 11: bizapi.BizApi.OnEventBatch:output_type -> bizapi.OnEventBatchResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_bizapi_proto_init() }
@@ -310,6 +512,54 @@ func file_bizapi_proto_init() {
 				return nil
 			}
 		}
+		file_bizapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnMessageBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bizapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnMessageBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bizapi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnEventBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bizapi_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnEventBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -317,7 +567,7 @@ func file_bizapi_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_bizapi_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
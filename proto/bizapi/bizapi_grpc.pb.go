@@ -20,6 +20,12 @@ const _ = grpc.SupportPackageIsVersion7
 type BizApiClient interface {
 	OnMessage(ctx context.Context, in *OnMessageRequest, opts ...grpc.CallOption) (*OnMessageResponse, error)
 	OnEvent(ctx context.Context, in *OnEventRequest, opts ...grpc.CallOption) (*OnEventResponse, error)
+	// OnMessageBatch delivers upgoing messages accumulated by the broker
+	// in a short window, it is used for apps which enable batching.
+	OnMessageBatch(ctx context.Context, in *OnMessageBatchRequest, opts ...grpc.CallOption) (*OnMessageBatchResponse, error)
+	// OnEventBatch delivers connection events accumulated by the broker
+	// in a short window, it is used for apps which enable batching.
+	OnEventBatch(ctx context.Context, in *OnEventBatchRequest, opts ...grpc.CallOption) (*OnEventBatchResponse, error)
 }
 
 type bizApiClient struct {
@@ -48,12 +54,36 @@ func (c *bizApiClient) OnEvent(ctx context.Context, in *OnEventRequest, opts ...
 	return out, nil
 }
 
+func (c *bizApiClient) OnMessageBatch(ctx context.Context, in *OnMessageBatchRequest, opts ...grpc.CallOption) (*OnMessageBatchResponse, error) {
+	out := new(OnMessageBatchResponse)
+	err := c.cc.Invoke(ctx, "/bizapi.BizApi/OnMessageBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bizApiClient) OnEventBatch(ctx context.Context, in *OnEventBatchRequest, opts ...grpc.CallOption) (*OnEventBatchResponse, error) {
+	out := new(OnEventBatchResponse)
+	err := c.cc.Invoke(ctx, "/bizapi.BizApi/OnEventBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BizApiServer is the server API for BizApi service.
 // All implementations must embed UnimplementedBizApiServer
 // for forward compatibility
 type BizApiServer interface {
 	OnMessage(context.Context, *OnMessageRequest) (*OnMessageResponse, error)
 	OnEvent(context.Context, *OnEventRequest) (*OnEventResponse, error)
+	// OnMessageBatch delivers upgoing messages accumulated by the broker
+	// in a short window, it is used for apps which enable batching.
+	OnMessageBatch(context.Context, *OnMessageBatchRequest) (*OnMessageBatchResponse, error)
+	// OnEventBatch delivers connection events accumulated by the broker
+	// in a short window, it is used for apps which enable batching.
+	OnEventBatch(context.Context, *OnEventBatchRequest) (*OnEventBatchResponse, error)
 	mustEmbedUnimplementedBizApiServer()
 }
 
@@ -67,6 +97,12 @@ func (UnimplementedBizApiServer) OnMessage(context.Context, *OnMessageRequest) (
 func (UnimplementedBizApiServer) OnEvent(context.Context, *OnEventRequest) (*OnEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OnEvent not implemented")
 }
+func (UnimplementedBizApiServer) OnMessageBatch(context.Context, *OnMessageBatchRequest) (*OnMessageBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnMessageBatch not implemented")
+}
+func (UnimplementedBizApiServer) OnEventBatch(context.Context, *OnEventBatchRequest) (*OnEventBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OnEventBatch not implemented")
+}
 func (UnimplementedBizApiServer) mustEmbedUnimplementedBizApiServer() {}
 
 // UnsafeBizApiServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +152,42 @@ func _BizApi_OnEvent_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BizApi_OnMessageBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnMessageBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BizApiServer).OnMessageBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bizapi.BizApi/OnMessageBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BizApiServer).OnMessageBatch(ctx, req.(*OnMessageBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BizApi_OnEventBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OnEventBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BizApiServer).OnEventBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bizapi.BizApi/OnEventBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BizApiServer).OnEventBatch(ctx, req.(*OnEventBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BizApi_ServiceDesc is the grpc.ServiceDesc for BizApi service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +203,14 @@ var BizApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OnEvent",
 			Handler:    _BizApi_OnEvent_Handler,
 		},
+		{
+			MethodName: "OnMessageBatch",
+			Handler:    _BizApi_OnMessageBatch_Handler,
+		},
+		{
+			MethodName: "OnEventBatch",
+			Handler:    _BizApi_OnEventBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bizapi.proto",
@@ -28,6 +28,8 @@ const (
 	PushTarget_USER                   PushTarget_Type = 1
 	PushTarget_USER_DEVICE            PushTarget_Type = 2
 	PushTarget_UNAUTHENTICATED_DEVICE PushTarget_Type = 3
+	PushTarget_FILTER                 PushTarget_Type = 4
+	PushTarget_GROUP                  PushTarget_Type = 5
 )
 
 // Enum value maps for PushTarget_Type.
@@ -37,12 +39,16 @@ var (
 		1: "USER",
 		2: "USER_DEVICE",
 		3: "UNAUTHENTICATED_DEVICE",
+		4: "FILTER",
+		5: "GROUP",
 	}
 	PushTarget_Type_value = map[string]int32{
 		"CONNECTION":             0,
 		"USER":                   1,
 		"USER_DEVICE":            2,
 		"UNAUTHENTICATED_DEVICE": 3,
+		"FILTER":                 4,
+		"GROUP":                  5,
 	}
 )
 
@@ -70,7 +76,7 @@ func (x PushTarget_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PushTarget_Type.Descriptor instead.
 func (PushTarget_Type) EnumDescriptor() ([]byte, []int) {
-	return file_brokersvc_proto_rawDescGZIP(), []int{4, 0}
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 0}
 }
 
 type Authorization struct {
@@ -133,9 +139,10 @@ type QueryRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Auth      *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
-	UserIds   []int64        `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
-	DeviceIds []int64        `protobuf:"varint,3,rep,packed,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+	Auth          *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	UserIds       []int64        `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	DeviceIds     []int64        `protobuf:"varint,3,rep,packed,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+	ConnectionIds []string       `protobuf:"bytes,4,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
 }
 
 func (x *QueryRequest) Reset() {
@@ -191,6 +198,13 @@ func (x *QueryRequest) GetDeviceIds() []int64 {
 	return nil
 }
 
+func (x *QueryRequest) GetConnectionIds() []string {
+	if x != nil {
+		return x.ConnectionIds
+	}
+	return nil
+}
+
 type QueryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -198,6 +212,9 @@ type QueryResponse struct {
 
 	UserConnections   map[int64]*protocol.ConnectionList `protobuf:"bytes,1,rep,name=user_connections,json=userConnections,proto3" json:"user_connections,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	DeviceConnections map[int64]*protocol.ConnectionList `protobuf:"bytes,2,rep,name=device_connections,json=deviceConnections,proto3" json:"device_connections,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// connections are keyed by connection ID, connections not found
+	// are absent.
+	Connections map[string]*protocol.Connection `protobuf:"bytes,3,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *QueryResponse) Reset() {
@@ -246,17 +263,37 @@ func (x *QueryResponse) GetDeviceConnections() map[int64]*protocol.ConnectionLis
 	return nil
 }
 
-type UserDevice struct {
+func (x *QueryResponse) GetConnections() map[string]*protocol.Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type ListConnectionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId   int64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DeviceId int64 `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-}
-
-func (x *UserDevice) Reset() {
-	*x = UserDevice{}
+	Auth *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	// cursor is the next_cursor of the previous page, zero to start.
+	Cursor uint64 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// limit is a hint of the page size, a page may contain more or fewer
+	// connections, default 100.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// If given, only connections with one of the client versions.
+	ClientVersions []string `protobuf:"bytes,4,rep,name=client_versions,json=clientVersions,proto3" json:"client_versions,omitempty"`
+	// If given, only connections having all the tags in claims.
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// If positive, only connections active within the last minutes.
+	ActiveWithinMinutes int32 `protobuf:"varint,6,opt,name=active_within_minutes,json=activeWithinMinutes,proto3" json:"active_within_minutes,omitempty"`
+	// If given, only connections matching the filter, client_versions
+	// and tags above are added to it.
+	Filter *ConnectionFilter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *ListConnectionsRequest) Reset() {
+	*x = ListConnectionsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -264,13 +301,13 @@ func (x *UserDevice) Reset() {
 	}
 }
 
-func (x *UserDevice) String() string {
+func (x *ListConnectionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserDevice) ProtoMessage() {}
+func (*ListConnectionsRequest) ProtoMessage() {}
 
-func (x *UserDevice) ProtoReflect() protoreflect.Message {
+func (x *ListConnectionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -282,42 +319,72 @@ func (x *UserDevice) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserDevice.ProtoReflect.Descriptor instead.
-func (*UserDevice) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConnectionsRequest) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UserDevice) GetUserId() int64 {
+func (x *ListConnectionsRequest) GetAuth() *Authorization {
 	if x != nil {
-		return x.UserId
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *ListConnectionsRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
 	}
 	return 0
 }
 
-func (x *UserDevice) GetDeviceId() int64 {
+func (x *ListConnectionsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.DeviceId
+		return x.Limit
 	}
 	return 0
 }
 
-type PushTarget struct {
+func (x *ListConnectionsRequest) GetClientVersions() []string {
+	if x != nil {
+		return x.ClientVersions
+	}
+	return nil
+}
+
+func (x *ListConnectionsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListConnectionsRequest) GetActiveWithinMinutes() int32 {
+	if x != nil {
+		return x.ActiveWithinMinutes
+	}
+	return 0
+}
+
+func (x *ListConnectionsRequest) GetFilter() *ConnectionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListConnectionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type PushTarget_Type `protobuf:"varint,1,opt,name=type,proto3,enum=brokersvc.PushTarget_Type" json:"type,omitempty"`
-	// Types that are assignable to Target:
-	//	*PushTarget_Connections_
-	//	*PushTarget_Users_
-	//	*PushTarget_UserDevices_
-	//	*PushTarget_Devices_
-	Target         isPushTarget_Target `protobuf_oneof:"target"`
-	VersionFilters []string            `protobuf:"bytes,8,rep,name=version_filters,json=versionFilters,proto3" json:"version_filters,omitempty"`
+	Connections []*protocol.Connection `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	// next_cursor is zero when the iteration is done.
+	NextCursor uint64 `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 }
 
-func (x *PushTarget) Reset() {
-	*x = PushTarget{}
+func (x *ListConnectionsResponse) Reset() {
+	*x = ListConnectionsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -325,13 +392,13 @@ func (x *PushTarget) Reset() {
 	}
 }
 
-func (x *PushTarget) String() string {
+func (x *ListConnectionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushTarget) ProtoMessage() {}
+func (*ListConnectionsResponse) ProtoMessage() {}
 
-func (x *PushTarget) ProtoReflect() protoreflect.Message {
+func (x *ListConnectionsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -343,100 +410,37 @@ func (x *PushTarget) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushTarget.ProtoReflect.Descriptor instead.
-func (*PushTarget) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConnectionsResponse) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PushTarget) GetType() PushTarget_Type {
+func (x *ListConnectionsResponse) GetConnections() []*protocol.Connection {
 	if x != nil {
-		return x.Type
-	}
-	return PushTarget_CONNECTION
-}
-
-func (m *PushTarget) GetTarget() isPushTarget_Target {
-	if m != nil {
-		return m.Target
-	}
-	return nil
-}
-
-func (x *PushTarget) GetConnections() *PushTarget_Connections {
-	if x, ok := x.GetTarget().(*PushTarget_Connections_); ok {
 		return x.Connections
 	}
 	return nil
 }
 
-func (x *PushTarget) GetUsers() *PushTarget_Users {
-	if x, ok := x.GetTarget().(*PushTarget_Users_); ok {
-		return x.Users
-	}
-	return nil
-}
-
-func (x *PushTarget) GetUserDevices() *PushTarget_UserDevices {
-	if x, ok := x.GetTarget().(*PushTarget_UserDevices_); ok {
-		return x.UserDevices
-	}
-	return nil
-}
-
-func (x *PushTarget) GetDevices() *PushTarget_Devices {
-	if x, ok := x.GetTarget().(*PushTarget_Devices_); ok {
-		return x.Devices
-	}
-	return nil
-}
-
-func (x *PushTarget) GetVersionFilters() []string {
+func (x *ListConnectionsResponse) GetNextCursor() uint64 {
 	if x != nil {
-		return x.VersionFilters
+		return x.NextCursor
 	}
-	return nil
-}
-
-type isPushTarget_Target interface {
-	isPushTarget_Target()
-}
-
-type PushTarget_Connections_ struct {
-	Connections *PushTarget_Connections `protobuf:"bytes,2,opt,name=connections,proto3,oneof"`
-}
-
-type PushTarget_Users_ struct {
-	Users *PushTarget_Users `protobuf:"bytes,3,opt,name=users,proto3,oneof"`
-}
-
-type PushTarget_UserDevices_ struct {
-	UserDevices *PushTarget_UserDevices `protobuf:"bytes,4,opt,name=user_devices,json=userDevices,proto3,oneof"`
-}
-
-type PushTarget_Devices_ struct {
-	Devices *PushTarget_Devices `protobuf:"bytes,5,opt,name=devices,proto3,oneof"`
+	return 0
 }
 
-func (*PushTarget_Connections_) isPushTarget_Target() {}
-
-func (*PushTarget_Users_) isPushTarget_Target() {}
-
-func (*PushTarget_UserDevices_) isPushTarget_Target() {}
-
-func (*PushTarget_Devices_) isPushTarget_Target() {}
-
-type PushRequest struct {
+type CountOnlineRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Auth    *Authorization    `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
-	Target  *PushTarget       `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	Content *protocol.Content `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Auth *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	// If positive, only count those active within the last minutes.
+	ActiveWithinMinutes int32 `protobuf:"varint,2,opt,name=active_within_minutes,json=activeWithinMinutes,proto3" json:"active_within_minutes,omitempty"`
 }
 
-func (x *PushRequest) Reset() {
-	*x = PushRequest{}
+func (x *CountOnlineRequest) Reset() {
+	*x = CountOnlineRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -444,13 +448,13 @@ func (x *PushRequest) Reset() {
 	}
 }
 
-func (x *PushRequest) String() string {
+func (x *CountOnlineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushRequest) ProtoMessage() {}
+func (*CountOnlineRequest) ProtoMessage() {}
 
-func (x *PushRequest) ProtoReflect() protoreflect.Message {
+func (x *CountOnlineRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -462,40 +466,39 @@ func (x *PushRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushRequest.ProtoReflect.Descriptor instead.
-func (*PushRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountOnlineRequest.ProtoReflect.Descriptor instead.
+func (*CountOnlineRequest) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PushRequest) GetAuth() *Authorization {
+func (x *CountOnlineRequest) GetAuth() *Authorization {
 	if x != nil {
 		return x.Auth
 	}
 	return nil
 }
 
-func (x *PushRequest) GetTarget() *PushTarget {
-	if x != nil {
-		return x.Target
-	}
-	return nil
-}
-
-func (x *PushRequest) GetContent() *protocol.Content {
+func (x *CountOnlineRequest) GetActiveWithinMinutes() int32 {
 	if x != nil {
-		return x.Content
+		return x.ActiveWithinMinutes
 	}
-	return nil
+	return 0
 }
 
-type PushResponse struct {
+// CountOnlineResponse is the approximate online counts of an app,
+// devices are the unauthenticated devices connected without user ID.
+type CountOnlineResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Users       int64 `protobuf:"varint,1,opt,name=users,proto3" json:"users,omitempty"`
+	Devices     int64 `protobuf:"varint,2,opt,name=devices,proto3" json:"devices,omitempty"`
+	Connections int64 `protobuf:"varint,3,opt,name=connections,proto3" json:"connections,omitempty"`
 }
 
-func (x *PushResponse) Reset() {
-	*x = PushResponse{}
+func (x *CountOnlineResponse) Reset() {
+	*x = CountOnlineResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -503,13 +506,13 @@ func (x *PushResponse) Reset() {
 	}
 }
 
-func (x *PushResponse) String() string {
+func (x *CountOnlineResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushResponse) ProtoMessage() {}
+func (*CountOnlineResponse) ProtoMessage() {}
 
-func (x *PushResponse) ProtoReflect() protoreflect.Message {
+func (x *CountOnlineResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -521,22 +524,43 @@ func (x *PushResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushResponse.ProtoReflect.Descriptor instead.
-func (*PushResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountOnlineResponse.ProtoReflect.Descriptor instead.
+func (*CountOnlineResponse) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{6}
 }
 
-type SyncRequest struct {
+func (x *CountOnlineResponse) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *CountOnlineResponse) GetDevices() int64 {
+	if x != nil {
+		return x.Devices
+	}
+	return 0
+}
+
+func (x *CountOnlineResponse) GetConnections() int64 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+type UserDevice struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Auth   *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
-	Target *PushTarget    `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	UserId   int64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId int64 `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 }
 
-func (x *SyncRequest) Reset() {
-	*x = SyncRequest{}
+func (x *UserDevice) Reset() {
+	*x = UserDevice{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -544,13 +568,13 @@ func (x *SyncRequest) Reset() {
 	}
 }
 
-func (x *SyncRequest) String() string {
+func (x *UserDevice) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncRequest) ProtoMessage() {}
+func (*UserDevice) ProtoMessage() {}
 
-func (x *SyncRequest) ProtoReflect() protoreflect.Message {
+func (x *UserDevice) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -562,33 +586,51 @@ func (x *SyncRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
-func (*SyncRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserDevice.ProtoReflect.Descriptor instead.
+func (*UserDevice) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SyncRequest) GetAuth() *Authorization {
+func (x *UserDevice) GetUserId() int64 {
 	if x != nil {
-		return x.Auth
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *SyncRequest) GetTarget() *PushTarget {
+func (x *UserDevice) GetDeviceId() int64 {
 	if x != nil {
-		return x.Target
+		return x.DeviceId
 	}
-	return nil
+	return 0
 }
 
-type SyncResponse struct {
+type PushTarget struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Type PushTarget_Type `protobuf:"varint,1,opt,name=type,proto3,enum=brokersvc.PushTarget_Type" json:"type,omitempty"`
+	// Types that are assignable to Target:
+	//	*PushTarget_Connections_
+	//	*PushTarget_Users_
+	//	*PushTarget_UserDevices_
+	//	*PushTarget_Devices_
+	//	*PushTarget_Expression_
+	//	*PushTarget_Group_
+	Target isPushTarget_Target `protobuf_oneof:"target"`
+	// version_filters is the same as filter.client_versions.
+	VersionFilters []string `protobuf:"bytes,8,rep,name=version_filters,json=versionFilters,proto3" json:"version_filters,omitempty"`
+	// If given, only connections matching the filter.
+	Filter *ConnectionFilter `protobuf:"bytes,9,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Connections excluded after the target is resolved, eg. to not
+	// echo a message back to the sender.
+	ExcludeConnectionIds []string `protobuf:"bytes,10,rep,name=exclude_connection_ids,json=excludeConnectionIds,proto3" json:"exclude_connection_ids,omitempty"`
+	ExcludeUserIds       []int64  `protobuf:"varint,11,rep,packed,name=exclude_user_ids,json=excludeUserIds,proto3" json:"exclude_user_ids,omitempty"`
 }
 
-func (x *SyncResponse) Reset() {
-	*x = SyncResponse{}
+func (x *PushTarget) Reset() {
+	*x = PushTarget{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -596,13 +638,13 @@ func (x *SyncResponse) Reset() {
 	}
 }
 
-func (x *SyncResponse) String() string {
+func (x *PushTarget) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncResponse) ProtoMessage() {}
+func (*PushTarget) ProtoMessage() {}
 
-func (x *SyncResponse) ProtoReflect() protoreflect.Message {
+func (x *PushTarget) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -614,33 +656,170 @@ func (x *SyncResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncResponse.ProtoReflect.Descriptor instead.
-func (*SyncResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PushTarget.ProtoReflect.Descriptor instead.
+func (*PushTarget) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{8}
 }
 
-type BroadcastTarget struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *PushTarget) GetType() PushTarget_Type {
+	if x != nil {
+		return x.Type
+	}
+	return PushTarget_CONNECTION
 }
 
-func (x *BroadcastTarget) Reset() {
-	*x = BroadcastTarget{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_brokersvc_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (m *PushTarget) GetTarget() isPushTarget_Target {
+	if m != nil {
+		return m.Target
 	}
+	return nil
 }
 
-func (x *BroadcastTarget) String() string {
+func (x *PushTarget) GetConnections() *PushTarget_Connections {
+	if x, ok := x.GetTarget().(*PushTarget_Connections_); ok {
+		return x.Connections
+	}
+	return nil
+}
+
+func (x *PushTarget) GetUsers() *PushTarget_Users {
+	if x, ok := x.GetTarget().(*PushTarget_Users_); ok {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *PushTarget) GetUserDevices() *PushTarget_UserDevices {
+	if x, ok := x.GetTarget().(*PushTarget_UserDevices_); ok {
+		return x.UserDevices
+	}
+	return nil
+}
+
+func (x *PushTarget) GetDevices() *PushTarget_Devices {
+	if x, ok := x.GetTarget().(*PushTarget_Devices_); ok {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *PushTarget) GetExpression() *PushTarget_Expression {
+	if x, ok := x.GetTarget().(*PushTarget_Expression_); ok {
+		return x.Expression
+	}
+	return nil
+}
+
+func (x *PushTarget) GetGroup() *PushTarget_Group {
+	if x, ok := x.GetTarget().(*PushTarget_Group_); ok {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *PushTarget) GetVersionFilters() []string {
+	if x != nil {
+		return x.VersionFilters
+	}
+	return nil
+}
+
+func (x *PushTarget) GetFilter() *ConnectionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *PushTarget) GetExcludeConnectionIds() []string {
+	if x != nil {
+		return x.ExcludeConnectionIds
+	}
+	return nil
+}
+
+func (x *PushTarget) GetExcludeUserIds() []int64 {
+	if x != nil {
+		return x.ExcludeUserIds
+	}
+	return nil
+}
+
+type isPushTarget_Target interface {
+	isPushTarget_Target()
+}
+
+type PushTarget_Connections_ struct {
+	Connections *PushTarget_Connections `protobuf:"bytes,2,opt,name=connections,proto3,oneof"`
+}
+
+type PushTarget_Users_ struct {
+	Users *PushTarget_Users `protobuf:"bytes,3,opt,name=users,proto3,oneof"`
+}
+
+type PushTarget_UserDevices_ struct {
+	UserDevices *PushTarget_UserDevices `protobuf:"bytes,4,opt,name=user_devices,json=userDevices,proto3,oneof"`
+}
+
+type PushTarget_Devices_ struct {
+	Devices *PushTarget_Devices `protobuf:"bytes,5,opt,name=devices,proto3,oneof"`
+}
+
+type PushTarget_Expression_ struct {
+	Expression *PushTarget_Expression `protobuf:"bytes,6,opt,name=expression,proto3,oneof"`
+}
+
+type PushTarget_Group_ struct {
+	Group *PushTarget_Group `protobuf:"bytes,7,opt,name=group,proto3,oneof"`
+}
+
+func (*PushTarget_Connections_) isPushTarget_Target() {}
+
+func (*PushTarget_Users_) isPushTarget_Target() {}
+
+func (*PushTarget_UserDevices_) isPushTarget_Target() {}
+
+func (*PushTarget_Devices_) isPushTarget_Target() {}
+
+func (*PushTarget_Expression_) isPushTarget_Target() {}
+
+func (*PushTarget_Group_) isPushTarget_Target() {}
+
+// ConnectionFilter matches connections by attributes, all the non-empty
+// fields must be matched, a list matches if any of the values equals
+// the connection's.
+type ConnectionFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientVersions []string `protobuf:"bytes,1,rep,name=client_versions,json=clientVersions,proto3" json:"client_versions,omitempty"`
+	Platforms      []string `protobuf:"bytes,2,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	Os             []string `protobuf:"bytes,3,rep,name=os,proto3" json:"os,omitempty"`
+	Locales        []string `protobuf:"bytes,4,rep,name=locales,proto3" json:"locales,omitempty"`
+	NetworkTypes   []string `protobuf:"bytes,5,rep,name=network_types,json=networkTypes,proto3" json:"network_types,omitempty"`
+	// The connection must have all the tags in claims.
+	Tags []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The connection must have all the attributes with equal values.
+	Attributes map[string]string `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ConnectionFilter) Reset() {
+	*x = ConnectionFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastTarget) ProtoMessage() {}
+func (*ConnectionFilter) ProtoMessage() {}
 
-func (x *BroadcastTarget) ProtoReflect() protoreflect.Message {
+func (x *ConnectionFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,23 +831,72 @@ func (x *BroadcastTarget) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastTarget.ProtoReflect.Descriptor instead.
-func (*BroadcastTarget) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConnectionFilter.ProtoReflect.Descriptor instead.
+func (*ConnectionFilter) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{9}
 }
 
-type BroadcastRequest struct {
+func (x *ConnectionFilter) GetClientVersions() []string {
+	if x != nil {
+		return x.ClientVersions
+	}
+	return nil
+}
+
+func (x *ConnectionFilter) GetPlatforms() []string {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *ConnectionFilter) GetOs() []string {
+	if x != nil {
+		return x.Os
+	}
+	return nil
+}
+
+func (x *ConnectionFilter) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+func (x *ConnectionFilter) GetNetworkTypes() []string {
+	if x != nil {
+		return x.NetworkTypes
+	}
+	return nil
+}
+
+func (x *ConnectionFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ConnectionFilter) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type PushRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Auth    *Authorization    `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
-	Target  *BroadcastTarget  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Target  *PushTarget       `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	Content *protocol.Content `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
 }
 
-func (x *BroadcastRequest) Reset() {
-	*x = BroadcastRequest{}
+func (x *PushRequest) Reset() {
+	*x = PushRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -676,13 +904,13 @@ func (x *BroadcastRequest) Reset() {
 	}
 }
 
-func (x *BroadcastRequest) String() string {
+func (x *PushRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastRequest) ProtoMessage() {}
+func (*PushRequest) ProtoMessage() {}
 
-func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
+func (x *PushRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -694,42 +922,40 @@ func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
-func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PushRequest.ProtoReflect.Descriptor instead.
+func (*PushRequest) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *BroadcastRequest) GetAuth() *Authorization {
+func (x *PushRequest) GetAuth() *Authorization {
 	if x != nil {
 		return x.Auth
 	}
 	return nil
 }
 
-func (x *BroadcastRequest) GetTarget() *BroadcastTarget {
+func (x *PushRequest) GetTarget() *PushTarget {
 	if x != nil {
 		return x.Target
 	}
 	return nil
 }
 
-func (x *BroadcastRequest) GetContent() *protocol.Content {
+func (x *PushRequest) GetContent() *protocol.Content {
 	if x != nil {
 		return x.Content
 	}
 	return nil
 }
 
-type BroadcastResponse struct {
+type PushResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	BroadcastId string `protobuf:"bytes,1,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"`
 }
 
-func (x *BroadcastResponse) Reset() {
-	*x = BroadcastResponse{}
+func (x *PushResponse) Reset() {
+	*x = PushResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -737,13 +963,13 @@ func (x *BroadcastResponse) Reset() {
 	}
 }
 
-func (x *BroadcastResponse) String() string {
+func (x *PushResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastResponse) ProtoMessage() {}
+func (*PushResponse) ProtoMessage() {}
 
-func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
+func (x *PushResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,29 +981,22 @@ func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastResponse.ProtoReflect.Descriptor instead.
-func (*BroadcastResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PushResponse.ProtoReflect.Descriptor instead.
+func (*PushResponse) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *BroadcastResponse) GetBroadcastId() string {
-	if x != nil {
-		return x.BroadcastId
-	}
-	return ""
-}
-
-type StopBroadcastRequest struct {
+type SyncRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Auth        *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
-	BroadcastId string         `protobuf:"bytes,2,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"`
+	Auth   *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Target *PushTarget    `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 }
 
-func (x *StopBroadcastRequest) Reset() {
-	*x = StopBroadcastRequest{}
+func (x *SyncRequest) Reset() {
+	*x = SyncRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -785,13 +1004,13 @@ func (x *StopBroadcastRequest) Reset() {
 	}
 }
 
-func (x *StopBroadcastRequest) String() string {
+func (x *SyncRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopBroadcastRequest) ProtoMessage() {}
+func (*SyncRequest) ProtoMessage() {}
 
-func (x *StopBroadcastRequest) ProtoReflect() protoreflect.Message {
+func (x *SyncRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -803,33 +1022,33 @@ func (x *StopBroadcastRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopBroadcastRequest.ProtoReflect.Descriptor instead.
-func (*StopBroadcastRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
+func (*SyncRequest) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *StopBroadcastRequest) GetAuth() *Authorization {
+func (x *SyncRequest) GetAuth() *Authorization {
 	if x != nil {
 		return x.Auth
 	}
 	return nil
 }
 
-func (x *StopBroadcastRequest) GetBroadcastId() string {
+func (x *SyncRequest) GetTarget() *PushTarget {
 	if x != nil {
-		return x.BroadcastId
+		return x.Target
 	}
-	return ""
+	return nil
 }
 
-type StopBroadcastResponse struct {
+type SyncResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *StopBroadcastResponse) Reset() {
-	*x = StopBroadcastResponse{}
+func (x *SyncResponse) Reset() {
+	*x = SyncResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -837,13 +1056,13 @@ func (x *StopBroadcastResponse) Reset() {
 	}
 }
 
-func (x *StopBroadcastResponse) String() string {
+func (x *SyncResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopBroadcastResponse) ProtoMessage() {}
+func (*SyncResponse) ProtoMessage() {}
 
-func (x *StopBroadcastResponse) ProtoReflect() protoreflect.Message {
+func (x *SyncResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -855,23 +1074,19 @@ func (x *StopBroadcastResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopBroadcastResponse.ProtoReflect.Descriptor instead.
-func (*StopBroadcastResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SyncResponse.ProtoReflect.Descriptor instead.
+func (*SyncResponse) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{13}
 }
 
-type SignTokenRequest struct {
+type BroadcastTarget struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Auth     *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
-	UserId   int64          `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DeviceId int64          `protobuf:"varint,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 }
 
-func (x *SignTokenRequest) Reset() {
-	*x = SignTokenRequest{}
+func (x *BroadcastTarget) Reset() {
+	*x = BroadcastTarget{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -879,13 +1094,13 @@ func (x *SignTokenRequest) Reset() {
 	}
 }
 
-func (x *SignTokenRequest) String() string {
+func (x *BroadcastTarget) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignTokenRequest) ProtoMessage() {}
+func (*BroadcastTarget) ProtoMessage() {}
 
-func (x *SignTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *BroadcastTarget) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -897,58 +1112,99 @@ func (x *SignTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignTokenRequest.ProtoReflect.Descriptor instead.
-func (*SignTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BroadcastTarget.ProtoReflect.Descriptor instead.
+func (*BroadcastTarget) Descriptor() ([]byte, []int) {
 	return file_brokersvc_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *SignTokenRequest) GetAuth() *Authorization {
+type BroadcastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth    *Authorization    `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Target  *BroadcastTarget  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Content *protocol.Content `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *BroadcastRequest) Reset() {
+	*x = BroadcastRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BroadcastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastRequest) ProtoMessage() {}
+
+func (x *BroadcastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BroadcastRequest) GetAuth() *Authorization {
 	if x != nil {
 		return x.Auth
 	}
 	return nil
 }
 
-func (x *SignTokenRequest) GetUserId() int64 {
+func (x *BroadcastRequest) GetTarget() *BroadcastTarget {
 	if x != nil {
-		return x.UserId
+		return x.Target
 	}
-	return 0
+	return nil
 }
 
-func (x *SignTokenRequest) GetDeviceId() int64 {
+func (x *BroadcastRequest) GetContent() *protocol.Content {
 	if x != nil {
-		return x.DeviceId
+		return x.Content
 	}
-	return 0
+	return nil
 }
 
-type SignTokenResponse struct {
+type BroadcastResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token    string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	ExpireAt int64  `protobuf:"varint,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	BroadcastId string `protobuf:"bytes,1,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"`
 }
 
-func (x *SignTokenResponse) Reset() {
-	*x = SignTokenResponse{}
+func (x *BroadcastResponse) Reset() {
+	*x = BroadcastResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_brokersvc_proto_msgTypes[15]
+		mi := &file_brokersvc_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignTokenResponse) String() string {
+func (x *BroadcastResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignTokenResponse) ProtoMessage() {}
+func (*BroadcastResponse) ProtoMessage() {}
 
-func (x *SignTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_brokersvc_proto_msgTypes[15]
+func (x *BroadcastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,35 +1215,81 @@ func (x *SignTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignTokenResponse.ProtoReflect.Descriptor instead.
-func (*SignTokenResponse) Descriptor() ([]byte, []int) {
-	return file_brokersvc_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use BroadcastResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *SignTokenResponse) GetToken() string {
+func (x *BroadcastResponse) GetBroadcastId() string {
 	if x != nil {
-		return x.Token
+		return x.BroadcastId
 	}
 	return ""
 }
 
-func (x *SignTokenResponse) GetExpireAt() int64 {
+type StopBroadcastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth        *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	BroadcastId string         `protobuf:"bytes,2,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"`
+}
+
+func (x *StopBroadcastRequest) Reset() {
+	*x = StopBroadcastRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopBroadcastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopBroadcastRequest) ProtoMessage() {}
+
+func (x *StopBroadcastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopBroadcastRequest.ProtoReflect.Descriptor instead.
+func (*StopBroadcastRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StopBroadcastRequest) GetAuth() *Authorization {
 	if x != nil {
-		return x.ExpireAt
+		return x.Auth
 	}
-	return 0
+	return nil
 }
 
-type PushTarget_Connections struct {
+func (x *StopBroadcastRequest) GetBroadcastId() string {
+	if x != nil {
+		return x.BroadcastId
+	}
+	return ""
+}
+
+type StopBroadcastResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	ConnectionIds []string `protobuf:"bytes,1,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
 }
 
-func (x *PushTarget_Connections) Reset() {
-	*x = PushTarget_Connections{}
+func (x *StopBroadcastResponse) Reset() {
+	*x = StopBroadcastResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_brokersvc_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -995,13 +1297,13 @@ func (x *PushTarget_Connections) Reset() {
 	}
 }
 
-func (x *PushTarget_Connections) String() string {
+func (x *StopBroadcastResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushTarget_Connections) ProtoMessage() {}
+func (*StopBroadcastResponse) ProtoMessage() {}
 
-func (x *PushTarget_Connections) ProtoReflect() protoreflect.Message {
+func (x *StopBroadcastResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_brokersvc_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1013,422 +1315,2473 @@ func (x *PushTarget_Connections) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushTarget_Connections.ProtoReflect.Descriptor instead.
-func (*PushTarget_Connections) Descriptor() ([]byte, []int) {
-	return file_brokersvc_proto_rawDescGZIP(), []int{4, 0}
+// Deprecated: Use StopBroadcastResponse.ProtoReflect.Descriptor instead.
+func (*StopBroadcastResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PushTarget_Connections) GetConnectionIds() []string {
+type SignTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth     *Authorization        `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	UserId   int64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId int64                 `protobuf:"varint,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Claims   *protocol.TokenClaims `protobuf:"bytes,4,opt,name=claims,proto3" json:"claims,omitempty"`
+	// If bind_ip is given, the token can only be used by clients
+	// connecting from the IP.
+	BindIp string `protobuf:"bytes,5,opt,name=bind_ip,json=bindIp,proto3" json:"bind_ip,omitempty"`
+}
+
+func (x *SignTokenRequest) Reset() {
+	*x = SignTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTokenRequest) ProtoMessage() {}
+
+func (x *SignTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTokenRequest.ProtoReflect.Descriptor instead.
+func (*SignTokenRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SignTokenRequest) GetAuth() *Authorization {
 	if x != nil {
-		return x.ConnectionIds
+		return x.Auth
 	}
 	return nil
 }
 
-type PushTarget_Users struct {
+func (x *SignTokenRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SignTokenRequest) GetDeviceId() int64 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+func (x *SignTokenRequest) GetClaims() *protocol.TokenClaims {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *SignTokenRequest) GetBindIp() string {
+	if x != nil {
+		return x.BindIp
+	}
+	return ""
+}
+
+type SignTokenResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserIds []int64 `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Token    string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpireAt int64  `protobuf:"varint,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
 }
 
-func (x *PushTarget_Users) Reset() {
-	*x = PushTarget_Users{}
+func (x *SignTokenResponse) Reset() {
+	*x = SignTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_brokersvc_proto_msgTypes[19]
+		mi := &file_brokersvc_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PushTarget_Users) String() string {
+func (x *SignTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushTarget_Users) ProtoMessage() {}
+func (*SignTokenResponse) ProtoMessage() {}
 
-func (x *PushTarget_Users) ProtoReflect() protoreflect.Message {
-	mi := &file_brokersvc_proto_msgTypes[19]
+func (x *SignTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PushTarget_Users.ProtoReflect.Descriptor instead.
-func (*PushTarget_Users) Descriptor() ([]byte, []int) {
-	return file_brokersvc_proto_rawDescGZIP(), []int{4, 1}
-}
-
-func (x *PushTarget_Users) GetUserIds() []int64 {
-	if x != nil {
-		return x.UserIds
-	}
-	return nil
-}
-
-type PushTarget_UserDevices struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	UserDevices []*UserDevice `protobuf:"bytes,1,rep,name=user_devices,json=userDevices,proto3" json:"user_devices,omitempty"`
-}
-
-func (x *PushTarget_UserDevices) Reset() {
-	*x = PushTarget_UserDevices{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_brokersvc_proto_msgTypes[20]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *PushTarget_UserDevices) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PushTarget_UserDevices) ProtoMessage() {}
-
-func (x *PushTarget_UserDevices) ProtoReflect() protoreflect.Message {
-	mi := &file_brokersvc_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTokenResponse.ProtoReflect.Descriptor instead.
+func (*SignTokenResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SignTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SignTokenResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth  *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Token string         `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RefreshTokenRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *RefreshTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token    string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpireAt int64  `protobuf:"varint,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RefreshTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type RevokeTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	// If token is given, only the token is revoked, else all tokens
+	// of the user signed before now are revoked.
+	Token  string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	UserId int64  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *RevokeTokenRequest) Reset() {
+	*x = RevokeTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenRequest) ProtoMessage() {}
+
+func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RevokeTokenRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *RevokeTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RevokeTokenRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type RevokeTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RevokeTokenResponse) Reset() {
+	*x = RevokeTokenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenResponse) ProtoMessage() {}
+
+func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{24}
+}
+
+type Blocklist struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IP addresses or CIDRs, eg. "10.0.0.1", "10.0.0.0/8".
+	Cidrs     []string `protobuf:"bytes,1,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	UserIds   []int64  `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	DeviceIds []int64  `protobuf:"varint,3,rep,packed,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+}
+
+func (x *Blocklist) Reset() {
+	*x = Blocklist{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Blocklist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Blocklist) ProtoMessage() {}
+
+func (x *Blocklist) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Blocklist.ProtoReflect.Descriptor instead.
+func (*Blocklist) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Blocklist) GetCidrs() []string {
+	if x != nil {
+		return x.Cidrs
+	}
+	return nil
+}
+
+func (x *Blocklist) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *Blocklist) GetDeviceIds() []int64 {
+	if x != nil {
+		return x.DeviceIds
+	}
+	return nil
+}
+
+type GetBlocklistRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *GetBlocklistRequest) Reset() {
+	*x = GetBlocklistRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlocklistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlocklistRequest) ProtoMessage() {}
+
+func (x *GetBlocklistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlocklistRequest.ProtoReflect.Descriptor instead.
+func (*GetBlocklistRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetBlocklistRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+type GetBlocklistResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocklist *Blocklist `protobuf:"bytes,1,opt,name=blocklist,proto3" json:"blocklist,omitempty"`
+}
+
+func (x *GetBlocklistResponse) Reset() {
+	*x = GetBlocklistResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlocklistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlocklistResponse) ProtoMessage() {}
+
+func (x *GetBlocklistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlocklistResponse.ProtoReflect.Descriptor instead.
+func (*GetBlocklistResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetBlocklistResponse) GetBlocklist() *Blocklist {
+	if x != nil {
+		return x.Blocklist
+	}
+	return nil
+}
+
+type UpdateBlocklistRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth   *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Add    *Blocklist     `protobuf:"bytes,2,opt,name=add,proto3" json:"add,omitempty"`
+	Remove *Blocklist     `protobuf:"bytes,3,opt,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *UpdateBlocklistRequest) Reset() {
+	*x = UpdateBlocklistRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBlocklistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlocklistRequest) ProtoMessage() {}
+
+func (x *UpdateBlocklistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlocklistRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBlocklistRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateBlocklistRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *UpdateBlocklistRequest) GetAdd() *Blocklist {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *UpdateBlocklistRequest) GetRemove() *Blocklist {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type UpdateBlocklistResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateBlocklistResponse) Reset() {
+	*x = UpdateBlocklistResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBlocklistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBlocklistResponse) ProtoMessage() {}
+
+func (x *UpdateBlocklistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBlocklistResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBlocklistResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{29}
+}
+
+type GetConnectionInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth   *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	ConnId string         `protobuf:"bytes,2,opt,name=conn_id,json=connId,proto3" json:"conn_id,omitempty"`
+}
+
+func (x *GetConnectionInfoRequest) Reset() {
+	*x = GetConnectionInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConnectionInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionInfoRequest) ProtoMessage() {}
+
+func (x *GetConnectionInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectionInfoRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetConnectionInfoRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *GetConnectionInfoRequest) GetConnId() string {
+	if x != nil {
+		return x.ConnId
+	}
+	return ""
+}
+
+type GetConnectionInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Detail *protocol.ConnectionDetail `protobuf:"bytes,1,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *GetConnectionInfoResponse) Reset() {
+	*x = GetConnectionInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConnectionInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionInfoResponse) ProtoMessage() {}
+
+func (x *GetConnectionInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetConnectionInfoResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetConnectionInfoResponse) GetDetail() *protocol.ConnectionDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth    *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	GroupId string         `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Name    string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateGroupRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *CreateGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CreateGroupResponse) Reset() {
+	*x = CreateGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupResponse) ProtoMessage() {}
+
+func (x *CreateGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{33}
+}
+
+type DeleteGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth    *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	GroupId string         `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+}
+
+func (x *DeleteGroupRequest) Reset() {
+	*x = DeleteGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupRequest) ProtoMessage() {}
+
+func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteGroupRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *DeleteGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type DeleteGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteGroupResponse) Reset() {
+	*x = DeleteGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupResponse) ProtoMessage() {}
+
+func (x *DeleteGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{35}
+}
+
+type AddGroupMembersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth    *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	GroupId string         `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserIds []int64        `protobuf:"varint,3,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+}
+
+func (x *AddGroupMembersRequest) Reset() {
+	*x = AddGroupMembersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupMembersRequest) ProtoMessage() {}
+
+func (x *AddGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*AddGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddGroupMembersRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *AddGroupMembersRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *AddGroupMembersRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type AddGroupMembersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddGroupMembersResponse) Reset() {
+	*x = AddGroupMembersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddGroupMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupMembersResponse) ProtoMessage() {}
+
+func (x *AddGroupMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupMembersResponse.ProtoReflect.Descriptor instead.
+func (*AddGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{37}
+}
+
+type RemoveGroupMembersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth    *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	GroupId string         `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserIds []int64        `protobuf:"varint,3,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+}
+
+func (x *RemoveGroupMembersRequest) Reset() {
+	*x = RemoveGroupMembersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupMembersRequest) ProtoMessage() {}
+
+func (x *RemoveGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*RemoveGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RemoveGroupMembersRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *RemoveGroupMembersRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *RemoveGroupMembersRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type RemoveGroupMembersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveGroupMembersResponse) Reset() {
+	*x = RemoveGroupMembersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveGroupMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupMembersResponse) ProtoMessage() {}
+
+func (x *RemoveGroupMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupMembersResponse.ProtoReflect.Descriptor instead.
+func (*RemoveGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{39}
+}
+
+type ListGroupMembersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Auth    *Authorization `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	GroupId string         `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// cursor is the next_cursor of the previous page, zero to start.
+	Cursor uint64 `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// limit is a hint of the page size, default 100.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListGroupMembersRequest) Reset() {
+	*x = ListGroupMembersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupMembersRequest) ProtoMessage() {}
+
+func (x *ListGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListGroupMembersRequest) GetAuth() *Authorization {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *ListGroupMembersRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListGroupMembersRequest) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListGroupMembersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListGroupMembersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UserIds []int64 `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	// next_cursor is zero when the iteration is done.
+	NextCursor uint64 `protobuf:"varint,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// total is the number of members of the group.
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *ListGroupMembersResponse) Reset() {
+	*x = ListGroupMembersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGroupMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupMembersResponse) ProtoMessage() {}
+
+func (x *ListGroupMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListGroupMembersResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListGroupMembersResponse) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *ListGroupMembersResponse) GetNextCursor() uint64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+func (x *ListGroupMembersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type PushTarget_Connections struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConnectionIds []string `protobuf:"bytes,1,rep,name=connection_ids,json=connectionIds,proto3" json:"connection_ids,omitempty"`
+}
+
+func (x *PushTarget_Connections) Reset() {
+	*x = PushTarget_Connections{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTarget_Connections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget_Connections) ProtoMessage() {}
+
+func (x *PushTarget_Connections) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget_Connections.ProtoReflect.Descriptor instead.
+func (*PushTarget_Connections) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *PushTarget_Connections) GetConnectionIds() []string {
+	if x != nil {
+		return x.ConnectionIds
+	}
+	return nil
+}
+
+type PushTarget_Users struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserIds []int64 `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+}
+
+func (x *PushTarget_Users) Reset() {
+	*x = PushTarget_Users{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTarget_Users) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget_Users) ProtoMessage() {}
+
+func (x *PushTarget_Users) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget_Users.ProtoReflect.Descriptor instead.
+func (*PushTarget_Users) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *PushTarget_Users) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type PushTarget_UserDevices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserDevices []*UserDevice `protobuf:"bytes,1,rep,name=user_devices,json=userDevices,proto3" json:"user_devices,omitempty"`
+}
+
+func (x *PushTarget_UserDevices) Reset() {
+	*x = PushTarget_UserDevices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTarget_UserDevices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget_UserDevices) ProtoMessage() {}
+
+func (x *PushTarget_UserDevices) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget_UserDevices.ProtoReflect.Descriptor instead.
+func (*PushTarget_UserDevices) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 2}
+}
+
+func (x *PushTarget_UserDevices) GetUserDevices() []*UserDevice {
+	if x != nil {
+		return x.UserDevices
+	}
+	return nil
+}
+
+type PushTarget_Devices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceIds []int64 `protobuf:"varint,1,rep,packed,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+}
+
+func (x *PushTarget_Devices) Reset() {
+	*x = PushTarget_Devices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTarget_Devices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget_Devices) ProtoMessage() {}
+
+func (x *PushTarget_Devices) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget_Devices.ProtoReflect.Descriptor instead.
+func (*PushTarget_Devices) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 3}
+}
+
+func (x *PushTarget_Devices) GetDeviceIds() []int64 {
+	if x != nil {
+		return x.DeviceIds
+	}
+	return nil
+}
+
+// Expression selects connections by a boolean expression over
+// connection attributes, eg.
+//
+//	platform == "ios" && version >= "3.1" && locale in ["en", "de"]
+//
+// Variables are id, app_id, user_id, device_id, client_ip, version,
+// platform, os, locale, network_type, machine_id, connect_time and
+// last_active_time (in milliseconds), tags and roles (lists), and
+// attr.<name> for custom attributes.
+type PushTarget_Expression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// If given, the expression is applied to connections of the users,
+	// else to all connections of the app.
+	UserIds []int64 `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+}
+
+func (x *PushTarget_Expression) Reset() {
+	*x = PushTarget_Expression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTarget_Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget_Expression) ProtoMessage() {}
+
+func (x *PushTarget_Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget_Expression.ProtoReflect.Descriptor instead.
+func (*PushTarget_Expression) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 4}
+}
+
+func (x *PushTarget_Expression) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *PushTarget_Expression) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type PushTarget_Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId string `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+}
+
+func (x *PushTarget_Group) Reset() {
+	*x = PushTarget_Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_brokersvc_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushTarget_Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushTarget_Group) ProtoMessage() {}
+
+func (x *PushTarget_Group) ProtoReflect() protoreflect.Message {
+	mi := &file_brokersvc_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushTarget_Group.ProtoReflect.Descriptor instead.
+func (*PushTarget_Group) Descriptor() ([]byte, []int) {
+	return file_brokersvc_proto_rawDescGZIP(), []int{8, 5}
+}
+
+func (x *PushTarget_Group) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+var File_brokersvc_proto protoreflect.FileDescriptor
+
+var file_brokersvc_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x1a, 0x0e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x45, 0x0a, 0x0d,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a,
+	0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61,
+	0x70, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x4b, 0x65, 0x79, 0x22, 0x9d, 0x01, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75,
+	0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x73, 0x22, 0xaa, 0x04, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f,
+	0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x5e, 0x0a, 0x12, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x4b, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x5c, 0x0a, 0x14,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5e, 0x0a, 0x16, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x54, 0x0a, 0x10, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x9a, 0x02, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61,
+	0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x77,
+	0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x13, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x74, 0x68, 0x69,
+	0x6e, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x72, 0x0a,
+	0x17, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x22, 0x76, 0x0a, 0x12, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x57, 0x69, 0x74, 0x68,
+	0x69, 0x6e, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x22, 0x67, 0x0a, 0x13, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x42, 0x0a, 0x0a, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x9c, 0x08, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e,
+	0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x45, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x05,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x12, 0x46, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x73,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e,
+	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x27, 0x0a,
+	0x0f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73,
+	0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x16, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0e, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x1a, 0x34, 0x0a, 0x0b, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x73, 0x1a, 0x22, 0x0a, 0x05, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x73, 0x1a, 0x47, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x1a, 0x28,
+	0x0a, 0x07, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x1a, 0x47, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x1a, 0x22, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x64, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a,
+	0x0a, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x53, 0x45, 0x52, 0x5f,
+	0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x55, 0x4e, 0x41, 0x55,
+	0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x44, 0x45, 0x56, 0x49,
+	0x43, 0x45, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x10, 0x04,
+	0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x05, 0x42, 0x08, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xc8, 0x02, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x12, 0x4b, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x97, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x2d,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75,
+	0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6a, 0x0a, 0x0b, 0x53, 0x79,
+	0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x2d, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xa1, 0x01, 0x0a, 0x10, 0x42, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c,
+	0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x32, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x36, 0x0a,
+	0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63,
+	0x61, 0x73, 0x74, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f,
+	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x64, 0x22, 0x17,
+	0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbe, 0x01, 0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x52, 0x06, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x12,
+	0x17, 0x0a, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x62, 0x69, 0x6e, 0x64, 0x49, 0x70, 0x22, 0x46, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74,
+	0x22, 0x59, 0x0a, 0x13, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x49, 0x0a, 0x14, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x22, 0x71, 0x0a, 0x12, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x5b, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x69, 0x64, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x69,
+	0x64, 0x72, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x43, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75,
+	0x74, 0x68, 0x22, 0x4a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x9c,
+	0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x03, 0x61, 0x64, 0x64, 0x12,
+	0x2c, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x19, 0x0a,
+	0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x61, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75,
+	0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0x4f, 0x0a, 0x19, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x71, 0x0a, 0x12,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x15, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7c, 0x0a, 0x16,
+	0x41, 0x64, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
+	0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x61, 0x75, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x41, 0x64,
+	0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7f, 0x0a, 0x19, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68,
+	0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x1c, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x90, 0x01, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x19,
+	0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x32, 0xa2, 0x0b, 0x0a, 0x06, 0x42,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x3a, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x58, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1d, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x50, 0x75, 0x73,
+	0x68, 0x12, 0x16, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75,
+	0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x72, 0x6f, 0x6b,
+	0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x16, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x42,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x73, 0x76, 0x63, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61, 0x64,
+	0x63, 0x61, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
+	0x2e, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4f, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f,
+	0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x1e,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x58, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x12, 0x21, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x23,
+	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
+	0x73, 0x76, 0x63, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73,
+	0x76, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x21, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65,
+	0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x64, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x61, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x24, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
+	0x63, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x62, 0x72,
+	0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x5b, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x22, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73,
+	0x76, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x62, 0x72, 0x6f,
+	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x78,
+	0x73, 0x6b, 0x69, 0x73, 0x73, 0x2f, 0x6e, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x67, 0x77, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x3b, 0x62,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_brokersvc_proto_rawDescOnce sync.Once
+	file_brokersvc_proto_rawDescData = file_brokersvc_proto_rawDesc
+)
+
+func file_brokersvc_proto_rawDescGZIP() []byte {
+	file_brokersvc_proto_rawDescOnce.Do(func() {
+		file_brokersvc_proto_rawDescData = protoimpl.X.CompressGZIP(file_brokersvc_proto_rawDescData)
+	})
+	return file_brokersvc_proto_rawDescData
+}
+
+var file_brokersvc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_brokersvc_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_brokersvc_proto_goTypes = []interface{}{
+	(PushTarget_Type)(0),               // 0: brokersvc.PushTarget.Type
+	(*Authorization)(nil),              // 1: brokersvc.Authorization
+	(*QueryRequest)(nil),               // 2: brokersvc.QueryRequest
+	(*QueryResponse)(nil),              // 3: brokersvc.QueryResponse
+	(*ListConnectionsRequest)(nil),     // 4: brokersvc.ListConnectionsRequest
+	(*ListConnectionsResponse)(nil),    // 5: brokersvc.ListConnectionsResponse
+	(*CountOnlineRequest)(nil),         // 6: brokersvc.CountOnlineRequest
+	(*CountOnlineResponse)(nil),        // 7: brokersvc.CountOnlineResponse
+	(*UserDevice)(nil),                 // 8: brokersvc.UserDevice
+	(*PushTarget)(nil),                 // 9: brokersvc.PushTarget
+	(*ConnectionFilter)(nil),           // 10: brokersvc.ConnectionFilter
+	(*PushRequest)(nil),                // 11: brokersvc.PushRequest
+	(*PushResponse)(nil),               // 12: brokersvc.PushResponse
+	(*SyncRequest)(nil),                // 13: brokersvc.SyncRequest
+	(*SyncResponse)(nil),               // 14: brokersvc.SyncResponse
+	(*BroadcastTarget)(nil),            // 15: brokersvc.BroadcastTarget
+	(*BroadcastRequest)(nil),           // 16: brokersvc.BroadcastRequest
+	(*BroadcastResponse)(nil),          // 17: brokersvc.BroadcastResponse
+	(*StopBroadcastRequest)(nil),       // 18: brokersvc.StopBroadcastRequest
+	(*StopBroadcastResponse)(nil),      // 19: brokersvc.StopBroadcastResponse
+	(*SignTokenRequest)(nil),           // 20: brokersvc.SignTokenRequest
+	(*SignTokenResponse)(nil),          // 21: brokersvc.SignTokenResponse
+	(*RefreshTokenRequest)(nil),        // 22: brokersvc.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),       // 23: brokersvc.RefreshTokenResponse
+	(*RevokeTokenRequest)(nil),         // 24: brokersvc.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),        // 25: brokersvc.RevokeTokenResponse
+	(*Blocklist)(nil),                  // 26: brokersvc.Blocklist
+	(*GetBlocklistRequest)(nil),        // 27: brokersvc.GetBlocklistRequest
+	(*GetBlocklistResponse)(nil),       // 28: brokersvc.GetBlocklistResponse
+	(*UpdateBlocklistRequest)(nil),     // 29: brokersvc.UpdateBlocklistRequest
+	(*UpdateBlocklistResponse)(nil),    // 30: brokersvc.UpdateBlocklistResponse
+	(*GetConnectionInfoRequest)(nil),   // 31: brokersvc.GetConnectionInfoRequest
+	(*GetConnectionInfoResponse)(nil),  // 32: brokersvc.GetConnectionInfoResponse
+	(*CreateGroupRequest)(nil),         // 33: brokersvc.CreateGroupRequest
+	(*CreateGroupResponse)(nil),        // 34: brokersvc.CreateGroupResponse
+	(*DeleteGroupRequest)(nil),         // 35: brokersvc.DeleteGroupRequest
+	(*DeleteGroupResponse)(nil),        // 36: brokersvc.DeleteGroupResponse
+	(*AddGroupMembersRequest)(nil),     // 37: brokersvc.AddGroupMembersRequest
+	(*AddGroupMembersResponse)(nil),    // 38: brokersvc.AddGroupMembersResponse
+	(*RemoveGroupMembersRequest)(nil),  // 39: brokersvc.RemoveGroupMembersRequest
+	(*RemoveGroupMembersResponse)(nil), // 40: brokersvc.RemoveGroupMembersResponse
+	(*ListGroupMembersRequest)(nil),    // 41: brokersvc.ListGroupMembersRequest
+	(*ListGroupMembersResponse)(nil),   // 42: brokersvc.ListGroupMembersResponse
+	nil,                                // 43: brokersvc.QueryResponse.UserConnectionsEntry
+	nil,                                // 44: brokersvc.QueryResponse.DeviceConnectionsEntry
+	nil,                                // 45: brokersvc.QueryResponse.ConnectionsEntry
+	(*PushTarget_Connections)(nil),     // 46: brokersvc.PushTarget.Connections
+	(*PushTarget_Users)(nil),           // 47: brokersvc.PushTarget.Users
+	(*PushTarget_UserDevices)(nil),     // 48: brokersvc.PushTarget.UserDevices
+	(*PushTarget_Devices)(nil),         // 49: brokersvc.PushTarget.Devices
+	(*PushTarget_Expression)(nil),      // 50: brokersvc.PushTarget.Expression
+	(*PushTarget_Group)(nil),           // 51: brokersvc.PushTarget.Group
+	nil,                                // 52: brokersvc.ConnectionFilter.AttributesEntry
+	(*protocol.Connection)(nil),        // 53: protocol.Connection
+	(*protocol.Content)(nil),           // 54: protocol.Content
+	(*protocol.TokenClaims)(nil),       // 55: protocol.TokenClaims
+	(*protocol.ConnectionDetail)(nil),  // 56: protocol.ConnectionDetail
+	(*protocol.ConnectionList)(nil),    // 57: protocol.ConnectionList
+}
+var file_brokersvc_proto_depIdxs = []int32{
+	1,  // 0: brokersvc.QueryRequest.auth:type_name -> brokersvc.Authorization
+	43, // 1: brokersvc.QueryResponse.user_connections:type_name -> brokersvc.QueryResponse.UserConnectionsEntry
+	44, // 2: brokersvc.QueryResponse.device_connections:type_name -> brokersvc.QueryResponse.DeviceConnectionsEntry
+	45, // 3: brokersvc.QueryResponse.connections:type_name -> brokersvc.QueryResponse.ConnectionsEntry
+	1,  // 4: brokersvc.ListConnectionsRequest.auth:type_name -> brokersvc.Authorization
+	10, // 5: brokersvc.ListConnectionsRequest.filter:type_name -> brokersvc.ConnectionFilter
+	53, // 6: brokersvc.ListConnectionsResponse.connections:type_name -> protocol.Connection
+	1,  // 7: brokersvc.CountOnlineRequest.auth:type_name -> brokersvc.Authorization
+	0,  // 8: brokersvc.PushTarget.type:type_name -> brokersvc.PushTarget.Type
+	46, // 9: brokersvc.PushTarget.connections:type_name -> brokersvc.PushTarget.Connections
+	47, // 10: brokersvc.PushTarget.users:type_name -> brokersvc.PushTarget.Users
+	48, // 11: brokersvc.PushTarget.user_devices:type_name -> brokersvc.PushTarget.UserDevices
+	49, // 12: brokersvc.PushTarget.devices:type_name -> brokersvc.PushTarget.Devices
+	50, // 13: brokersvc.PushTarget.expression:type_name -> brokersvc.PushTarget.Expression
+	51, // 14: brokersvc.PushTarget.group:type_name -> brokersvc.PushTarget.Group
+	10, // 15: brokersvc.PushTarget.filter:type_name -> brokersvc.ConnectionFilter
+	52, // 16: brokersvc.ConnectionFilter.attributes:type_name -> brokersvc.ConnectionFilter.AttributesEntry
+	1,  // 17: brokersvc.PushRequest.auth:type_name -> brokersvc.Authorization
+	9,  // 18: brokersvc.PushRequest.target:type_name -> brokersvc.PushTarget
+	54, // 19: brokersvc.PushRequest.content:type_name -> protocol.Content
+	1,  // 20: brokersvc.SyncRequest.auth:type_name -> brokersvc.Authorization
+	9,  // 21: brokersvc.SyncRequest.target:type_name -> brokersvc.PushTarget
+	1,  // 22: brokersvc.BroadcastRequest.auth:type_name -> brokersvc.Authorization
+	15, // 23: brokersvc.BroadcastRequest.target:type_name -> brokersvc.BroadcastTarget
+	54, // 24: brokersvc.BroadcastRequest.content:type_name -> protocol.Content
+	1,  // 25: brokersvc.StopBroadcastRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 26: brokersvc.SignTokenRequest.auth:type_name -> brokersvc.Authorization
+	55, // 27: brokersvc.SignTokenRequest.claims:type_name -> protocol.TokenClaims
+	1,  // 28: brokersvc.RefreshTokenRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 29: brokersvc.RevokeTokenRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 30: brokersvc.GetBlocklistRequest.auth:type_name -> brokersvc.Authorization
+	26, // 31: brokersvc.GetBlocklistResponse.blocklist:type_name -> brokersvc.Blocklist
+	1,  // 32: brokersvc.UpdateBlocklistRequest.auth:type_name -> brokersvc.Authorization
+	26, // 33: brokersvc.UpdateBlocklistRequest.add:type_name -> brokersvc.Blocklist
+	26, // 34: brokersvc.UpdateBlocklistRequest.remove:type_name -> brokersvc.Blocklist
+	1,  // 35: brokersvc.GetConnectionInfoRequest.auth:type_name -> brokersvc.Authorization
+	56, // 36: brokersvc.GetConnectionInfoResponse.detail:type_name -> protocol.ConnectionDetail
+	1,  // 37: brokersvc.CreateGroupRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 38: brokersvc.DeleteGroupRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 39: brokersvc.AddGroupMembersRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 40: brokersvc.RemoveGroupMembersRequest.auth:type_name -> brokersvc.Authorization
+	1,  // 41: brokersvc.ListGroupMembersRequest.auth:type_name -> brokersvc.Authorization
+	57, // 42: brokersvc.QueryResponse.UserConnectionsEntry.value:type_name -> protocol.ConnectionList
+	57, // 43: brokersvc.QueryResponse.DeviceConnectionsEntry.value:type_name -> protocol.ConnectionList
+	53, // 44: brokersvc.QueryResponse.ConnectionsEntry.value:type_name -> protocol.Connection
+	8,  // 45: brokersvc.PushTarget.UserDevices.user_devices:type_name -> brokersvc.UserDevice
+	2,  // 46: brokersvc.Broker.Query:input_type -> brokersvc.QueryRequest
+	4,  // 47: brokersvc.Broker.ListConnections:input_type -> brokersvc.ListConnectionsRequest
+	6,  // 48: brokersvc.Broker.CountOnline:input_type -> brokersvc.CountOnlineRequest
+	11, // 49: brokersvc.Broker.Push:input_type -> brokersvc.PushRequest
+	13, // 50: brokersvc.Broker.Sync:input_type -> brokersvc.SyncRequest
+	16, // 51: brokersvc.Broker.Broadcast:input_type -> brokersvc.BroadcastRequest
+	18, // 52: brokersvc.Broker.StopBroadcast:input_type -> brokersvc.StopBroadcastRequest
+	20, // 53: brokersvc.Broker.SignToken:input_type -> brokersvc.SignTokenRequest
+	22, // 54: brokersvc.Broker.RefreshToken:input_type -> brokersvc.RefreshTokenRequest
+	24, // 55: brokersvc.Broker.RevokeToken:input_type -> brokersvc.RevokeTokenRequest
+	27, // 56: brokersvc.Broker.GetBlocklist:input_type -> brokersvc.GetBlocklistRequest
+	29, // 57: brokersvc.Broker.UpdateBlocklist:input_type -> brokersvc.UpdateBlocklistRequest
+	31, // 58: brokersvc.Broker.GetConnectionInfo:input_type -> brokersvc.GetConnectionInfoRequest
+	33, // 59: brokersvc.Broker.CreateGroup:input_type -> brokersvc.CreateGroupRequest
+	35, // 60: brokersvc.Broker.DeleteGroup:input_type -> brokersvc.DeleteGroupRequest
+	37, // 61: brokersvc.Broker.AddGroupMembers:input_type -> brokersvc.AddGroupMembersRequest
+	39, // 62: brokersvc.Broker.RemoveGroupMembers:input_type -> brokersvc.RemoveGroupMembersRequest
+	41, // 63: brokersvc.Broker.ListGroupMembers:input_type -> brokersvc.ListGroupMembersRequest
+	3,  // 64: brokersvc.Broker.Query:output_type -> brokersvc.QueryResponse
+	5,  // 65: brokersvc.Broker.ListConnections:output_type -> brokersvc.ListConnectionsResponse
+	7,  // 66: brokersvc.Broker.CountOnline:output_type -> brokersvc.CountOnlineResponse
+	12, // 67: brokersvc.Broker.Push:output_type -> brokersvc.PushResponse
+	14, // 68: brokersvc.Broker.Sync:output_type -> brokersvc.SyncResponse
+	17, // 69: brokersvc.Broker.Broadcast:output_type -> brokersvc.BroadcastResponse
+	19, // 70: brokersvc.Broker.StopBroadcast:output_type -> brokersvc.StopBroadcastResponse
+	21, // 71: brokersvc.Broker.SignToken:output_type -> brokersvc.SignTokenResponse
+	23, // 72: brokersvc.Broker.RefreshToken:output_type -> brokersvc.RefreshTokenResponse
+	25, // 73: brokersvc.Broker.RevokeToken:output_type -> brokersvc.RevokeTokenResponse
+	28, // 74: brokersvc.Broker.GetBlocklist:output_type -> brokersvc.GetBlocklistResponse
+	30, // 75: brokersvc.Broker.UpdateBlocklist:output_type -> brokersvc.UpdateBlocklistResponse
+	32, // 76: brokersvc.Broker.GetConnectionInfo:output_type -> brokersvc.GetConnectionInfoResponse
+	34, // 77: brokersvc.Broker.CreateGroup:output_type -> brokersvc.CreateGroupResponse
+	36, // 78: brokersvc.Broker.DeleteGroup:output_type -> brokersvc.DeleteGroupResponse
+	38, // 79: brokersvc.Broker.AddGroupMembers:output_type -> brokersvc.AddGroupMembersResponse
+	40, // 80: brokersvc.Broker.RemoveGroupMembers:output_type -> brokersvc.RemoveGroupMembersResponse
+	42, // 81: brokersvc.Broker.ListGroupMembers:output_type -> brokersvc.ListGroupMembersResponse
+	64, // [64:82] is the sub-list for method output_type
+	46, // [46:64] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
+}
+
+func init() { file_brokersvc_proto_init() }
+func file_brokersvc_proto_init() {
+	if File_brokersvc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_brokersvc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Authorization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListConnectionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListConnectionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountOnlineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountOnlineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BroadcastResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopBroadcastRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopBroadcastResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PushTarget_UserDevices.ProtoReflect.Descriptor instead.
-func (*PushTarget_UserDevices) Descriptor() ([]byte, []int) {
-	return file_brokersvc_proto_rawDescGZIP(), []int{4, 2}
-}
-
-func (x *PushTarget_UserDevices) GetUserDevices() []*UserDevice {
-	if x != nil {
-		return x.UserDevices
-	}
-	return nil
-}
-
-type PushTarget_Devices struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	DeviceIds []int64 `protobuf:"varint,1,rep,packed,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
-}
-
-func (x *PushTarget_Devices) Reset() {
-	*x = PushTarget_Devices{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_brokersvc_proto_msgTypes[21]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *PushTarget_Devices) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PushTarget_Devices) ProtoMessage() {}
-
-func (x *PushTarget_Devices) ProtoReflect() protoreflect.Message {
-	mi := &file_brokersvc_proto_msgTypes[21]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		file_brokersvc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PushTarget_Devices.ProtoReflect.Descriptor instead.
-func (*PushTarget_Devices) Descriptor() ([]byte, []int) {
-	return file_brokersvc_proto_rawDescGZIP(), []int{4, 3}
-}
-
-func (x *PushTarget_Devices) GetDeviceIds() []int64 {
-	if x != nil {
-		return x.DeviceIds
-	}
-	return nil
-}
-
-var File_brokersvc_proto protoreflect.FileDescriptor
-
-var file_brokersvc_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x1a, 0x0e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x45, 0x0a, 0x0d,
-	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a,
-	0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61,
-	0x70, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b,
-	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x4b, 0x65, 0x79, 0x22, 0x76, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74,
-	0x68, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03,
-	0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x87, 0x03, 0x0a, 0x0d,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a,
-	0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
-	0x73, 0x76, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5e, 0x0a, 0x12, 0x64, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x5c, 0x0a, 0x14, 0x55, 0x73, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5e, 0x0a, 0x16, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x42, 0x0a, 0x0a, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x8a, 0x05, 0x0a, 0x0a, 0x50, 0x75,
-	0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73,
-	0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x45, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x48, 0x00, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x33, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x05, 0x75,
-	0x73, 0x65, 0x72, 0x73, 0x12, 0x46, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x72, 0x6f,
-	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52,
-	0x0b, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x07,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x1a, 0x34, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x1a, 0x22, 0x0a, 0x05, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12,
-	0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x1a, 0x47, 0x0a, 0x0b, 0x55, 0x73,
-	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0c, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x1a, 0x28, 0x0a, 0x07, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1d,
-	0x0a, 0x0a, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22, 0x4d, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54,
-	0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12,
-	0x0f, 0x0a, 0x0b, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x02,
-	0x12, 0x1a, 0x0a, 0x16, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
-	0x54, 0x45, 0x44, 0x5f, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x03, 0x42, 0x08, 0x0a, 0x06,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x97, 0x01, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
-	0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
-	0x61, 0x75, 0x74, 0x68, 0x12, 0x2d, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
-	0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x0e, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x6a, 0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x2d, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x0e, 0x0a, 0x0c,
-	0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x0a, 0x0f,
-	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22,
-	0xa1, 0x01, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75,
-	0x74, 0x68, 0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x42,
-	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x22, 0x36, 0x0a, 0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x14, 0x53,
-	0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74,
-	0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
-	0x73, 0x74, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61,
-	0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x76, 0x0a,
-	0x10, 0x53, 0x69, 0x67, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x2c, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x18, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12,
-	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x46, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x32, 0x9a, 0x03,
-	0x0a, 0x06, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x3a, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x12, 0x17, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x72, 0x6f,
-	0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x16, 0x2e, 0x62,
-	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63,
-	0x2e, 0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a,
-	0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x16, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76,
-	0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63,
-	0x61, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e,
-	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1c, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x42, 0x72, 0x6f,
-	0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
-	0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x12,
-	0x1f, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x74, 0x6f, 0x70,
-	0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x20, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x74, 0x6f,
-	0x70, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x46, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x1b, 0x2e, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62,
-	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73,
-	0x2f, 0x6e, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x67, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x73, 0x76, 0x63, 0x3b, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72,
-	0x73, 0x76, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_brokersvc_proto_rawDescOnce sync.Once
-	file_brokersvc_proto_rawDescData = file_brokersvc_proto_rawDesc
-)
-
-func file_brokersvc_proto_rawDescGZIP() []byte {
-	file_brokersvc_proto_rawDescOnce.Do(func() {
-		file_brokersvc_proto_rawDescData = protoimpl.X.CompressGZIP(file_brokersvc_proto_rawDescData)
-	})
-	return file_brokersvc_proto_rawDescData
-}
-
-var file_brokersvc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_brokersvc_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
-var file_brokersvc_proto_goTypes = []interface{}{
-	(PushTarget_Type)(0),            // 0: brokersvc.PushTarget.Type
-	(*Authorization)(nil),           // 1: brokersvc.Authorization
-	(*QueryRequest)(nil),            // 2: brokersvc.QueryRequest
-	(*QueryResponse)(nil),           // 3: brokersvc.QueryResponse
-	(*UserDevice)(nil),              // 4: brokersvc.UserDevice
-	(*PushTarget)(nil),              // 5: brokersvc.PushTarget
-	(*PushRequest)(nil),             // 6: brokersvc.PushRequest
-	(*PushResponse)(nil),            // 7: brokersvc.PushResponse
-	(*SyncRequest)(nil),             // 8: brokersvc.SyncRequest
-	(*SyncResponse)(nil),            // 9: brokersvc.SyncResponse
-	(*BroadcastTarget)(nil),         // 10: brokersvc.BroadcastTarget
-	(*BroadcastRequest)(nil),        // 11: brokersvc.BroadcastRequest
-	(*BroadcastResponse)(nil),       // 12: brokersvc.BroadcastResponse
-	(*StopBroadcastRequest)(nil),    // 13: brokersvc.StopBroadcastRequest
-	(*StopBroadcastResponse)(nil),   // 14: brokersvc.StopBroadcastResponse
-	(*SignTokenRequest)(nil),        // 15: brokersvc.SignTokenRequest
-	(*SignTokenResponse)(nil),       // 16: brokersvc.SignTokenResponse
-	nil,                             // 17: brokersvc.QueryResponse.UserConnectionsEntry
-	nil,                             // 18: brokersvc.QueryResponse.DeviceConnectionsEntry
-	(*PushTarget_Connections)(nil),  // 19: brokersvc.PushTarget.Connections
-	(*PushTarget_Users)(nil),        // 20: brokersvc.PushTarget.Users
-	(*PushTarget_UserDevices)(nil),  // 21: brokersvc.PushTarget.UserDevices
-	(*PushTarget_Devices)(nil),      // 22: brokersvc.PushTarget.Devices
-	(*protocol.Content)(nil),        // 23: protocol.Content
-	(*protocol.ConnectionList)(nil), // 24: protocol.ConnectionList
-}
-var file_brokersvc_proto_depIdxs = []int32{
-	1,  // 0: brokersvc.QueryRequest.auth:type_name -> brokersvc.Authorization
-	17, // 1: brokersvc.QueryResponse.user_connections:type_name -> brokersvc.QueryResponse.UserConnectionsEntry
-	18, // 2: brokersvc.QueryResponse.device_connections:type_name -> brokersvc.QueryResponse.DeviceConnectionsEntry
-	0,  // 3: brokersvc.PushTarget.type:type_name -> brokersvc.PushTarget.Type
-	19, // 4: brokersvc.PushTarget.connections:type_name -> brokersvc.PushTarget.Connections
-	20, // 5: brokersvc.PushTarget.users:type_name -> brokersvc.PushTarget.Users
-	21, // 6: brokersvc.PushTarget.user_devices:type_name -> brokersvc.PushTarget.UserDevices
-	22, // 7: brokersvc.PushTarget.devices:type_name -> brokersvc.PushTarget.Devices
-	1,  // 8: brokersvc.PushRequest.auth:type_name -> brokersvc.Authorization
-	5,  // 9: brokersvc.PushRequest.target:type_name -> brokersvc.PushTarget
-	23, // 10: brokersvc.PushRequest.content:type_name -> protocol.Content
-	1,  // 11: brokersvc.SyncRequest.auth:type_name -> brokersvc.Authorization
-	5,  // 12: brokersvc.SyncRequest.target:type_name -> brokersvc.PushTarget
-	1,  // 13: brokersvc.BroadcastRequest.auth:type_name -> brokersvc.Authorization
-	10, // 14: brokersvc.BroadcastRequest.target:type_name -> brokersvc.BroadcastTarget
-	23, // 15: brokersvc.BroadcastRequest.content:type_name -> protocol.Content
-	1,  // 16: brokersvc.StopBroadcastRequest.auth:type_name -> brokersvc.Authorization
-	1,  // 17: brokersvc.SignTokenRequest.auth:type_name -> brokersvc.Authorization
-	24, // 18: brokersvc.QueryResponse.UserConnectionsEntry.value:type_name -> protocol.ConnectionList
-	24, // 19: brokersvc.QueryResponse.DeviceConnectionsEntry.value:type_name -> protocol.ConnectionList
-	4,  // 20: brokersvc.PushTarget.UserDevices.user_devices:type_name -> brokersvc.UserDevice
-	2,  // 21: brokersvc.Broker.Query:input_type -> brokersvc.QueryRequest
-	6,  // 22: brokersvc.Broker.Push:input_type -> brokersvc.PushRequest
-	8,  // 23: brokersvc.Broker.Sync:input_type -> brokersvc.SyncRequest
-	11, // 24: brokersvc.Broker.Broadcast:input_type -> brokersvc.BroadcastRequest
-	13, // 25: brokersvc.Broker.StopBroadcast:input_type -> brokersvc.StopBroadcastRequest
-	15, // 26: brokersvc.Broker.SignToken:input_type -> brokersvc.SignTokenRequest
-	3,  // 27: brokersvc.Broker.Query:output_type -> brokersvc.QueryResponse
-	7,  // 28: brokersvc.Broker.Push:output_type -> brokersvc.PushResponse
-	9,  // 29: brokersvc.Broker.Sync:output_type -> brokersvc.SyncResponse
-	12, // 30: brokersvc.Broker.Broadcast:output_type -> brokersvc.BroadcastResponse
-	14, // 31: brokersvc.Broker.StopBroadcast:output_type -> brokersvc.StopBroadcastResponse
-	16, // 32: brokersvc.Broker.SignToken:output_type -> brokersvc.SignTokenResponse
-	27, // [27:33] is the sub-list for method output_type
-	21, // [21:27] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
-}
-
-func init() { file_brokersvc_proto_init() }
-func file_brokersvc_proto_init() {
-	if File_brokersvc_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_brokersvc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Authorization); i {
+		file_brokersvc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1439,8 +3792,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryRequest); i {
+		file_brokersvc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Blocklist); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1451,8 +3804,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryResponse); i {
+		file_brokersvc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlocklistRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1463,8 +3816,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserDevice); i {
+		file_brokersvc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlocklistResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1475,8 +3828,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushTarget); i {
+		file_brokersvc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateBlocklistRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1487,8 +3840,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushRequest); i {
+		file_brokersvc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateBlocklistResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1499,8 +3852,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushResponse); i {
+		file_brokersvc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConnectionInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1511,8 +3864,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncRequest); i {
+		file_brokersvc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConnectionInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1523,8 +3876,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncResponse); i {
+		file_brokersvc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1535,8 +3888,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastTarget); i {
+		file_brokersvc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateGroupResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1547,8 +3900,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastRequest); i {
+		file_brokersvc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1559,8 +3912,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BroadcastResponse); i {
+		file_brokersvc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteGroupResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1571,8 +3924,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopBroadcastRequest); i {
+		file_brokersvc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddGroupMembersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1583,8 +3936,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopBroadcastResponse); i {
+		file_brokersvc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddGroupMembersResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1595,8 +3948,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignTokenRequest); i {
+		file_brokersvc_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveGroupMembersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1607,8 +3960,8 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignTokenResponse); i {
+		file_brokersvc_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveGroupMembersResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1619,7 +3972,31 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_brokersvc_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGroupMembersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGroupMembersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PushTarget_Connections); i {
 			case 0:
 				return &v.state
@@ -1631,7 +4008,7 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_brokersvc_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PushTarget_Users); i {
 			case 0:
 				return &v.state
@@ -1643,7 +4020,7 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_brokersvc_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PushTarget_UserDevices); i {
 			case 0:
 				return &v.state
@@ -1655,7 +4032,7 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
-		file_brokersvc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_brokersvc_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PushTarget_Devices); i {
 			case 0:
 				return &v.state
@@ -1667,12 +4044,38 @@ func file_brokersvc_proto_init() {
 				return nil
 			}
 		}
+		file_brokersvc_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushTarget_Expression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_brokersvc_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushTarget_Group); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_brokersvc_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_brokersvc_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*PushTarget_Connections_)(nil),
 		(*PushTarget_Users_)(nil),
 		(*PushTarget_UserDevices_)(nil),
 		(*PushTarget_Devices_)(nil),
+		(*PushTarget_Expression_)(nil),
+		(*PushTarget_Group_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1680,7 +4083,7 @@ func file_brokersvc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_brokersvc_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   22,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
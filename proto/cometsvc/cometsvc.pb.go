@@ -8,6 +8,7 @@ package cometsvc
 
 import (
 	messag "github.com/jxskiss/nonamegw/proto/messag"
+	protocol "github.com/jxskiss/nonamegw/proto/protocol"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -25,8 +26,19 @@ type AuthToken_VerifyCode int32
 
 const (
 	AuthToken_SUCCESS AuthToken_VerifyCode = 0
+	// The token is not signed by broker, or does not exist.
 	AuthToken_INVALID AuthToken_VerifyCode = 1
+	// The token is expired, client should fetch a new token.
 	AuthToken_EXPIRED AuthToken_VerifyCode = 2
+	// The token is revoked or has already been used, client should
+	// not retry with it.
+	AuthToken_REVOKED         AuthToken_VerifyCode = 3
+	AuthToken_UNKNOWN_VERSION AuthToken_VerifyCode = 4
+	AuthToken_MALFORMED       AuthToken_VerifyCode = 5
+	// The token is bound to another client IP.
+	AuthToken_IP_MISMATCH AuthToken_VerifyCode = 6
+	// The client IP, user or device is in the blocklist of the app.
+	AuthToken_BLOCKED AuthToken_VerifyCode = 7
 )
 
 // Enum value maps for AuthToken_VerifyCode.
@@ -35,11 +47,21 @@ var (
 		0: "SUCCESS",
 		1: "INVALID",
 		2: "EXPIRED",
+		3: "REVOKED",
+		4: "UNKNOWN_VERSION",
+		5: "MALFORMED",
+		6: "IP_MISMATCH",
+		7: "BLOCKED",
 	}
 	AuthToken_VerifyCode_value = map[string]int32{
-		"SUCCESS": 0,
-		"INVALID": 1,
-		"EXPIRED": 2,
+		"SUCCESS":         0,
+		"INVALID":         1,
+		"EXPIRED":         2,
+		"REVOKED":         3,
+		"UNKNOWN_VERSION": 4,
+		"MALFORMED":       5,
+		"IP_MISMATCH":     6,
+		"BLOCKED":         7,
 	}
 )
 
@@ -67,7 +89,64 @@ func (x AuthToken_VerifyCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AuthToken_VerifyCode.Descriptor instead.
 func (AuthToken_VerifyCode) EnumDescriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{0, 0}
+	return file_cometsvc_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// RpcError is carried in response envelopes when a RPC fails,
+// code is the error code registered in pkg/errcode.
+type RpcError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *RpcError) Reset() {
+	*x = RpcError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cometsvc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcError) ProtoMessage() {}
+
+func (x *RpcError) ProtoReflect() protoreflect.Message {
+	mi := &file_cometsvc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcError.ProtoReflect.Descriptor instead.
+func (*RpcError) Descriptor() ([]byte, []int) {
+	return file_cometsvc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RpcError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *RpcError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
 type AuthToken struct {
@@ -75,17 +154,20 @@ type AuthToken struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token        string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	SignTimeMsec int64  `protobuf:"varint,2,opt,name=sign_time_msec,json=signTimeMsec,proto3" json:"sign_time_msec,omitempty"`
-	AppId        int64  `protobuf:"varint,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	UserId       int64  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DeviceId     int64  `protobuf:"varint,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Token          string                `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	SignTimeMsec   int64                 `protobuf:"varint,2,opt,name=sign_time_msec,json=signTimeMsec,proto3" json:"sign_time_msec,omitempty"`
+	AppId          int64                 `protobuf:"varint,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	UserId         int64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId       int64                 `protobuf:"varint,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	ExpireTimeMsec int64                 `protobuf:"varint,6,opt,name=expire_time_msec,json=expireTimeMsec,proto3" json:"expire_time_msec,omitempty"`
+	Claims         *protocol.TokenClaims `protobuf:"bytes,7,opt,name=claims,proto3" json:"claims,omitempty"`
+	BindIp         string                `protobuf:"bytes,8,opt,name=bind_ip,json=bindIp,proto3" json:"bind_ip,omitempty"`
 }
 
 func (x *AuthToken) Reset() {
 	*x = AuthToken{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[0]
+		mi := &file_cometsvc_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -98,7 +180,7 @@ func (x *AuthToken) String() string {
 func (*AuthToken) ProtoMessage() {}
 
 func (x *AuthToken) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[0]
+	mi := &file_cometsvc_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +193,7 @@ func (x *AuthToken) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthToken.ProtoReflect.Descriptor instead.
 func (*AuthToken) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{0}
+	return file_cometsvc_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *AuthToken) GetToken() string {
@@ -149,6 +231,27 @@ func (x *AuthToken) GetDeviceId() int64 {
 	return 0
 }
 
+func (x *AuthToken) GetExpireTimeMsec() int64 {
+	if x != nil {
+		return x.ExpireTimeMsec
+	}
+	return 0
+}
+
+func (x *AuthToken) GetClaims() *protocol.TokenClaims {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *AuthToken) GetBindIp() string {
+	if x != nil {
+		return x.BindIp
+	}
+	return ""
+}
+
 type VerifyAuthTokenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -161,7 +264,7 @@ type VerifyAuthTokenRequest struct {
 func (x *VerifyAuthTokenRequest) Reset() {
 	*x = VerifyAuthTokenRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[1]
+		mi := &file_cometsvc_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -174,7 +277,7 @@ func (x *VerifyAuthTokenRequest) String() string {
 func (*VerifyAuthTokenRequest) ProtoMessage() {}
 
 func (x *VerifyAuthTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[1]
+	mi := &file_cometsvc_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,7 +290,7 @@ func (x *VerifyAuthTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyAuthTokenRequest.ProtoReflect.Descriptor instead.
 func (*VerifyAuthTokenRequest) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{1}
+	return file_cometsvc_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *VerifyAuthTokenRequest) GetToken() string {
@@ -216,7 +319,7 @@ type VerifyAuthTokenResponse struct {
 func (x *VerifyAuthTokenResponse) Reset() {
 	*x = VerifyAuthTokenResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[2]
+		mi := &file_cometsvc_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -229,7 +332,7 @@ func (x *VerifyAuthTokenResponse) String() string {
 func (*VerifyAuthTokenResponse) ProtoMessage() {}
 
 func (x *VerifyAuthTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[2]
+	mi := &file_cometsvc_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -242,7 +345,7 @@ func (x *VerifyAuthTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyAuthTokenResponse.ProtoReflect.Descriptor instead.
 func (*VerifyAuthTokenResponse) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{2}
+	return file_cometsvc_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *VerifyAuthTokenResponse) GetCode() AuthToken_VerifyCode {
@@ -268,7 +371,7 @@ type GetCometConfigurationRequest struct {
 func (x *GetCometConfigurationRequest) Reset() {
 	*x = GetCometConfigurationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[3]
+		mi := &file_cometsvc_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -281,7 +384,7 @@ func (x *GetCometConfigurationRequest) String() string {
 func (*GetCometConfigurationRequest) ProtoMessage() {}
 
 func (x *GetCometConfigurationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[3]
+	mi := &file_cometsvc_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -294,7 +397,7 @@ func (x *GetCometConfigurationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCometConfigurationRequest.ProtoReflect.Descriptor instead.
 func (*GetCometConfigurationRequest) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{3}
+	return file_cometsvc_proto_rawDescGZIP(), []int{4}
 }
 
 type GetCometConfigurationResponse struct {
@@ -308,7 +411,7 @@ type GetCometConfigurationResponse struct {
 func (x *GetCometConfigurationResponse) Reset() {
 	*x = GetCometConfigurationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[4]
+		mi := &file_cometsvc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -321,7 +424,7 @@ func (x *GetCometConfigurationResponse) String() string {
 func (*GetCometConfigurationResponse) ProtoMessage() {}
 
 func (x *GetCometConfigurationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[4]
+	mi := &file_cometsvc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,7 +437,7 @@ func (x *GetCometConfigurationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCometConfigurationResponse.ProtoReflect.Descriptor instead.
 func (*GetCometConfigurationResponse) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{4}
+	return file_cometsvc_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetCometConfigurationResponse) GetConfiguration() *messag.CometConfiguration {
@@ -358,7 +461,7 @@ type BrokerRequest struct {
 func (x *BrokerRequest) Reset() {
 	*x = BrokerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[5]
+		mi := &file_cometsvc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -371,7 +474,7 @@ func (x *BrokerRequest) String() string {
 func (*BrokerRequest) ProtoMessage() {}
 
 func (x *BrokerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[5]
+	mi := &file_cometsvc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +487,7 @@ func (x *BrokerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BrokerRequest.ProtoReflect.Descriptor instead.
 func (*BrokerRequest) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{5}
+	return file_cometsvc_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *BrokerRequest) GetRequest() isBrokerRequest_Request {
@@ -433,12 +536,13 @@ type BrokerResponse struct {
 	//	*BrokerResponse_VerifyAuthTokenResponse
 	//	*BrokerResponse_GetCometConfigurationResponse
 	Response isBrokerResponse_Response `protobuf_oneof:"response"`
+	Error    *RpcError                 `protobuf:"bytes,100,opt,name=error,proto3" json:"error,omitempty"`
 }
 
 func (x *BrokerResponse) Reset() {
 	*x = BrokerResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[6]
+		mi := &file_cometsvc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -451,7 +555,7 @@ func (x *BrokerResponse) String() string {
 func (*BrokerResponse) ProtoMessage() {}
 
 func (x *BrokerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[6]
+	mi := &file_cometsvc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +568,7 @@ func (x *BrokerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BrokerResponse.ProtoReflect.Descriptor instead.
 func (*BrokerResponse) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{6}
+	return file_cometsvc_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *BrokerResponse) GetResponse() isBrokerResponse_Response {
@@ -488,6 +592,13 @@ func (x *BrokerResponse) GetGetCometConfigurationResponse() *GetCometConfigurati
 	return nil
 }
 
+func (x *BrokerResponse) GetError() *RpcError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
 type isBrokerResponse_Response interface {
 	isBrokerResponse_Response()
 }
@@ -515,7 +626,7 @@ type GetConnectionInfoRequest struct {
 func (x *GetConnectionInfoRequest) Reset() {
 	*x = GetConnectionInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[7]
+		mi := &file_cometsvc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -528,7 +639,7 @@ func (x *GetConnectionInfoRequest) String() string {
 func (*GetConnectionInfoRequest) ProtoMessage() {}
 
 func (x *GetConnectionInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[7]
+	mi := &file_cometsvc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +652,7 @@ func (x *GetConnectionInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConnectionInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetConnectionInfoRequest) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{7}
+	return file_cometsvc_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetConnectionInfoRequest) GetConnId() string {
@@ -555,12 +666,14 @@ type GetConnectionInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Detail *protocol.ConnectionDetail `protobuf:"bytes,1,opt,name=detail,proto3" json:"detail,omitempty"`
 }
 
 func (x *GetConnectionInfoResponse) Reset() {
 	*x = GetConnectionInfoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[8]
+		mi := &file_cometsvc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -573,7 +686,7 @@ func (x *GetConnectionInfoResponse) String() string {
 func (*GetConnectionInfoResponse) ProtoMessage() {}
 
 func (x *GetConnectionInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[8]
+	mi := &file_cometsvc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,7 +699,14 @@ func (x *GetConnectionInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConnectionInfoResponse.ProtoReflect.Descriptor instead.
 func (*GetConnectionInfoResponse) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{8}
+	return file_cometsvc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetConnectionInfoResponse) GetDetail() *protocol.ConnectionDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
 }
 
 type CometRequest struct {
@@ -602,7 +722,7 @@ type CometRequest struct {
 func (x *CometRequest) Reset() {
 	*x = CometRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[9]
+		mi := &file_cometsvc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -615,7 +735,7 @@ func (x *CometRequest) String() string {
 func (*CometRequest) ProtoMessage() {}
 
 func (x *CometRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[9]
+	mi := &file_cometsvc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +748,7 @@ func (x *CometRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CometRequest.ProtoReflect.Descriptor instead.
 func (*CometRequest) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{9}
+	return file_cometsvc_proto_rawDescGZIP(), []int{10}
 }
 
 func (m *CometRequest) GetRequest() isCometRequest_Request {
@@ -663,12 +783,13 @@ type CometResponse struct {
 	// Types that are assignable to Response:
 	//	*CometResponse_GetConnectionInfoResponse
 	Response isCometResponse_Response `protobuf_oneof:"response"`
+	Error    *RpcError                `protobuf:"bytes,100,opt,name=error,proto3" json:"error,omitempty"`
 }
 
 func (x *CometResponse) Reset() {
 	*x = CometResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cometsvc_proto_msgTypes[10]
+		mi := &file_cometsvc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -681,7 +802,7 @@ func (x *CometResponse) String() string {
 func (*CometResponse) ProtoMessage() {}
 
 func (x *CometResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cometsvc_proto_msgTypes[10]
+	mi := &file_cometsvc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,7 +815,7 @@ func (x *CometResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CometResponse.ProtoReflect.Descriptor instead.
 func (*CometResponse) Descriptor() ([]byte, []int) {
-	return file_cometsvc_proto_rawDescGZIP(), []int{10}
+	return file_cometsvc_proto_rawDescGZIP(), []int{11}
 }
 
 func (m *CometResponse) GetResponse() isCometResponse_Response {
@@ -711,6 +832,13 @@ func (x *CometResponse) GetGetConnectionInfoResponse() *GetConnectionInfoRespons
 	return nil
 }
 
+func (x *CometResponse) GetError() *RpcError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
 type isCometResponse_Response interface {
 	isCometResponse_Response()
 }
@@ -726,95 +854,120 @@ var File_cometsvc_proto protoreflect.FileDescriptor
 var file_cometsvc_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x08, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x1a, 0x0c, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x01, 0x0a, 0x09, 0x41, 0x75, 0x74,
-	0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0e,
-	0x73, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73,
-	0x65, 0x63, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x61, 0x70, 0x70, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22,
-	0x33, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a,
-	0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e,
-	0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52,
-	0x45, 0x44, 0x10, 0x02, 0x22, 0x4b, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75,
-	0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69,
-	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
-	0x70, 0x22, 0x78, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x04,
-	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x63, 0x6f, 0x6d,
-	0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x2e,
-	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
-	0x12, 0x29, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x1e, 0x0a, 0x1c, 0x47,
-	0x65, 0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x61, 0x0a, 0x1d, 0x47,
-	0x65, 0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0d,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x2e, 0x43, 0x6f, 0x6d,
-	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe4,
-	0x01, 0x0a, 0x0d, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x5a, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x48, 0x00, 0x52, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6c, 0x0a, 0x1c,
-	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65,
-	0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x1c, 0x47, 0x65,
-	0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xec, 0x01, 0x0a, 0x0e, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x65,
-	0x74, 0x73, 0x76, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x17,
-	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x38, 0x0a, 0x08, 0x52, 0x70, 0x63, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x8b, 0x03, 0x0a, 0x09, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x15, 0x0a, 0x06,
+	0x61, 0x70, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61, 0x70,
+	0x70, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d,
+	0x73, 0x65, 0x63, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x52, 0x06, 0x63, 0x6c, 0x61, 0x69,
+	0x6d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x69, 0x70, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x64, 0x49, 0x70, 0x22, 0x82, 0x01, 0x0a, 0x0a,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55,
+	0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10,
+	0x02, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x44, 0x10, 0x03, 0x12, 0x13,
+	0x0a, 0x0f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f,
+	0x4e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x41, 0x4c, 0x46, 0x4f, 0x52, 0x4d, 0x45, 0x44,
+	0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x50, 0x5f, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54, 0x43,
+	0x48, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x07,
+	0x22, 0x4b, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x70, 0x22, 0x78, 0x0a,
+	0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76,
+	0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f,
+	0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x1e, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x61, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x6f,
 	0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe4, 0x01, 0x0a, 0x0d, 0x42,
+	0x72, 0x6f, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5a, 0x0a, 0x16,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63,
+	0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75,
+	0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6c, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
 	0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d,
 	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x6f,
-	0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x17, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7b, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x1c, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x96, 0x02, 0x0a, 0x0e, 0x42, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75,
+	0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x17, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6f, 0x6d,
+	0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x1d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x64, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x52,
+	0x70, 0x63, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x0a,
+	0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x22,
+	0x4f, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x22, 0x7b, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x60, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
 	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
-	0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x18,
-	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
-	0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
-	0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73, 0x2f, 0x6e, 0x6f, 0x6e,
-	0x61, 0x6d, 0x65, 0x67, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x65,
-	0x74, 0x73, 0x76, 0x63, 0x3b, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xaa, 0x01,
+	0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x63, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x19, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x64, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x2e, 0x52,
+	0x70, 0x63, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x0a,
+	0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73,
+	0x2f, 0x6e, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x67, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76, 0x63, 0x3b, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x76,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -830,37 +983,44 @@ func file_cometsvc_proto_rawDescGZIP() []byte {
 }
 
 var file_cometsvc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_cometsvc_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_cometsvc_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_cometsvc_proto_goTypes = []interface{}{
 	(AuthToken_VerifyCode)(0),             // 0: cometsvc.AuthToken.VerifyCode
-	(*AuthToken)(nil),                     // 1: cometsvc.AuthToken
-	(*VerifyAuthTokenRequest)(nil),        // 2: cometsvc.VerifyAuthTokenRequest
-	(*VerifyAuthTokenResponse)(nil),       // 3: cometsvc.VerifyAuthTokenResponse
-	(*GetCometConfigurationRequest)(nil),  // 4: cometsvc.GetCometConfigurationRequest
-	(*GetCometConfigurationResponse)(nil), // 5: cometsvc.GetCometConfigurationResponse
-	(*BrokerRequest)(nil),                 // 6: cometsvc.BrokerRequest
-	(*BrokerResponse)(nil),                // 7: cometsvc.BrokerResponse
-	(*GetConnectionInfoRequest)(nil),      // 8: cometsvc.GetConnectionInfoRequest
-	(*GetConnectionInfoResponse)(nil),     // 9: cometsvc.GetConnectionInfoResponse
-	(*CometRequest)(nil),                  // 10: cometsvc.CometRequest
-	(*CometResponse)(nil),                 // 11: cometsvc.CometResponse
-	(*messag.CometConfiguration)(nil),     // 12: messag.CometConfiguration
+	(*RpcError)(nil),                      // 1: cometsvc.RpcError
+	(*AuthToken)(nil),                     // 2: cometsvc.AuthToken
+	(*VerifyAuthTokenRequest)(nil),        // 3: cometsvc.VerifyAuthTokenRequest
+	(*VerifyAuthTokenResponse)(nil),       // 4: cometsvc.VerifyAuthTokenResponse
+	(*GetCometConfigurationRequest)(nil),  // 5: cometsvc.GetCometConfigurationRequest
+	(*GetCometConfigurationResponse)(nil), // 6: cometsvc.GetCometConfigurationResponse
+	(*BrokerRequest)(nil),                 // 7: cometsvc.BrokerRequest
+	(*BrokerResponse)(nil),                // 8: cometsvc.BrokerResponse
+	(*GetConnectionInfoRequest)(nil),      // 9: cometsvc.GetConnectionInfoRequest
+	(*GetConnectionInfoResponse)(nil),     // 10: cometsvc.GetConnectionInfoResponse
+	(*CometRequest)(nil),                  // 11: cometsvc.CometRequest
+	(*CometResponse)(nil),                 // 12: cometsvc.CometResponse
+	(*protocol.TokenClaims)(nil),          // 13: protocol.TokenClaims
+	(*messag.CometConfiguration)(nil),     // 14: messag.CometConfiguration
+	(*protocol.ConnectionDetail)(nil),     // 15: protocol.ConnectionDetail
 }
 var file_cometsvc_proto_depIdxs = []int32{
-	0,  // 0: cometsvc.VerifyAuthTokenResponse.code:type_name -> cometsvc.AuthToken.VerifyCode
-	1,  // 1: cometsvc.VerifyAuthTokenResponse.token:type_name -> cometsvc.AuthToken
-	12, // 2: cometsvc.GetCometConfigurationResponse.configuration:type_name -> messag.CometConfiguration
-	2,  // 3: cometsvc.BrokerRequest.VerifyAuthTokenRequest:type_name -> cometsvc.VerifyAuthTokenRequest
-	4,  // 4: cometsvc.BrokerRequest.GetCometConfigurationRequest:type_name -> cometsvc.GetCometConfigurationRequest
-	3,  // 5: cometsvc.BrokerResponse.VerifyAuthTokenResponse:type_name -> cometsvc.VerifyAuthTokenResponse
-	5,  // 6: cometsvc.BrokerResponse.GetCometConfigurationResponse:type_name -> cometsvc.GetCometConfigurationResponse
-	8,  // 7: cometsvc.CometRequest.GetConnectionInfoRequest:type_name -> cometsvc.GetConnectionInfoRequest
-	9,  // 8: cometsvc.CometResponse.GetConnectionInfoResponse:type_name -> cometsvc.GetConnectionInfoResponse
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	13, // 0: cometsvc.AuthToken.claims:type_name -> protocol.TokenClaims
+	0,  // 1: cometsvc.VerifyAuthTokenResponse.code:type_name -> cometsvc.AuthToken.VerifyCode
+	2,  // 2: cometsvc.VerifyAuthTokenResponse.token:type_name -> cometsvc.AuthToken
+	14, // 3: cometsvc.GetCometConfigurationResponse.configuration:type_name -> messag.CometConfiguration
+	3,  // 4: cometsvc.BrokerRequest.VerifyAuthTokenRequest:type_name -> cometsvc.VerifyAuthTokenRequest
+	5,  // 5: cometsvc.BrokerRequest.GetCometConfigurationRequest:type_name -> cometsvc.GetCometConfigurationRequest
+	4,  // 6: cometsvc.BrokerResponse.VerifyAuthTokenResponse:type_name -> cometsvc.VerifyAuthTokenResponse
+	6,  // 7: cometsvc.BrokerResponse.GetCometConfigurationResponse:type_name -> cometsvc.GetCometConfigurationResponse
+	1,  // 8: cometsvc.BrokerResponse.error:type_name -> cometsvc.RpcError
+	15, // 9: cometsvc.GetConnectionInfoResponse.detail:type_name -> protocol.ConnectionDetail
+	9,  // 10: cometsvc.CometRequest.GetConnectionInfoRequest:type_name -> cometsvc.GetConnectionInfoRequest
+	10, // 11: cometsvc.CometResponse.GetConnectionInfoResponse:type_name -> cometsvc.GetConnectionInfoResponse
+	1,  // 12: cometsvc.CometResponse.error:type_name -> cometsvc.RpcError
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_cometsvc_proto_init() }
@@ -870,7 +1030,7 @@ func file_cometsvc_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_cometsvc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthToken); i {
+			switch v := v.(*RpcError); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -882,7 +1042,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAuthTokenRequest); i {
+			switch v := v.(*AuthToken); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,7 +1054,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAuthTokenResponse); i {
+			switch v := v.(*VerifyAuthTokenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -906,7 +1066,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCometConfigurationRequest); i {
+			switch v := v.(*VerifyAuthTokenResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -918,7 +1078,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCometConfigurationResponse); i {
+			switch v := v.(*GetCometConfigurationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -930,7 +1090,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BrokerRequest); i {
+			switch v := v.(*GetCometConfigurationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -942,7 +1102,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BrokerResponse); i {
+			switch v := v.(*BrokerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -954,7 +1114,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConnectionInfoRequest); i {
+			switch v := v.(*BrokerResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -966,7 +1126,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConnectionInfoResponse); i {
+			switch v := v.(*GetConnectionInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -978,7 +1138,7 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CometRequest); i {
+			switch v := v.(*GetConnectionInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -990,6 +1150,18 @@ func file_cometsvc_proto_init() {
 			}
 		}
 		file_cometsvc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CometRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cometsvc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CometResponse); i {
 			case 0:
 				return &v.state
@@ -1002,18 +1174,18 @@ func file_cometsvc_proto_init() {
 			}
 		}
 	}
-	file_cometsvc_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_cometsvc_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*BrokerRequest_VerifyAuthTokenRequest)(nil),
 		(*BrokerRequest_GetCometConfigurationRequest)(nil),
 	}
-	file_cometsvc_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_cometsvc_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*BrokerResponse_VerifyAuthTokenResponse)(nil),
 		(*BrokerResponse_GetCometConfigurationResponse)(nil),
 	}
-	file_cometsvc_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_cometsvc_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*CometRequest_GetConnectionInfoRequest)(nil),
 	}
-	file_cometsvc_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_cometsvc_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*CometResponse_GetConnectionInfoResponse)(nil),
 	}
 	type x struct{}
@@ -1022,7 +1194,7 @@ func file_cometsvc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cometsvc_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
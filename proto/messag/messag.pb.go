@@ -202,6 +202,154 @@ func (*BroadcastMessage) Descriptor() ([]byte, []int) {
 	return file_messag_proto_rawDescGZIP(), []int{2}
 }
 
+// KickMessage tells a comet server to close the connections, eg. the
+// connections evicted for exceeding the per-user limit. The broker
+// reports DISCONNECT events for them, the comet must not report again.
+type KickMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConnIds []string `protobuf:"bytes,1,rep,name=conn_ids,json=connIds,proto3" json:"conn_ids,omitempty"`
+	Reason  string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *KickMessage) Reset() {
+	*x = KickMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messag_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KickMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickMessage) ProtoMessage() {}
+
+func (x *KickMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_messag_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickMessage.ProtoReflect.Descriptor instead.
+func (*KickMessage) Descriptor() ([]byte, []int) {
+	return file_messag_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *KickMessage) GetConnIds() []string {
+	if x != nil {
+		return x.ConnIds
+	}
+	return nil
+}
+
+func (x *KickMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CometHeartbeat is published by comet servers periodically to register
+// themselves to the brokers.
+type CometHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId     string `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Addr          string `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	StartTimeMsec int64  `protobuf:"varint,3,opt,name=start_time_msec,json=startTimeMsec,proto3" json:"start_time_msec,omitempty"`
+	TimeMsec      int64  `protobuf:"varint,4,opt,name=time_msec,json=timeMsec,proto3" json:"time_msec,omitempty"`
+	Connections   int64  `protobuf:"varint,5,opt,name=connections,proto3" json:"connections,omitempty"`
+	// Shutdown tells that the comet server is exiting gracefully.
+	Shutdown bool `protobuf:"varint,6,opt,name=shutdown,proto3" json:"shutdown,omitempty"`
+}
+
+func (x *CometHeartbeat) Reset() {
+	*x = CometHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_messag_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CometHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CometHeartbeat) ProtoMessage() {}
+
+func (x *CometHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_messag_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CometHeartbeat.ProtoReflect.Descriptor instead.
+func (*CometHeartbeat) Descriptor() ([]byte, []int) {
+	return file_messag_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CometHeartbeat) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *CometHeartbeat) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *CometHeartbeat) GetStartTimeMsec() int64 {
+	if x != nil {
+		return x.StartTimeMsec
+	}
+	return 0
+}
+
+func (x *CometHeartbeat) GetTimeMsec() int64 {
+	if x != nil {
+		return x.TimeMsec
+	}
+	return 0
+}
+
+func (x *CometHeartbeat) GetConnections() int64 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *CometHeartbeat) GetShutdown() bool {
+	if x != nil {
+		return x.Shutdown
+	}
+	return false
+}
+
 type TokenKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -209,12 +357,14 @@ type TokenKey struct {
 
 	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	EnableTimeSec int64  `protobuf:"varint,2,opt,name=enable_time_sec,json=enableTimeSec,proto3" json:"enable_time_sec,omitempty"`
+	KeyId         string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Algorithm     string `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
 }
 
 func (x *TokenKey) Reset() {
 	*x = TokenKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_messag_proto_msgTypes[3]
+		mi := &file_messag_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -227,7 +377,7 @@ func (x *TokenKey) String() string {
 func (*TokenKey) ProtoMessage() {}
 
 func (x *TokenKey) ProtoReflect() protoreflect.Message {
-	mi := &file_messag_proto_msgTypes[3]
+	mi := &file_messag_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +390,7 @@ func (x *TokenKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenKey.ProtoReflect.Descriptor instead.
 func (*TokenKey) Descriptor() ([]byte, []int) {
-	return file_messag_proto_rawDescGZIP(), []int{3}
+	return file_messag_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TokenKey) GetKey() string {
@@ -257,19 +407,41 @@ func (x *TokenKey) GetEnableTimeSec() int64 {
 	return 0
 }
 
+func (x *TokenKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *TokenKey) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+// CometConfiguration is returned by the broker RPC getCometConfiguration,
+// and published to comet servers when it changes.
 type CometConfiguration struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TokenKey     string      `protobuf:"bytes,1,opt,name=token_key,json=tokenKey,proto3" json:"token_key,omitempty"`
-	OldTokenKeys []*TokenKey `protobuf:"bytes,2,rep,name=old_token_keys,json=oldTokenKeys,proto3" json:"old_token_keys,omitempty"`
+	// The key currently used to sign tokens, base64 encoded.
+	// For algorithm "ed25519", it is the public key to verify tokens.
+	TokenKey string `protobuf:"bytes,1,opt,name=token_key,json=tokenKey,proto3" json:"token_key,omitempty"`
+	// Keys which are superseded but still valid to verify tokens signed
+	// before the rotation, and keys scheduled to be enabled in future.
+	OldTokenKeys      []*TokenKey `protobuf:"bytes,2,rep,name=old_token_keys,json=oldTokenKeys,proto3" json:"old_token_keys,omitempty"`
+	TokenKeyId        string      `protobuf:"bytes,3,opt,name=token_key_id,json=tokenKeyId,proto3" json:"token_key_id,omitempty"`
+	TokenKeyAlgorithm string      `protobuf:"bytes,4,opt,name=token_key_algorithm,json=tokenKeyAlgorithm,proto3" json:"token_key_algorithm,omitempty"`
 }
 
 func (x *CometConfiguration) Reset() {
 	*x = CometConfiguration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_messag_proto_msgTypes[4]
+		mi := &file_messag_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -282,7 +454,7 @@ func (x *CometConfiguration) String() string {
 func (*CometConfiguration) ProtoMessage() {}
 
 func (x *CometConfiguration) ProtoReflect() protoreflect.Message {
-	mi := &file_messag_proto_msgTypes[4]
+	mi := &file_messag_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +467,7 @@ func (x *CometConfiguration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CometConfiguration.ProtoReflect.Descriptor instead.
 func (*CometConfiguration) Descriptor() ([]byte, []int) {
-	return file_messag_proto_rawDescGZIP(), []int{4}
+	return file_messag_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CometConfiguration) GetTokenKey() string {
@@ -312,6 +484,20 @@ func (x *CometConfiguration) GetOldTokenKeys() []*TokenKey {
 	return nil
 }
 
+func (x *CometConfiguration) GetTokenKeyId() string {
+	if x != nil {
+		return x.TokenKeyId
+	}
+	return ""
+}
+
+func (x *CometConfiguration) GetTokenKeyAlgorithm() string {
+	if x != nil {
+		return x.TokenKeyAlgorithm
+	}
+	return ""
+}
+
 var File_messag_proto protoreflect.FileDescriptor
 
 var file_messag_proto_rawDesc = []byte{
@@ -334,21 +520,47 @@ var file_messag_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
 	0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
 	0x12, 0x0a, 0x10, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x08, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x5f, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x22, 0x69, 0x0a, 0x12, 0x43, 0x6f, 0x6d,
-	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x0e,
-	0x6f, 0x6c, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x2e, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x6f, 0x6c, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x4b, 0x65, 0x79, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73, 0x2f, 0x6e, 0x6f, 0x6e, 0x61, 0x6d,
-	0x65, 0x67, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x3b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x67, 0x65, 0x22, 0x40, 0x0a, 0x0b, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xc6, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x65, 0x74, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4d,
+	0x73, 0x65, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x65, 0x63,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x65, 0x63,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x22, 0x79,
+	0x0a, 0x08, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x65, 0x63, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0xbb, 0x01, 0x0a, 0x12, 0x43, 0x6f,
+	0x6d, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x36, 0x0a,
+	0x0e, 0x6f, 0x6c, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x2e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x6f, 0x6c, 0x64, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x6b,
+	0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x78, 0x73, 0x6b, 0x69, 0x73, 0x73, 0x2f, 0x6e, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x67, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x3b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -363,21 +575,23 @@ func file_messag_proto_rawDescGZIP() []byte {
 	return file_messag_proto_rawDescData
 }
 
-var file_messag_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_messag_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_messag_proto_goTypes = []interface{}{
 	(*UpgoingMessage)(nil),      // 0: messag.UpgoingMessage
 	(*DowngoingMessage)(nil),    // 1: messag.DowngoingMessage
 	(*BroadcastMessage)(nil),    // 2: messag.BroadcastMessage
-	(*TokenKey)(nil),            // 3: messag.TokenKey
-	(*CometConfiguration)(nil),  // 4: messag.CometConfiguration
-	(*protocol.Packet)(nil),     // 5: protocol.Packet
-	(*protocol.Connection)(nil), // 6: protocol.Connection
+	(*KickMessage)(nil),         // 3: messag.KickMessage
+	(*CometHeartbeat)(nil),      // 4: messag.CometHeartbeat
+	(*TokenKey)(nil),            // 5: messag.TokenKey
+	(*CometConfiguration)(nil),  // 6: messag.CometConfiguration
+	(*protocol.Packet)(nil),     // 7: protocol.Packet
+	(*protocol.Connection)(nil), // 8: protocol.Connection
 }
 var file_messag_proto_depIdxs = []int32{
-	5, // 0: messag.UpgoingMessage.packet:type_name -> protocol.Packet
-	6, // 1: messag.UpgoingMessage.conn:type_name -> protocol.Connection
-	5, // 2: messag.DowngoingMessage.packet:type_name -> protocol.Packet
-	3, // 3: messag.CometConfiguration.old_token_keys:type_name -> messag.TokenKey
+	7, // 0: messag.UpgoingMessage.packet:type_name -> protocol.Packet
+	8, // 1: messag.UpgoingMessage.conn:type_name -> protocol.Connection
+	7, // 2: messag.DowngoingMessage.packet:type_name -> protocol.Packet
+	5, // 3: messag.CometConfiguration.old_token_keys:type_name -> messag.TokenKey
 	4, // [4:4] is the sub-list for method output_type
 	4, // [4:4] is the sub-list for method input_type
 	4, // [4:4] is the sub-list for extension type_name
@@ -428,7 +642,7 @@ func file_messag_proto_init() {
 			}
 		}
 		file_messag_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenKey); i {
+			switch v := v.(*KickMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -440,6 +654,30 @@ func file_messag_proto_init() {
 			}
 		}
 		file_messag_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CometHeartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messag_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_messag_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CometConfiguration); i {
 			case 0:
 				return &v.state
@@ -462,7 +700,7 @@ func file_messag_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_messag_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},